@@ -0,0 +1,389 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/arnaubennassar/zkOnacci/contracts"
+	"github.com/arnaubennassar/zkOnacci/signer"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/iden3/go-merkletree"
+)
+
+// captureBundle is a self-contained capture of the position N of the sequence that can be generated on an offline
+// machine and broadcasted from another one. Numbers are encoded as 0x prefixed hex strings
+type captureBundle struct {
+	Contract    common.Address              `json:"contract"`
+	Puzzle      uint64                      `json:"puzzle"`
+	Sender      common.Address              `json:"sender"`
+	N           int                         `json:"n"`
+	CurrentRoot *math.HexOrDecimal256       `json:"currentRoot"`
+	NextRoot    *math.HexOrDecimal256       `json:"nextRoot"`
+	ProofA      [2]*math.HexOrDecimal256    `json:"proofA"`
+	ProofB      [2][2]*math.HexOrDecimal256 `json:"proofB"`
+	ProofC      [2]*math.HexOrDecimal256    `json:"proofC"`
+	// RawTx is an optional signed captureTheFlag tx
+	RawTx hexutil.Bytes `json:"rawTx,omitempty"`
+}
+
+// offlineTxConfig holds the parameters of a tx signed without access to a node. The fees are used as they are,
+// unlike the caps of txutil.FeeConfig
+type offlineTxConfig struct {
+	chainID  *big.Int
+	nonce    uint64
+	gasLimit uint64
+	// gasPrice is set for legacy txs, otherwise maxFeePerGas and maxPriorityFeePerGas are set
+	gasPrice             *big.Int
+	maxFeePerGas         *big.Int
+	maxPriorityFeePerGas *big.Int
+}
+
+// offlineTxConfigFromEnv reads the parameters of the signed tx from the env vars CHAIN_ID, NONCE, GAS_LIMIT and either
+// TX_GAS_PRICE (legacy tx) or TX_MAX_FEE_PER_GAS and TX_MAX_PRIORITY_FEE_PER_GAS (dynamic fee tx)
+func offlineTxConfigFromEnv() (offlineTxConfig, error) {
+	var cfg offlineTxConfig
+	readBigInt := func(name string, required bool) (*big.Int, error) {
+		str := os.Getenv(name)
+		if str == "" {
+			if required {
+				return nil, fmt.Errorf("must provide the env var %s to sign the tx", name)
+			}
+			return nil, nil
+		}
+		n, ok := new(big.Int).SetString(str, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid %s: %s", name, str)
+		}
+		return n, nil
+	}
+	var err error
+	if cfg.chainID, err = readBigInt("CHAIN_ID", true); err != nil {
+		return cfg, err
+	}
+	nonce, err := readBigInt("NONCE", true)
+	if err != nil {
+		return cfg, err
+	}
+	cfg.nonce = nonce.Uint64()
+	gasLimit, err := readBigInt("GAS_LIMIT", true)
+	if err != nil {
+		return cfg, err
+	}
+	cfg.gasLimit = gasLimit.Uint64()
+	if cfg.gasPrice, err = readBigInt("TX_GAS_PRICE", false); err != nil {
+		return cfg, err
+	}
+	if cfg.gasPrice != nil {
+		if os.Getenv("TX_MAX_FEE_PER_GAS") != "" || os.Getenv("TX_MAX_PRIORITY_FEE_PER_GAS") != "" {
+			return cfg, errors.New("TX_GAS_PRICE can't be combined with TX_MAX_FEE_PER_GAS and TX_MAX_PRIORITY_FEE_PER_GAS")
+		}
+		return cfg, nil
+	}
+	if cfg.maxFeePerGas, err = readBigInt("TX_MAX_FEE_PER_GAS", true); err != nil {
+		return cfg, err
+	}
+	if cfg.maxPriorityFeePerGas, err = readBigInt("TX_MAX_PRIORITY_FEE_PER_GAS", true); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// newCaptureBundle builds a bundle from a prepared capture
+func newCaptureBundle(scAddr, sender common.Address, prepared *preparedCapture) *captureBundle {
+	b := &captureBundle{
+		Contract:    scAddr,
+		Puzzle:      prepared.puzzle,
+		Sender:      sender,
+		N:           prepared.n,
+		CurrentRoot: (*math.HexOrDecimal256)(prepared.currentRoot.BigInt()),
+		NextRoot:    (*math.HexOrDecimal256)(prepared.nextRoot.BigInt()),
+	}
+	for i := 0; i < 2; i++ {
+		b.ProofA[i] = (*math.HexOrDecimal256)(prepared.proofA[i])
+		b.ProofC[i] = (*math.HexOrDecimal256)(prepared.proofC[i])
+		for j := 0; j < 2; j++ {
+			b.ProofB[i][j] = (*math.HexOrDecimal256)(prepared.proofB[i][j])
+		}
+	}
+	return b
+}
+
+// prepared returns the capture held by the bundle
+func (b *captureBundle) prepared() (*preparedCapture, error) {
+	toBigInt := func(n *math.HexOrDecimal256) (*big.Int, error) {
+		if n == nil {
+			return nil, errors.New("incomplete bundle")
+		}
+		return (*big.Int)(n), nil
+	}
+	prepared := &preparedCapture{puzzle: b.Puzzle, n: b.N}
+	currentRoot, err := toBigInt(b.CurrentRoot)
+	if err != nil {
+		return nil, err
+	}
+	nextRoot, err := toBigInt(b.NextRoot)
+	if err != nil {
+		return nil, err
+	}
+	prepared.currentRoot = merkletree.NewHashFromBigInt(currentRoot)
+	prepared.nextRoot = merkletree.NewHashFromBigInt(nextRoot)
+	for i := 0; i < 2; i++ {
+		if prepared.proofA[i], err = toBigInt(b.ProofA[i]); err != nil {
+			return nil, err
+		}
+		if prepared.proofC[i], err = toBigInt(b.ProofC[i]); err != nil {
+			return nil, err
+		}
+		for j := 0; j < 2; j++ {
+			if prepared.proofB[i][j], err = toBigInt(b.ProofB[i][j]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return prepared, nil
+}
+
+// sign adds a captureTheFlag tx signed by s to the bundle
+func (b *captureBundle) sign(s signer.Signer, cfg offlineTxConfig) error {
+	if s.Address() != b.Sender {
+		return fmt.Errorf("the proof is bound to %s, it can't be sent by %s", b.Sender.Hex(), s.Address().Hex())
+	}
+	data, err := b.calldata()
+	if err != nil {
+		return err
+	}
+	to := b.Contract
+	var tx *types.Transaction
+	if cfg.gasPrice != nil {
+		tx = types.NewTx(&types.LegacyTx{
+			Nonce:    cfg.nonce,
+			GasPrice: cfg.gasPrice,
+			Gas:      cfg.gasLimit,
+			To:       &to,
+			Value:    big.NewInt(0),
+			Data:     data,
+		})
+	} else {
+		tx = types.NewTx(&types.DynamicFeeTx{
+			ChainID:   cfg.chainID,
+			Nonce:     cfg.nonce,
+			GasTipCap: cfg.maxPriorityFeePerGas,
+			GasFeeCap: cfg.maxFeePerGas,
+			Gas:       cfg.gasLimit,
+			To:        &to,
+			Value:     big.NewInt(0),
+			Data:      data,
+		})
+	}
+	signed, err := s.SignTx(tx, cfg.chainID)
+	if err != nil {
+		return err
+	}
+	b.RawTx, err = signed.MarshalBinary()
+	return err
+}
+
+// calldata returns the captureTheFlag calldata of the bundle
+func (b *captureBundle) calldata() ([]byte, error) {
+	prepared, err := b.prepared()
+	if err != nil {
+		return nil, err
+	}
+	zkOnacciABI, err := abi.JSON(strings.NewReader(contracts.ZKOnacciABI))
+	if err != nil {
+		return nil, err
+	}
+	return zkOnacciABI.Pack("captureTheFlag", prepared.puzzleID(), prepared.proofA, prepared.proofB, prepared.proofC, prepared.nextRoot.BigInt())
+}
+
+// tx decodes the signed tx of the bundle and checks that it matches the rest of the bundle
+func (b *captureBundle) tx() (*types.Transaction, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(b.RawTx); err != nil {
+		return nil, err
+	}
+	if tx.To() == nil || *tx.To() != b.Contract {
+		return nil, errors.New("the signed tx is not sent to the contract of the bundle")
+	}
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return nil, err
+	}
+	if from != b.Sender {
+		return nil, fmt.Errorf("the signed tx is sent by %s instead of %s", from.Hex(), b.Sender.Hex())
+	}
+	if err := b.checkCalldata(tx.Data()); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// checkCalldata checks that data calls captureTheFlag with the puzzle, the proof and the next root of the bundle
+func (b *captureBundle) checkCalldata(data []byte) error {
+	zkOnacciABI, err := abi.JSON(strings.NewReader(contracts.ZKOnacciABI))
+	if err != nil {
+		return err
+	}
+	if len(data) < 4 {
+		return errors.New("the signed tx doesn't call captureTheFlag")
+	}
+	method, err := zkOnacciABI.MethodById(data[:4])
+	if err != nil || method.Name != "captureTheFlag" {
+		return errors.New("the signed tx doesn't call captureTheFlag")
+	}
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return err
+	}
+	puzzle, okPuzzle := args[0].(*big.Int)
+	proofA, okA := args[1].([2]*big.Int)
+	proofB, okB := args[2].([2][2]*big.Int)
+	proofC, okC := args[3].([2]*big.Int)
+	nextRoot, okRoot := args[4].(*big.Int)
+	if !okPuzzle || !okA || !okB || !okC || !okRoot {
+		return errors.New("unexpected captureTheFlag arguments in the signed tx")
+	}
+	prepared, err := b.prepared()
+	if err != nil {
+		return err
+	}
+	signed := captureArgs(puzzle, proofA, proofB, proofC, nextRoot)
+	for i, expected := range captureArgs(prepared.puzzleID(), prepared.proofA, prepared.proofB, prepared.proofC, prepared.nextRoot.BigInt()) {
+		if signed[i].Cmp(expected) != 0 {
+			return errors.New("the puzzle, the proof or the next root of the signed tx don't match the bundle")
+		}
+	}
+	return nil
+}
+
+// captureArgs flattens the arguments of captureTheFlag
+func captureArgs(puzzle *big.Int, proofA [2]*big.Int, proofB [2][2]*big.Int, proofC [2]*big.Int, nextRoot *big.Int) []*big.Int {
+	return []*big.Int{puzzle, proofA[0], proofA[1], proofB[0][0], proofB[0][1], proofB[1][0], proofB[1][1], proofC[0], proofC[1], nextRoot}
+}
+
+func (b *captureBundle) save(path string) error {
+	bundleJSON, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, bundleJSON, 0644)
+}
+
+func loadCaptureBundle(path string) (*captureBundle, error) {
+	bundleJSON, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	b := &captureBundle{}
+	if err := json.Unmarshal(bundleJSON, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// exportBundle proves the position n of the sequence of the puzzle of cfg for sender without connecting to any node.
+// If s is not nil, the bundle also includes a captureTheFlag tx signed according to txCfg
+func exportBundle(
+	path string,
+	scAddr, sender common.Address,
+	n int,
+	s signer.Signer,
+	txCfg offlineTxConfig,
+	cfg captureConfig,
+) (*captureBundle, error) {
+	seq, err := newSequence(cfg.recurrence, cfg.nLevels)
+	if err != nil {
+		return nil, err
+	}
+	if n < seq.first {
+		return nil, fmt.Errorf("invalid position %d, the first position to prove is %d", n, seq.first)
+	}
+	if err := seq.advance(n); err != nil {
+		return nil, err
+	}
+	prepared, err := prepareCapture(seq, cfg.puzzle, sender, cfg.artifactsPath)
+	if err != nil {
+		return nil, err
+	}
+	b := newCaptureBundle(scAddr, sender, prepared)
+	if s != nil {
+		if err := b.sign(s, txCfg); err != nil {
+			return nil, err
+		}
+	}
+	return b, b.save(path)
+}
+
+// broadcastBundle checks that the bundle still proves the next position against the live root of its puzzle and sends it:
+// the signed tx of the bundle if any, otherwise a new tx signed by s
+func broadcastBundle(
+	ctx context.Context,
+	backend captureBackend,
+	zkOnacci *contracts.ZKOnacci,
+	b *captureBundle,
+	s signer.Signer,
+	cfg captureConfig,
+) (*types.Transaction, *types.Receipt, error) {
+	prepared, err := b.prepared()
+	if err != nil {
+		return nil, nil, err
+	}
+	root, err := zkOnacci.Root(&bind.CallOpts{Context: ctx}, prepared.puzzleID())
+	if err != nil {
+		return nil, nil, err
+	}
+	if root.Cmp(prepared.currentRoot.BigInt()) != 0 {
+		return nil, nil, fmt.Errorf("%w: the bundle proves n = %d against root %s, but the current root is %s",
+			errRootMoved, b.N, prepared.currentRoot.BigInt(), root)
+	}
+	var tx *types.Transaction
+	if len(b.RawTx) > 0 {
+		if tx, err = b.tx(); err != nil {
+			return nil, nil, err
+		}
+		nonce, err := backend.NonceAt(ctx, b.Sender, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		if tx.Nonce() < nonce {
+			return nil, nil, fmt.Errorf("the nonce of the signed tx (%d) has already been used, the next nonce of %s is %d",
+				tx.Nonce(), b.Sender.Hex(), nonce)
+		}
+		if err := backend.SendTransaction(ctx, tx); err != nil {
+			return nil, nil, err
+		}
+	} else {
+		if s == nil {
+			return nil, nil, errors.New("the bundle doesn't include a signed tx, a signer is needed to send it")
+		}
+		if s.Address() != b.Sender {
+			return nil, nil, fmt.Errorf("the proof is bound to %s, it can't be sent by %s", b.Sender.Hex(), s.Address().Hex())
+		}
+		if tx, err = sendCapture(ctx, backend, zkOnacci, s, cfg.feeConfig, prepared, nil); err != nil {
+			return nil, nil, err
+		}
+	}
+	fmt.Println("Tx sent to the blockchain. Tx Hash:", tx.Hash())
+	receipt, err := waitForCapture(ctx, backend, zkOnacci, tx, prepared, cfg.confirmations)
+	return tx, receipt, err
+}
+
+// parseSender reads the address bound to the proof of an exported bundle from the env var SENDER
+func parseSender() (common.Address, error) {
+	senderHex := os.Getenv("SENDER")
+	if !common.IsHexAddress(senderHex) {
+		return common.Address{}, fmt.Errorf("invalid SENDER: %q", senderHex)
+	}
+	return common.HexToAddress(senderHex), nil
+}
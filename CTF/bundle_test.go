@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/arnaubennassar/zkOnacci/signer"
+	"github.com/arnaubennassar/zkOnacci/testutil"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/iden3/go-merkletree"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCaptureBundle(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	s := signer.NewKeySigner(privateKey)
+	scAddr := common.HexToAddress("0x36E9CA815e61d1C7a171E638Af5681e4aB8ACc65")
+	prepared := &preparedCapture{
+		puzzle:      2,
+		n:           5,
+		currentRoot: merkletree.NewHashFromBigInt(big.NewInt(1234)),
+		nextRoot:    merkletree.NewHashFromBigInt(big.NewInt(5678)),
+		proofA:      [2]*big.Int{big.NewInt(1), big.NewInt(2)},
+		proofB:      [2][2]*big.Int{{big.NewInt(3), big.NewInt(4)}, {big.NewInt(5), big.NewInt(6)}},
+		proofC:      [2]*big.Int{big.NewInt(7), big.NewInt(8)},
+	}
+	b := newCaptureBundle(scAddr, s.Address(), prepared)
+	txCfg := offlineTxConfig{
+		chainID:              big.NewInt(1337),
+		nonce:                7,
+		gasLimit:             500000,
+		maxFeePerGas:         big.NewInt(2000000000),
+		maxPriorityFeePerGas: big.NewInt(1000000000),
+	}
+	require.NoError(t, b.sign(s, txCfg))
+	// Save and load
+	path := filepath.Join(t.TempDir(), "bundle.json")
+	require.NoError(t, b.save(path))
+	loaded, err := loadCaptureBundle(path)
+	require.NoError(t, err)
+	assert.Equal(t, b, loaded)
+	loadedPrepared, err := loaded.prepared()
+	require.NoError(t, err)
+	assert.Equal(t, prepared, loadedPrepared)
+	// Signed tx
+	tx, err := loaded.tx()
+	require.NoError(t, err)
+	assert.Equal(t, uint8(types.DynamicFeeTxType), tx.Type())
+	assert.Equal(t, uint64(7), tx.Nonce())
+	assert.Equal(t, uint64(500000), tx.Gas())
+	assert.Equal(t, scAddr, *tx.To())
+	// The puzzle, the proof and the next root of the signed tx must match the bundle
+	tampered := *loaded
+	tampered.Puzzle = 0
+	_, err = tampered.tx()
+	assert.Error(t, err)
+	tampered = *loaded
+	tampered.NextRoot = (*math.HexOrDecimal256)(big.NewInt(1))
+	_, err = tampered.tx()
+	assert.Error(t, err)
+	tampered = *loaded
+	tampered.ProofB[1][0] = (*math.HexOrDecimal256)(big.NewInt(1))
+	_, err = tampered.tx()
+	assert.Error(t, err)
+	// The proof is bound to the sender, other accounts can't sign the tx
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	assert.Error(t, b.sign(signer.NewKeySigner(otherKey), txCfg))
+	loaded.Sender = crypto.PubkeyToAddress(otherKey.PublicKey)
+	_, err = loaded.tx()
+	assert.Error(t, err)
+	// Legacy tx
+	txCfg.gasPrice = big.NewInt(2000000000)
+	txCfg.maxFeePerGas, txCfg.maxPriorityFeePerGas = nil, nil
+	require.NoError(t, b.sign(s, txCfg))
+	tx, err = b.tx()
+	require.NoError(t, err)
+	assert.Equal(t, uint8(types.LegacyTxType), tx.Type())
+	assert.Equal(t, big.NewInt(1337), tx.ChainId())
+	assert.Equal(t, txCfg.gasPrice, tx.GasPrice())
+}
+
+func TestBroadcastStaleBundle(t *testing.T) {
+	env := newCaptureEnv(t)
+	ctx := context.Background()
+	cfg := env.captureConfig()
+	b, err := exportBundle(filepath.Join(t.TempDir(), "bundle.json"), env.scAddr, env.player.Address(), 2, env.player, offlineTxConfig{
+		chainID:              big.NewInt(testutil.ChainID),
+		gasLimit:             500000,
+		maxFeePerGas:         big.NewInt(10000000000),
+		maxPriorityFeePerGas: big.NewInt(1),
+	}, cfg)
+	require.NoError(t, err)
+	// Another player captures the flag proven by the bundle
+	env.captureFirstFlag(t)
+	_, _, err = broadcastBundle(ctx, env.backend, env.zkOnacci, b, nil, cfg)
+	assert.True(t, errors.Is(err, errRootMoved))
+	// Nothing has been sent
+	nonce, err := env.sim.PendingNonceAt(ctx, env.player.Address())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), nonce)
+}
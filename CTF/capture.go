@@ -0,0 +1,362 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/arnaubennassar/zkOnacci/contracts"
+	"github.com/arnaubennassar/zkOnacci/recurrence"
+	"github.com/arnaubennassar/zkOnacci/signer"
+	"github.com/arnaubennassar/zkOnacci/txutil"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/iden3/go-merkletree"
+)
+
+// errRootMoved is returned when the root of the SC changes before a captureTheFlag tx is mined,
+// which means that another player has captured the flag first
+var errRootMoved = errors.New("the root of the SC has changed, another player captured the flag first")
+
+// errCaptureLimit is returned when the player has captured the max amount of flags of the season
+var errCaptureLimit = errors.New("CAPTURE_LIMIT_REACHED")
+
+// errTierCaptureLimit is returned when the player has captured the max amount of flags of the tier of the next flag
+var errTierCaptureLimit = errors.New("TIER_CAPTURE_LIMIT_REACHED")
+
+// captureBackend is the functionality needed to send captureTheFlag txs and wait for them
+type captureBackend interface {
+	txutil.Backend
+	txutil.WaitBackend
+}
+
+// captureConfig holds the limits of the capture loop
+type captureConfig struct {
+	// maxRetries is the amount of times that the flag will be re-proved after losing the race against another player
+	maxRetries int
+	// timeout is the max time that the capture loop can take
+	timeout time.Duration
+	// confirmations is the amount of blocks (including the one that mines the tx) to wait for
+	confirmations uint64
+	// artifactsPath is the path of the circom artifacts used to generate proofs
+	artifactsPath string
+	// nLevels is the amount of levels of the MT of the circuit
+	nLevels int
+	// recurrence is the puzzle variant, nil for the zkOnacci circuit
+	recurrence *recurrence.Recurrence
+	// puzzle is the ID of the puzzle of the SC to play
+	puzzle uint64
+	// feeConfig sets how the gas and fees of the captureTheFlag txs are calculated
+	feeConfig txutil.FeeConfig
+}
+
+// puzzleID returns the ID of the puzzle as a contract argument
+func (cfg captureConfig) puzzleID() *big.Int {
+	return new(big.Int).SetUint64(cfg.puzzle)
+}
+
+// captureFlag proves the next number of the sequence and sends it to the SC. If another player captures the flag
+// before the tx is mined, the local tree is advanced, the proof is regenerated and the tx is resent
+// (replacing the pending one if it hasn't been mined yet)
+func captureFlag(
+	backend captureBackend,
+	zkOnacci *contracts.ZKOnacci,
+	s signer.Signer,
+	seq *sequence,
+	cfg captureConfig,
+) (*types.Transaction, *types.Receipt, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.timeout)
+	defer cancel()
+	fromAddress := s.Address()
+	var pending *types.Transaction
+	for retries := 0; ; retries++ {
+		// Sync the local tree with the SC
+		callOpts := &bind.CallOpts{Context: ctx}
+		nMintedTokens, err := zkOnacci.TokenCounter(callOpts, cfg.puzzleID())
+		if err != nil {
+			return nil, nil, err
+		}
+		fmt.Println(nMintedTokens, " tokens already minted")
+		if err := seq.advance(int(nMintedTokens.Int64()) + seq.first); err != nil {
+			return nil, nil, err
+		}
+		root, err := zkOnacci.Root(callOpts, cfg.puzzleID())
+		if err != nil {
+			return nil, nil, err
+		}
+		if root.Cmp(seq.root().BigInt()) != 0 {
+			// The root may have changed between both calls, try again
+			if retries < cfg.maxRetries {
+				continue
+			}
+			return nil, nil, fmt.Errorf("local root %s doesn't match the root of the SC %s", seq.root().BigInt(), root)
+		}
+		// Don't prove a flag that the SC won't mint to the player
+		if err := checkCaptureLimits(callOpts, zkOnacci, cfg.puzzleID(), fromAddress, nMintedTokens.Uint64(), false); err != nil {
+			return nil, nil, err
+		}
+		// Calculate proof
+		prepared, err := prepareCapture(seq, cfg.puzzle, fromAddress, cfg.artifactsPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		// Send tx, replacing the previous one if it's still pending
+		if pending != nil {
+			mined, err := txMined(ctx, backend, pending)
+			if err != nil {
+				return nil, nil, err
+			}
+			if mined {
+				// The previous tx has been mined in the meantime, there is nothing to replace
+				pending = nil
+			}
+		}
+		tx, err := sendCapture(ctx, backend, zkOnacci, s, cfg.feeConfig, prepared, pending)
+		if err != nil {
+			// The gas estimation fails if the flag has just been captured by another player
+			moved, rootErr := rootMoved(ctx, zkOnacci, prepared)
+			if rootErr != nil || !moved {
+				return nil, nil, err
+			}
+			if retries >= cfg.maxRetries {
+				return nil, nil, fmt.Errorf("%w before sending the tx, max retries (%d) reached", errRootMoved, cfg.maxRetries)
+			}
+			fmt.Println(errRootMoved, "before sending the tx, generating a new proof")
+			continue
+		}
+		if pending != nil {
+			fmt.Println("Replacement tx sent to the blockchain. Tx Hash:", tx.Hash(), ", replaces:", pending.Hash())
+		} else {
+			fmt.Println("Tx sent to the blockchain. Tx Hash:", tx.Hash())
+		}
+		// Wait for the tx to be mined
+		receipt, err := waitForCapture(ctx, backend, zkOnacci, tx, prepared, cfg.confirmations)
+		if !errors.Is(err, errRootMoved) {
+			return tx, receipt, err
+		}
+		if retries >= cfg.maxRetries {
+			return tx, receipt, fmt.Errorf("%w, max retries (%d) reached", err, cfg.maxRetries)
+		}
+		fmt.Println(err, ", generating a new proof")
+		if receipt == nil {
+			pending = tx
+		} else {
+			pending = nil
+		}
+	}
+}
+
+// checkCaptureLimits returns errCaptureLimit or errTierCaptureLimit if the limits of the current season of the puzzle
+// don't allow player to capture the token of the position index. If afterPending is set, the capture of the position
+// index - 1 by player is assumed to succeed
+func checkCaptureLimits(
+	callOpts *bind.CallOpts,
+	zkOnacci *contracts.ZKOnacci,
+	puzzle *big.Int,
+	player common.Address,
+	index uint64,
+	afterPending bool,
+) error {
+	limits, err := zkOnacci.CaptureLimits(callOpts, puzzle)
+	if err != nil {
+		return err
+	}
+	pending := int64(0)
+	if afterPending {
+		pending = 1
+	}
+	if limits.MaxCaptures.Sign() > 0 {
+		captures, err := zkOnacci.Captures(callOpts, puzzle, player)
+		if err != nil {
+			return err
+		}
+		if captures.Add(captures, big.NewInt(pending)).Cmp(limits.MaxCaptures) >= 0 {
+			return fmt.Errorf("%w: %s can capture %s flags of the season", errCaptureLimit, player.Hex(), limits.MaxCaptures)
+		}
+	}
+	if len(limits.MaxTierCaptures) == 0 {
+		return nil
+	}
+	tokenTiers, err := readTokenTiers(callOpts, zkOnacci, puzzle)
+	if err != nil {
+		return err
+	}
+	tier := tierOf(index, tokenTiers)
+	maxTierCaptures := limits.MaxTierCaptures[tier]
+	if maxTierCaptures.Sign() == 0 {
+		return nil
+	}
+	tierCaptures, err := zkOnacci.TierCaptures(callOpts, puzzle, player, uint8(tier))
+	if err != nil {
+		return err
+	}
+	if afterPending && index > 0 && tierOf(index-1, tokenTiers) == tier {
+		tierCaptures.Add(tierCaptures, big.NewInt(pending))
+	}
+	if tierCaptures.Cmp(maxTierCaptures) >= 0 {
+		return fmt.Errorf("%w: %s can capture %s flags of the tier %d", errTierCaptureLimit, player.Hex(), maxTierCaptures, tier)
+	}
+	return nil
+}
+
+// preparedCapture holds a proof of the position n of the sequence of a puzzle, ready to be sent to the SC
+type preparedCapture struct {
+	puzzle      uint64
+	n           int
+	currentRoot *merkletree.Hash
+	nextRoot    *merkletree.Hash
+	proofA      [2]*big.Int
+	proofB      [2][2]*big.Int
+	proofC      [2]*big.Int
+}
+
+// prepareCapture adds the next number of the sequence to the tree and generates the proof for it
+func prepareCapture(seq *sequence, puzzle uint64, sender common.Address, artifactsPath string) (*preparedCapture, error) {
+	input, currentRoot, nextRoot, err := seq.nextInput(sender)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Println("Generating proof for n =", input.N)
+	proofA, proofB, proofC, err := seq.generateProof(input, artifactsPath)
+	if err != nil {
+		return nil, err
+	}
+	return &preparedCapture{
+		puzzle:      puzzle,
+		n:           input.N,
+		currentRoot: currentRoot,
+		nextRoot:    nextRoot,
+		proofA:      proofA,
+		proofB:      proofB,
+		proofC:      proofC,
+	}, nil
+}
+
+// puzzleID returns the ID of the puzzle of the capture as a contract argument
+func (p *preparedCapture) puzzleID() *big.Int {
+	return new(big.Int).SetUint64(p.puzzle)
+}
+
+// sendCapture sends a captureTheFlag tx with the prepared proof. If replace is not nil, the tx will replace it
+func sendCapture(
+	ctx context.Context,
+	backend captureBackend,
+	zkOnacci *contracts.ZKOnacci,
+	s signer.Signer,
+	feeConfig txutil.FeeConfig,
+	prepared *preparedCapture,
+	replace *types.Transaction,
+) (*types.Transaction, error) {
+	auth, err := captureTransactOpts(ctx, backend, s, feeConfig, replace)
+	if err != nil {
+		return nil, err
+	}
+	return txutil.Send(auth, feeConfig, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return zkOnacci.CaptureTheFlag(opts, prepared.puzzleID(), prepared.proofA, prepared.proofB, prepared.proofC, prepared.nextRoot.BigInt())
+	})
+}
+
+// captureTransactOpts returns the options to send a captureTheFlag tx. If replace is not nil, the returned options
+// will use its nonce and fees high enough to replace it
+func captureTransactOpts(
+	ctx context.Context,
+	backend captureBackend,
+	s signer.Signer,
+	feeConfig txutil.FeeConfig,
+	replace *types.Transaction,
+) (*bind.TransactOpts, error) {
+	auth, err := txutil.NewTransactOpts(ctx, backend, s, feeConfig)
+	if err != nil {
+		return nil, err
+	}
+	if replace != nil {
+		txutil.BumpFees(auth, replace)
+		auth.Nonce = new(big.Int).SetUint64(replace.Nonce())
+	} else {
+		nonce, err := backend.PendingNonceAt(ctx, auth.From)
+		if err != nil {
+			return nil, err
+		}
+		auth.Nonce = new(big.Int).SetUint64(nonce)
+	}
+	return auth, nil
+}
+
+// waitForCapture waits for a captureTheFlag tx to be mined and confirmed. While the tx is pending, the root of the puzzle
+// is polled and errRootMoved is returned (with a nil receipt) if it doesn't match the current root of prepared anymore.
+// errRootMoved is also returned (along with the receipt) if the tx reverts because the root changed,
+// otherwise reverted txs are returned along with a *txutil.TxFailedError
+func waitForCapture(
+	ctx context.Context,
+	backend captureBackend,
+	zkOnacci *contracts.ZKOnacci,
+	tx *types.Transaction,
+	prepared *preparedCapture,
+	confirmations uint64,
+) (*types.Receipt, error) {
+	for {
+		mined, err := txMined(ctx, backend, tx)
+		if err != nil {
+			return nil, err
+		}
+		if mined {
+			break
+		}
+		moved, err := rootMoved(ctx, zkOnacci, prepared)
+		if err != nil {
+			return nil, err
+		}
+		if moved {
+			// The root may have been moved by the tx itself, if it has been mined after checking the receipt
+			if mined, err = txMined(ctx, backend, tx); err != nil {
+				return nil, err
+			}
+			if mined {
+				break
+			}
+			return nil, errRootMoved
+		}
+		fmt.Println("tx not mined yet")
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(txutil.DefaultPollInterval):
+		}
+	}
+	receipt, err := txutil.WaitMined(ctx, backend, tx, waitConfig(confirmations))
+	var failed *txutil.TxFailedError
+	if errors.As(err, &failed) {
+		// Check if the tx failed because another player captured the flag first
+		root, rootErr := zkOnacci.Root(&bind.CallOpts{Context: ctx, BlockNumber: receipt.BlockNumber}, prepared.puzzleID())
+		if rootErr != nil {
+			return nil, rootErr
+		}
+		if root.Cmp(prepared.currentRoot.BigInt()) != 0 {
+			return receipt, errRootMoved
+		}
+	}
+	return receipt, err
+}
+
+// txMined returns true if tx has been mined
+func txMined(ctx context.Context, backend txutil.WaitBackend, tx *types.Transaction) (bool, error) {
+	receipt, err := backend.TransactionReceipt(ctx, tx.Hash())
+	if errors.Is(err, ethereum.NotFound) {
+		return false, nil
+	}
+	// Some backends (e.g. the simulated one) return a nil receipt instead of NotFound
+	return err == nil && receipt != nil, err
+}
+
+// rootMoved returns true if the root of the puzzle of prepared doesn't match its current root anymore
+func rootMoved(ctx context.Context, zkOnacci *contracts.ZKOnacci, prepared *preparedCapture) (bool, error) {
+	root, err := zkOnacci.Root(&bind.CallOpts{Context: ctx}, prepared.puzzleID())
+	if err != nil {
+		return false, err
+	}
+	return root.Cmp(prepared.currentRoot.BigInt()) != 0, nil
+}
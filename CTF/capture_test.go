@@ -0,0 +1,242 @@
+package main
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/arnaubennassar/zkOnacci/contracts"
+	"github.com/arnaubennassar/zkOnacci/game"
+	"github.com/arnaubennassar/zkOnacci/signer"
+	"github.com/arnaubennassar/zkOnacci/testutil"
+	"github.com/arnaubennassar/zkOnacci/txutil"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// raceBackend calls the hooks after the receipt and pending nonce queries and the txs of the player,
+// so the tests can mine blocks and capture flags with other accounts in between
+type raceBackend struct {
+	testutil.SimulatedBackend
+	afterReceipt      func()
+	afterPendingNonce func()
+	afterSend         func(tx *types.Transaction)
+}
+
+func (b raceBackend) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	receipt, err := b.SimulatedBackend.TransactionReceipt(ctx, txHash)
+	if b.afterReceipt != nil {
+		b.afterReceipt()
+	}
+	return receipt, err
+}
+
+func (b raceBackend) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	nonce, err := b.SimulatedBackend.PendingNonceAt(ctx, account)
+	if b.afterPendingNonce != nil {
+		b.afterPendingNonce()
+	}
+	return nonce, err
+}
+
+func (b raceBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	err := b.SimulatedBackend.SendTransaction(ctx, tx)
+	if err == nil && b.afterSend != nil {
+		b.afterSend(tx)
+	}
+	return err
+}
+
+type captureEnv struct {
+	sim          testutil.SimulatedBackend
+	backend      *raceBackend
+	verifierAddr common.Address
+	scAddr       common.Address
+	zkOnacci     *contracts.ZKOnacci
+	ownerAuth    *bind.TransactOpts
+	player       signer.Signer
+	otherAuth    *bind.TransactOpts
+}
+
+// newCaptureEnv deploys the contracts and funds the player and another account
+func newCaptureEnv(t *testing.T) *captureEnv {
+	ownerKey, playerKey, otherKey := testutil.NewKey(t), testutil.NewKey(t), testutil.NewKey(t)
+	sim := testutil.NewSimulatedBackend(ownerKey, playerKey, otherKey)
+	ownerAuth := testutil.NewTransactor(t, ownerKey)
+	verifierAddr, scAddr, _ := testutil.Deploy(t, sim, ownerAuth)
+	backend := &raceBackend{SimulatedBackend: sim}
+	zkOnacci, err := contracts.NewZKOnacci(scAddr, backend)
+	require.NoError(t, err)
+	return &captureEnv{
+		sim:          sim,
+		backend:      backend,
+		verifierAddr: verifierAddr,
+		scAddr:       scAddr,
+		zkOnacci:     zkOnacci,
+		ownerAuth:    ownerAuth,
+		player:       signer.NewKeySigner(playerKey),
+		otherAuth:    testutil.NewTransactor(t, otherKey),
+	}
+}
+
+// captureFirstFlag mines the capture of the first flag by the other account
+func (env *captureEnv) captureFirstFlag(t *testing.T) {
+	capture := testutil.ProveFirstCapture(t, env.otherAuth.From)
+	_, err := env.zkOnacci.CaptureTheFlag(env.otherAuth, big.NewInt(0), capture.ProofA, capture.ProofB, capture.ProofC, capture.NextRoot)
+	require.NoError(t, err)
+	env.sim.Commit()
+}
+
+// captureConfig returns the capture settings of the tests
+func (env *captureEnv) captureConfig() captureConfig {
+	return captureConfig{
+		maxRetries:    3,
+		timeout:       time.Minute,
+		confirmations: 1,
+		artifactsPath: testutil.ArtifactsPath,
+		nLevels:       testutil.NLevels,
+		feeConfig:     txutil.FeeConfig{GasMargin: txutil.DefaultGasMargin},
+	}
+}
+
+// captureFlag runs the capture loop of the player
+func (env *captureEnv) captureFlag(t *testing.T) (*types.Transaction, *types.Receipt, error) {
+	seq, err := newSequence(nil, testutil.NLevels)
+	require.NoError(t, err)
+	return captureFlag(env.backend, env.zkOnacci, env.player, seq, env.captureConfig())
+}
+
+// assertPlayerOwns checks that the player has captured the second flag
+func (env *captureEnv) assertPlayerOwns(t *testing.T, receipt *types.Receipt) {
+	assert.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	callOpts := &bind.CallOpts{}
+	tokenCounter, err := env.zkOnacci.TokenCounter(callOpts, big.NewInt(0))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), tokenCounter.Int64())
+	owner, err := env.zkOnacci.OwnerOf(callOpts, big.NewInt(1))
+	require.NoError(t, err)
+	assert.Equal(t, env.player.Address(), owner)
+	// The capture is read from the FlagCaptured event
+	flag, err := parseCapture(receipt, env.scAddr, env.zkOnacci)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), flag.TokenID.Int64())
+	assert.Equal(t, 0, flag.Tier)
+	assert.Equal(t, uint64(3), flag.N)
+}
+
+func TestCaptureFlagLosingTheRace(t *testing.T) {
+	env := newCaptureEnv(t)
+	receiptChecks := 0
+	env.backend.afterReceipt = func() {
+		receiptChecks++
+		if receiptChecks == 1 {
+			// Another player captures the flag before the tx of the player is mined
+			env.sim.Rollback()
+			env.captureFirstFlag(t)
+			return
+		}
+		// The tx of the player is mined after the receipt is checked but before the root is checked
+		env.sim.Commit()
+	}
+	tx, receipt, err := env.captureFlag(t)
+	require.NoError(t, err)
+	assert.Equal(t, tx.Hash(), receipt.TxHash)
+	env.assertPlayerOwns(t, receipt)
+	// The first tx was replaced by the proof of the next flag, and no other tx has been sent afterwards
+	assert.Equal(t, uint64(0), tx.Nonce())
+	nonce, err := env.sim.PendingNonceAt(context.Background(), env.player.Address())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), nonce)
+}
+
+func TestCaptureFlagCapturedBeforeSending(t *testing.T) {
+	env := newCaptureEnv(t)
+	nonceChecks := 0
+	env.backend.afterPendingNonce = func() {
+		nonceChecks++
+		if nonceChecks == 1 {
+			// Another player captures the flag after the player syncs, the gas estimation reverts
+			env.captureFirstFlag(t)
+		}
+	}
+	env.backend.afterReceipt = env.sim.Commit
+	_, receipt, err := env.captureFlag(t)
+	require.NoError(t, err)
+	env.assertPlayerOwns(t, receipt)
+}
+
+func TestCaptureFlagOfAnotherPuzzle(t *testing.T) {
+	env := newCaptureEnv(t)
+	// A second puzzle with the same circuit, the first flag of the puzzle 0 is already captured
+	def := testutil.LoadGame(t)
+	_, err := env.zkOnacci.AddPuzzle(env.ownerAuth, env.verifierAddr, game.Order, def.GenesisRoot, def.BaseURI, def.TokenTiers(), def.TokenURIs())
+	require.NoError(t, err)
+	env.sim.Commit()
+	env.captureFirstFlag(t)
+	env.backend.afterReceipt = env.sim.Commit
+	seq, err := newSequence(nil, testutil.NLevels)
+	require.NoError(t, err)
+	cfg := env.captureConfig()
+	cfg.puzzle = 1
+	_, receipt, err := captureFlag(env.backend, env.zkOnacci, env.player, seq, cfg)
+	require.NoError(t, err)
+	assert.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	// The player captures the first flag of the puzzle 1, the puzzle 0 doesn't move
+	flag, err := parseCapture(receipt, env.scAddr, env.zkOnacci)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), flag.Puzzle)
+	assert.Equal(t, new(big.Int).Lsh(big.NewInt(1), 32), flag.TokenID)
+	assert.Equal(t, uint64(2), flag.N)
+	owner, err := env.zkOnacci.OwnerOf(&bind.CallOpts{}, flag.TokenID)
+	require.NoError(t, err)
+	assert.Equal(t, env.player.Address(), owner)
+	tokenCounter, err := env.zkOnacci.TokenCounter(&bind.CallOpts{}, big.NewInt(0))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), tokenCounter.Int64())
+}
+
+func TestCaptureFlagLimits(t *testing.T) {
+	env := newCaptureEnv(t)
+	env.backend.afterReceipt = env.sim.Commit
+	callOpts := &bind.CallOpts{}
+	puzzle := big.NewInt(0)
+	setLimits := func(maxCaptures int64, maxTierCaptures []*big.Int) {
+		_, err := env.zkOnacci.SetCaptureLimits(env.ownerAuth, puzzle, big.NewInt(maxCaptures), maxTierCaptures)
+		require.NoError(t, err)
+		env.sim.Commit()
+	}
+	// A single capture per address
+	setLimits(1, nil)
+	_, receipt, err := env.captureFlag(t)
+	require.NoError(t, err)
+	assert.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	nonce, err := env.sim.NonceAt(context.Background(), env.player.Address(), nil)
+	require.NoError(t, err)
+	_, _, err = env.captureFlag(t)
+	require.ErrorIs(t, err, errCaptureLimit)
+	// Nothing is sent
+	tokenCounter, err := env.zkOnacci.TokenCounter(callOpts, puzzle)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), tokenCounter.Int64())
+	currentNonce, err := env.sim.NonceAt(context.Background(), env.player.Address(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, nonce, currentNonce)
+
+	// A single token of the first tier (tokens 0-2) per address
+	tierLimits := []*big.Int{big.NewInt(1), big.NewInt(0), big.NewInt(0), big.NewInt(0)}
+	setLimits(0, tierLimits)
+	_, _, err = env.captureFlag(t)
+	require.ErrorIs(t, err, errTierCaptureLimit)
+	// The other tiers have no limits
+	require.NoError(t, checkCaptureLimits(callOpts, env.zkOnacci, puzzle, env.player.Address(), 3, false))
+	// Pending captures are counted on the tier of the previous token
+	tierLimits[0] = big.NewInt(2)
+	setLimits(0, tierLimits)
+	require.NoError(t, checkCaptureLimits(callOpts, env.zkOnacci, puzzle, env.player.Address(), 2, false))
+	require.ErrorIs(t, checkCaptureLimits(callOpts, env.zkOnacci, puzzle, env.player.Address(), 2, true), errTierCaptureLimit)
+	require.NoError(t, checkCaptureLimits(callOpts, env.zkOnacci, puzzle, env.player.Address(), 3, true))
+}
@@ -2,132 +2,218 @@ package main
 
 import (
 	"context"
-	"crypto/ecdsa"
+	"errors"
+	"flag"
 	"fmt"
-	"math/big"
 	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
 
+	"github.com/arnaubennassar/zkOnacci/config"
 	"github.com/arnaubennassar/zkOnacci/contracts"
-	"github.com/arnaubennassar/zkOnacci/contracts/zkinputs"
+	"github.com/arnaubennassar/zkOnacci/recurrence"
+	"github.com/arnaubennassar/zkOnacci/signer"
+	"github.com/arnaubennassar/zkOnacci/txutil"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
-	"github.com/iden3/go-merkletree"
-	"github.com/iden3/go-merkletree/db/memory"
 )
 
-const nLevels = 6
-
 func main() {
-	// Set up client
-	web3URL := os.Getenv("WEB3_URL")
-	if web3URL == "" {
-		panic("Must provide the env var WEB3_URL")
-	}
-	client, err := ethclient.Dial(web3URL)
+	configFlags := config.RegisterFlags(flag.CommandLine)
+	watch := flag.Bool("watch", false, "keep capturing flags as soon as they become available")
+	exportPath := flag.String("export", "", "prove without connecting to a node and write the capture bundle to this file")
+	exportN := flag.Int("n", 0, "position of the sequence proven by the exported bundle (amount of minted tokens + the order of the recurrence, 2 for zkOnacci)")
+	signBundle := flag.Bool("sign", false, "include a signed captureTheFlag tx in the exported bundle")
+	broadcastPath := flag.String("broadcast", "", "send the capture bundle of this file")
+	flag.Parse()
+	conf, err := configFlags.Load()
 	if err != nil {
 		panic(err)
 	}
-	privKeyStr := os.Getenv("PRIVATE_KEY")
-	if privKeyStr == "" {
-		panic("Must provide the env var PRIVATE_KEY")
+	newSigner := func() signer.Signer {
+		s, err := signer.New(conf.Signer)
+		if err != nil {
+			panic(err)
+		}
+		return s
 	}
-	privateKey, err := crypto.HexToECDSA(privKeyStr)
+	scAddr := conf.ZKOnacciAddr
+	cfg, err := captureConfigFromEnv(conf)
 	if err != nil {
 		panic(err)
 	}
-	publicKey := privateKey.Public()
-	publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
-	if !ok {
-		panic("error casting public key to ECDSA")
+	if *exportPath != "" {
+		runExport(*exportPath, scAddr, *exportN, *signBundle, newSigner, cfg)
+		return
 	}
-	fromAddress := crypto.PubkeyToAddress(*publicKeyECDSA)
-	nonce, err := client.PendingNonceAt(context.Background(), fromAddress)
+	// Set up client
+	if conf.Web3URL == "" {
+		panic("Must provide the web3 URL (web3URL of the profile, env var WEB3_URL or -web3-url flag)")
+	}
+	client, err := ethclient.Dial(conf.Web3URL)
 	if err != nil {
 		panic(err)
 	}
-	scAddrHex := os.Getenv("SC_ADDR")
-	scAddr := common.HexToAddress(scAddrHex)
 	zkOnacci, err := contracts.NewZKOnacci(scAddr, client)
 	if err != nil {
 		panic(err)
 	}
-	// Read the SC
-	callOpts := &bind.CallOpts{}
-	nMintedTokens, err := zkOnacci.TokenCounter(callOpts)
-	if err != nil {
-		panic(err)
+	if *broadcastPath != "" {
+		runBroadcast(client, *broadcastPath, newSigner, cfg)
+		return
+	}
+	s := newSigner()
+	if *watch {
+		runWatch(client, zkOnacci, scAddr, s, cfg)
+		return
 	}
-	fmt.Println(nMintedTokens, " tokens already minted")
-	// Add existing numbers of the sequence to the tree
-	n := int(nMintedTokens.Int64() + 2)
-	FnMinOne := 1
-	FnMinTwo := 0
-	merkleTree, err := merkletree.NewMerkleTree(memory.NewMemoryStorage(), nLevels)
+	// Prove the next number of the sequence and send it to the SC
+	seq, err := newSequence(cfg.recurrence, cfg.nLevels)
 	if err != nil {
 		panic(err)
 	}
-	if err := merkleTree.Add(big.NewInt(0), big.NewInt(0)); err != nil {
+	_, receipt, err := captureFlag(client, zkOnacci, s, seq, cfg)
+	reportCapture(scAddr, zkOnacci, receipt, err)
+}
+
+// captureConfigFromEnv reads the capture settings from the env vars MAX_RETRIES, CAPTURE_TIMEOUT and CONFIRMATIONS,
+// the rest of settings are taken from conf
+func captureConfigFromEnv(conf *config.Config) (captureConfig, error) {
+	cfg := captureConfig{
+		maxRetries:    3,
+		timeout:       time.Minute * 30,
+		confirmations: 1,
+		artifactsPath: conf.ArtifactsPath,
+		nLevels:       conf.NLevels,
+		feeConfig:     conf.Fees,
+		puzzle:        conf.Puzzle,
+	}
+	var err error
+	if conf.Recurrence != "" {
+		if cfg.recurrence, err = recurrence.Load(conf.Recurrence); err != nil {
+			return cfg, err
+		}
+	}
+	if maxRetriesStr := os.Getenv("MAX_RETRIES"); maxRetriesStr != "" {
+		if cfg.maxRetries, err = strconv.Atoi(maxRetriesStr); err != nil {
+			return cfg, err
+		}
+	}
+	if timeoutStr := os.Getenv("CAPTURE_TIMEOUT"); timeoutStr != "" {
+		if cfg.timeout, err = time.ParseDuration(timeoutStr); err != nil {
+			return cfg, err
+		}
+	}
+	if confirmationsStr := os.Getenv("CONFIRMATIONS"); confirmationsStr != "" {
+		if cfg.confirmations, err = strconv.ParseUint(confirmationsStr, 10, 64); err != nil {
+			return cfg, err
+		}
+	}
+	return cfg, nil
+}
+
+// reportCapture prints the result of a captureTheFlag tx
+func reportCapture(scAddr common.Address, zkOnacci *contracts.ZKOnacci, receipt *types.Receipt, err error) {
+	var failed *txutil.TxFailedError
+	if errors.As(err, &failed) {
+		fmt.Println("Tx reverted on block", receipt.BlockNumber, ", reason:", failed.Reason)
+		os.Exit(1)
+	}
+	if errors.Is(err, errCaptureLimit) || errors.Is(err, errTierCaptureLimit) {
+		fmt.Println("The flag can't be captured:", err)
+		os.Exit(1)
+	}
+	if err != nil {
 		panic(err)
 	}
-	if err := merkleTree.Add(big.NewInt(1), big.NewInt(1)); err != nil {
+	flag, err := parseCapture(receipt, scAddr, zkOnacci)
+	if err != nil {
 		panic(err)
 	}
-	for i := int64(2); i < int64(n); i++ {
-		Fn := FnMinOne + FnMinTwo
-		if err := merkleTree.Add(big.NewInt(i), big.NewInt(int64(Fn))); err != nil {
+	fmt.Printf("Flag captured on block %d! puzzle %d, season %d, n = %d, token ID: %s, tier: %d, URI: %s\n",
+		receipt.BlockNumber, flag.Puzzle, flag.Season, flag.N, flag.TokenID, flag.Tier, flag.URI)
+}
+
+// runExport proves the position n without connecting to a node and writes the capture bundle to path.
+// The proof is bound to the env var SENDER, or to the address of the signer if it's not set or the tx is signed
+func runExport(path string, scAddr common.Address, n int, sign bool, newSigner func() signer.Signer, cfg captureConfig) {
+	var (
+		s      signer.Signer
+		sender common.Address
+		txCfg  offlineTxConfig
+		err    error
+	)
+	if sign {
+		if txCfg, err = offlineTxConfigFromEnv(); err != nil {
 			panic(err)
 		}
-		// Values for next iteration
-		FnMinTwo = FnMinOne
-		FnMinOne = Fn
+		s = newSigner()
+		sender = s.Address()
+	} else if os.Getenv("SENDER") != "" {
+		if sender, err = parseSender(); err != nil {
+			panic(err)
+		}
+	} else {
+		sender = newSigner().Address()
 	}
-	// Calculate proof
-	oldRoot := merkleTree.Root()
-	mtpNMinOne, err := merkleTree.GenerateCircomVerifierProof(big.NewInt(int64(n-1)), nil)
+	b, err := exportBundle(path, scAddr, sender, n, s, txCfg, cfg)
 	if err != nil {
 		panic(err)
 	}
-	mtpNMinTwo, err := merkleTree.GenerateCircomVerifierProof(big.NewInt(int64(n-2)), nil)
+	fmt.Printf("Capture bundle of puzzle %d, n = %d for %s written to %s (signed tx included: %t)\n", b.Puzzle, b.N, b.Sender.Hex(), path, len(b.RawTx) > 0)
+}
+
+// runBroadcast sends the capture bundle of path and reports the result
+func runBroadcast(client *ethclient.Client, path string, newSigner func() signer.Signer, cfg captureConfig) {
+	b, err := loadCaptureBundle(path)
 	if err != nil {
 		panic(err)
 	}
-	// Add Fn and get processing proof
-	mtpN, err := merkleTree.AddAndGetCircomProof(big.NewInt(int64(n)), big.NewInt(int64(FnMinOne+FnMinTwo)))
+	zkOnacci, err := contracts.NewZKOnacci(b.Contract, client)
 	if err != nil {
 		panic(err)
 	}
-	proofA, proofB, proofC, err := zkinputs.GenerateProof(zkinputs.ZKInput{
-		Sender:           fromAddress,
-		Root:             oldRoot,
-		N:                int(n),
-		Fn:               FnMinOne + FnMinTwo,
-		SiblingsFn:       mtpN.Siblings,
-		OldKeyFn:         mtpN.OldKey,
-		OldValueFn:       mtpN.OldValue,
-		IsOld0Fn:         mtpN.IsOld0,
-		FnMinOne:         FnMinOne,
-		SiblingsFnMinOne: mtpNMinOne.Siblings,
-		FnMinTwo:         FnMinTwo,
-		SiblingsFnMinTwo: mtpNMinTwo.Siblings,
-	}, "../circuits")
+	var s signer.Signer
+	if len(b.RawTx) == 0 {
+		s = newSigner()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.timeout)
+	defer cancel()
+	_, receipt, err := broadcastBundle(ctx, client, zkOnacci, b, s, cfg)
+	reportCapture(b.Contract, zkOnacci, receipt, err)
+}
+
+// runWatch runs the watch mode until it's done or the process is interrupted, and prints a summary of the captures
+func runWatch(client *ethclient.Client, zkOnacci *contracts.ZKOnacci, scAddr common.Address, s signer.Signer, cfg captureConfig) {
+	wcfg, err := watchConfigFromEnv()
 	if err != nil {
 		panic(err)
 	}
-	// Send tx
-	gasPrice, err := client.SuggestGasPrice(context.Background())
+	state, err := loadWatchState(wcfg.stateFile, scAddr, cfg.puzzle)
 	if err != nil {
 		panic(err)
 	}
-	auth := bind.NewKeyedTransactor(privateKey)
-	auth.Nonce = big.NewInt(int64(nonce))
-	auth.Value = big.NewInt(0)      // in wei
-	auth.GasLimit = uint64(1500000) // in units
-	auth.GasPrice = gasPrice
-	tx, err := zkOnacci.CaptureTheFlag(auth, proofA, proofB, proofC, merkleTree.Root().BigInt())
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	err = watchFlags(ctx, client, zkOnacci, scAddr, s, cfg, wcfg, state)
+	if errors.Is(err, context.Canceled) {
+		fmt.Println("Shutting down")
+		err = nil
+	}
+	tokenTiers, tiersErr := readTokenTiers(&bind.CallOpts{}, zkOnacci, cfg.puzzleID())
+	if tiersErr != nil {
+		panic(tiersErr)
+	}
+	season, seasonErr := zkOnacci.CurrentSeason(&bind.CallOpts{}, cfg.puzzleID())
+	if seasonErr != nil {
+		panic(seasonErr)
+	}
+	state.printSummary(season.Uint64(), len(tokenTiers))
 	if err != nil {
 		panic(err)
 	}
-	fmt.Println("Tx sent to the blockchain. Tx Hash:", tx.Hash())
 }
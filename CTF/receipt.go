@@ -0,0 +1,68 @@
+package main
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/arnaubennassar/zkOnacci/contracts"
+	"github.com/arnaubennassar/zkOnacci/game"
+	"github.com/arnaubennassar/zkOnacci/txutil"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// capture holds the result of a successful captureTheFlag tx
+type capture struct {
+	// TokenID encodes the puzzle and the season, see game.TokenID
+	TokenID *big.Int
+	Puzzle  uint64
+	Season  uint64
+	Tier    int
+	// N is the position of the sequence that has been proven
+	N       uint64
+	URI     string
+	Receipt *types.Receipt
+}
+
+// waitConfig returns the settings used to wait for txs
+func waitConfig(confirmations uint64) txutil.WaitConfig {
+	return txutil.WaitConfig{Confirmations: confirmations, OnPending: txutil.PrintProgress}
+}
+
+// parseCapture gets the minted token from the FlagCaptured log of a successful captureTheFlag tx
+// and queries its URI
+func parseCapture(receipt *types.Receipt, scAddr common.Address, zkOnacci *contracts.ZKOnacci) (capture, error) {
+	zkOnacciABI, err := abi.JSON(strings.NewReader(contracts.ZKOnacciABI))
+	if err != nil {
+		return capture{}, err
+	}
+	flagCapturedID := zkOnacciABI.Events["FlagCaptured"].ID
+	for _, log := range receipt.Logs {
+		if log.Address != scAddr || len(log.Topics) == 0 || log.Topics[0] != flagCapturedID {
+			continue
+		}
+		captured, err := zkOnacci.ParseFlagCaptured(*log)
+		if err != nil {
+			return capture{}, err
+		}
+		// The URI of a token doesn't change once minted, so it's read from the latest block
+		uri, err := zkOnacci.TokenURI(&bind.CallOpts{}, captured.TokenId)
+		if err != nil {
+			return capture{}, err
+		}
+		puzzle, season, _ := game.SplitTokenID(captured.TokenId)
+		return capture{
+			TokenID: captured.TokenId,
+			Puzzle:  puzzle,
+			Season:  season,
+			Tier:    int(captured.Tier),
+			N:       captured.N.Uint64(),
+			URI:     uri,
+			Receipt: receipt,
+		}, nil
+	}
+	return capture{}, errors.New("FlagCaptured event not found in the tx receipt")
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"math/big"
+
+	"github.com/arnaubennassar/zkOnacci/recurrence"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iden3/go-merkletree"
+)
+
+// sequence keeps a local copy of the MT that represents the state of the game:
+// the leaf i holds the i-th number of the sequence of the puzzle
+type sequence struct {
+	tree *recurrence.Tree
+	// variant is the recurrence of the circuit, nil for the zkOnacci circuit (Fibonacci with its own inputs)
+	variant *recurrence.Recurrence
+	// first is the first position to prove, the previous ones are the seeds of the genesis tree
+	first int
+}
+
+// newSequence returns the sequence of variant (the Fibonacci sequence of the zkOnacci circuit if nil),
+// with its seeds already added to the tree
+func newSequence(variant *recurrence.Recurrence, nLevels int) (*sequence, error) {
+	r := variant
+	if r == nil {
+		var err error
+		if r, err = recurrence.Load("fibonacci"); err != nil {
+			return nil, err
+		}
+	}
+	tree, err := recurrence.NewTree(r, nLevels)
+	if err != nil {
+		return nil, err
+	}
+	return &sequence{tree: tree, variant: variant, first: r.Order()}, nil
+}
+
+// n returns the next position of the sequence to be added to the tree
+func (s *sequence) n() int {
+	return s.tree.N()
+}
+
+// root returns the root of the local tree
+func (s *sequence) root() *merkletree.Hash {
+	return s.tree.Root()
+}
+
+// advance adds the numbers of the sequence to the tree until the next position to be added is n
+func (s *sequence) advance(n int) error {
+	return s.tree.Advance(n)
+}
+
+// nextInput adds the next number of the sequence to the tree and returns the inputs of the circuit that
+// prove it, along with the roots before and after adding it
+func (s *sequence) nextInput(sender common.Address) (input recurrence.Input, currentRoot, nextRoot *merkletree.Hash, err error) {
+	return s.tree.NextInput(sender)
+}
+
+// generateProof proves input with the circuit of the sequence
+func (s *sequence) generateProof(input recurrence.Input, artifactsPath string) (
+	proofA [2]*big.Int,
+	proofB [2][2]*big.Int,
+	proofC [2]*big.Int,
+	err error,
+) {
+	if s.variant != nil {
+		return s.variant.GenerateProof(input, artifactsPath)
+	}
+	return recurrence.GenerateZKOnacciProof(input, artifactsPath)
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/arnaubennassar/zkOnacci/recurrence"
+	"github.com/arnaubennassar/zkOnacci/testutil"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSequence(t *testing.T) {
+	sender := common.HexToAddress("0x01")
+	// The zkOnacci circuit gets the Fibonacci numbers with its own inputs
+	seq, err := newSequence(nil, testutil.NLevels)
+	require.NoError(t, err)
+	assert.Equal(t, 2, seq.first)
+	require.NoError(t, seq.advance(5))
+	input, _, _, err := seq.nextInput(sender)
+	require.NoError(t, err)
+	zkInput, err := recurrence.ZKOnacciInput(input)
+	require.NoError(t, err)
+	assert.Equal(t, 5, zkInput.N)
+	assert.Equal(t, 5, zkInput.Fn)
+	assert.Equal(t, 3, zkInput.FnMinOne)
+	assert.Equal(t, 2, zkInput.FnMinTwo)
+	assert.Equal(t, input.SiblingsFprev[1], zkInput.SiblingsFnMinTwo)
+
+	// Variants start after their seeds
+	tribonacci, err := recurrence.Load("tribonacci")
+	require.NoError(t, err)
+	seq, err = newSequence(tribonacci, testutil.NLevels)
+	require.NoError(t, err)
+	assert.Equal(t, 3, seq.first)
+	assert.Equal(t, 3, seq.n())
+	genesisRoot, err := tribonacci.GenesisRoot(testutil.NLevels)
+	require.NoError(t, err)
+	assert.Equal(t, genesisRoot, seq.root().BigInt())
+}
@@ -0,0 +1,415 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/arnaubennassar/zkOnacci/contracts"
+	"github.com/arnaubennassar/zkOnacci/signer"
+	"github.com/arnaubennassar/zkOnacci/txutil"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// watchConfig holds the settings of the watch mode
+type watchConfig struct {
+	// stateFile is the path of the file where the progress is persisted
+	stateFile string
+	// pollInterval is the time between checks of the SC state when subscriptions are not available
+	pollInterval time.Duration
+	// tierTargets is the amount of flags to capture on each tier (nil = capture everything)
+	tierTargets []int
+}
+
+// watchConfigFromEnv reads the watch mode settings from the env vars WATCH_STATE_FILE, POLL_INTERVAL and TIER_TARGETS
+func watchConfigFromEnv() (watchConfig, error) {
+	cfg := watchConfig{
+		stateFile:    "watch_state.json",
+		pollInterval: time.Second * 15,
+	}
+	if stateFile := os.Getenv("WATCH_STATE_FILE"); stateFile != "" {
+		cfg.stateFile = stateFile
+	}
+	if pollIntervalStr := os.Getenv("POLL_INTERVAL"); pollIntervalStr != "" {
+		pollInterval, err := time.ParseDuration(pollIntervalStr)
+		if err != nil {
+			return watchConfig{}, fmt.Errorf("invalid POLL_INTERVAL: %w", err)
+		}
+		cfg.pollInterval = pollInterval
+	}
+	if tierTargetsStr := os.Getenv("TIER_TARGETS"); tierTargetsStr != "" {
+		for _, targetStr := range strings.Split(tierTargetsStr, ",") {
+			target, err := strconv.Atoi(strings.TrimSpace(targetStr))
+			if err != nil {
+				return watchConfig{}, fmt.Errorf("invalid TIER_TARGETS: %w", err)
+			}
+			cfg.tierTargets = append(cfg.tierTargets, target)
+		}
+	}
+	return cfg, nil
+}
+
+// capturedFlag is a flag captured by the watch mode
+type capturedFlag struct {
+	TokenID     uint64      `json:"tokenId"`
+	Season      uint64      `json:"season"`
+	Tier        int         `json:"tier"`
+	N           int         `json:"n"`
+	URI         string      `json:"uri"`
+	TxHash      common.Hash `json:"txHash"`
+	BlockNumber uint64      `json:"blockNumber"`
+}
+
+// watchState is the progress of the watch mode on a puzzle, persisted across restarts
+type watchState struct {
+	Contract common.Address `json:"contract"`
+	Puzzle   uint64         `json:"puzzle"`
+	Captures []capturedFlag `json:"captures"`
+}
+
+// loadWatchState reads the state file. If it doesn't exist, an empty state is returned
+func loadWatchState(path string, scAddr common.Address, puzzle uint64) (*watchState, error) {
+	state := &watchState{Contract: scAddr, Puzzle: puzzle}
+	stateJSON, err := ioutil.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(stateJSON, state); err != nil {
+		return nil, err
+	}
+	if state.Contract != scAddr {
+		return nil, fmt.Errorf("the state file %s belongs to the contract %s", path, state.Contract.Hex())
+	}
+	if state.Puzzle != puzzle {
+		return nil, fmt.Errorf("the state file %s belongs to the puzzle %d", path, state.Puzzle)
+	}
+	return state, nil
+}
+
+// save writes the state into path, replacing the file atomically
+func (ws *watchState) save(path string) error {
+	stateJSON, err := json.MarshalIndent(ws, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(path+".tmp", stateJSON, 0644); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+// capturesByTier returns the amount of captured flags on each tier of season
+func (ws *watchState) capturesByTier(season uint64, nTiers int) []int {
+	byTier := make([]int, nTiers)
+	for _, c := range ws.Captures {
+		if c.Season == season && c.Tier < nTiers {
+			byTier[c.Tier]++
+		}
+	}
+	return byTier
+}
+
+// printSummary prints the flags captured so far, and how many of them belong to each tier of season
+func (ws *watchState) printSummary(season uint64, nTiers int) {
+	fmt.Printf("Captured %d flags:\n", len(ws.Captures))
+	for _, c := range ws.Captures {
+		fmt.Printf("  token %d (season %d, tier %d, n = %d) on block %d, tx %s\n", c.TokenID, c.Season, c.Tier, c.N, c.BlockNumber, c.TxHash.Hex())
+	}
+	for tier, count := range ws.capturesByTier(season, nTiers) {
+		fmt.Printf("  season %d, tier %d: %d flags\n", season, tier, count)
+	}
+}
+
+// watchFlags keeps capturing flags of the puzzle of cfg as soon as they are available, until ctx is cancelled,
+// all the tokens of the current season are minted, the tier targets of the season are met or the player reaches
+// the capture limit of the season. The tiers where the player reached its capture limit are skipped. A season can't start before the previous one
+// is sold out, so it doesn't change while watching. The proof of the next flag is generated ahead of time, assuming that the pending
+// capture will succeed (the tree after a capture is the same regardless of who captures it)
+func watchFlags(
+	ctx context.Context,
+	backend captureBackend,
+	zkOnacci *contracts.ZKOnacci,
+	scAddr common.Address,
+	s signer.Signer,
+	cfg captureConfig,
+	wcfg watchConfig,
+	state *watchState,
+) error {
+	tokenTiers, err := readTokenTiers(&bind.CallOpts{Context: ctx}, zkOnacci, cfg.puzzleID())
+	if err != nil {
+		return err
+	}
+	season, err := zkOnacci.CurrentSeason(&bind.CallOpts{Context: ctx}, cfg.puzzleID())
+	if err != nil {
+		return err
+	}
+	// Positions of the tokens within the season
+	maxIndex := uint64(tokenTiers[len(tokenTiers)-1])
+	mints := newMintNotifier(ctx, zkOnacci, cfg.puzzleID(), wcfg.pollInterval)
+	seq, err := newSequence(cfg.recurrence, cfg.nLevels)
+	if err != nil {
+		return err
+	}
+	var prepared *preparedCapture
+	// pending is a capture tx of the player that lost the race and hasn't been mined
+	var pending *types.Transaction
+	for {
+		callOpts := &bind.CallOpts{Context: ctx}
+		nMintedTokens, err := zkOnacci.TokenCounter(callOpts, cfg.puzzleID())
+		if err != nil {
+			return err
+		}
+		if nMintedTokens.Uint64() > maxIndex {
+			fmt.Println("ALL_TOKENS_MINTED: there are no flags left")
+			return nil
+		}
+		nextIndex := nMintedTokens.Uint64()
+		tier := tierOf(nextIndex, tokenTiers)
+		if targetsMet(state.capturesByTier(season.Uint64(), len(tokenTiers)), wcfg.tierTargets, nextIndex, tokenTiers) {
+			fmt.Println("All the tier targets have been met")
+			return nil
+		}
+		n := int(nextIndex) + seq.first
+		if prepared != nil && prepared.n < n {
+			// Other players went ahead of the prepared proof
+			prepared = nil
+		}
+		limitErr := checkCaptureLimits(callOpts, zkOnacci, cfg.puzzleID(), s.Address(), nextIndex, false)
+		switch {
+		case errors.Is(limitErr, errCaptureLimit):
+			fmt.Println(limitErr)
+			return nil
+		case errors.Is(limitErr, errTierCaptureLimit):
+			// The tier is skipped, the next one may allow more captures
+			fmt.Println(limitErr)
+		case limitErr != nil:
+			return limitErr
+		}
+		if limitErr == nil && tierWanted(state.capturesByTier(season.Uint64(), len(tokenTiers)), wcfg.tierTargets, tier) {
+			if prepared == nil {
+				if seq.n() > n {
+					// The local tree went ahead of the SC (e.g. a capture was reverted), start over
+					if seq, err = newSequence(cfg.recurrence, cfg.nLevels); err != nil {
+						return err
+					}
+				}
+				if err := seq.advance(n); err != nil {
+					return err
+				}
+				if prepared, err = prepareCapture(seq, cfg.puzzle, s.Address(), cfg.artifactsPath); err != nil {
+					return err
+				}
+			}
+			root, err := zkOnacci.Root(callOpts, cfg.puzzleID())
+			if err != nil {
+				return err
+			}
+			if prepared.n == n && root.Cmp(prepared.currentRoot.BigInt()) == 0 {
+				// Eligible: submit right away and prepare the next proof while the tx is pending
+				if err := submitPrepared(ctx, backend, zkOnacci, scAddr, s, cfg, wcfg, seq, state, &prepared, &pending); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		// Wait until another flag is captured
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-mints:
+		}
+	}
+}
+
+// submitPrepared sends the prepared capture, generates the proof of the next flag while it's pending and
+// records the capture once it's confirmed. prepared is updated with the next proof (or nil if it can't be predicted).
+// If pending is set, the capture replaces it (using its nonce), and it's updated with the tx if it loses the race without being mined
+func submitPrepared(
+	ctx context.Context,
+	backend captureBackend,
+	zkOnacci *contracts.ZKOnacci,
+	scAddr common.Address,
+	s signer.Signer,
+	cfg captureConfig,
+	wcfg watchConfig,
+	seq *sequence,
+	state *watchState,
+	prepared **preparedCapture,
+	pending **types.Transaction,
+) error {
+	current := *prepared
+	if *pending != nil {
+		mined, err := txMined(ctx, backend, *pending)
+		if err != nil {
+			return err
+		}
+		if mined {
+			// The previous tx has been mined in the meantime, there is nothing to replace
+			*pending = nil
+		}
+	}
+	tx, err := sendCapture(ctx, backend, zkOnacci, s, cfg.feeConfig, current, *pending)
+	if err != nil {
+		// The gas estimation fails if the flag has just been captured by another player
+		moved, rootErr := rootMoved(ctx, zkOnacci, current)
+		if rootErr != nil || !moved {
+			return err
+		}
+		fmt.Println("Flag n =", current.n, "captured by another player before sending the tx")
+		*prepared = nil
+		return nil
+	}
+	if *pending != nil {
+		fmt.Println("Replacement tx sent to the blockchain. n =", current.n, ", tx Hash:", tx.Hash(), ", replaces:", (*pending).Hash())
+	} else {
+		fmt.Println("Tx sent to the blockchain. n =", current.n, ", tx Hash:", tx.Hash())
+	}
+	*pending = nil
+	// Predict the next state, unless the limits won't allow capturing it after the pending capture
+	nextIndex := uint64(current.n-seq.first) + 1
+	limitErr := checkCaptureLimits(&bind.CallOpts{Context: ctx}, zkOnacci, cfg.puzzleID(), s.Address(), nextIndex, true)
+	switch {
+	case errors.Is(limitErr, errCaptureLimit), errors.Is(limitErr, errTierCaptureLimit):
+		*prepared = nil
+	case limitErr != nil:
+		return limitErr
+	default:
+		if *prepared, err = prepareCapture(seq, cfg.puzzle, s.Address(), cfg.artifactsPath); err != nil {
+			return err
+		}
+	}
+	receipt, err := waitForCapture(ctx, backend, zkOnacci, tx, current, cfg.confirmations)
+	switch {
+	case errors.Is(err, errRootMoved):
+		fmt.Println("Flag n =", current.n, "captured by another player")
+		if receipt == nil {
+			// The tx is still pending, the next capture will replace it
+			*pending = tx
+		}
+		return nil
+	case errors.Is(err, txutil.ErrTxReorged), errors.Is(err, txutil.ErrTxDropped), errors.Is(err, txutil.ErrTxReplaced):
+		fmt.Println("Tx", tx.Hash().Hex(), "won't be mined:", err)
+		*prepared = nil
+		return nil
+	case err != nil:
+		if ctx.Err() != nil {
+			fmt.Println("Shutting down with tx", tx.Hash().Hex(), "pending")
+		}
+		return err
+	}
+	flag, err := parseCapture(receipt, scAddr, zkOnacci)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Flag captured on block %d! puzzle %d, season %d, n = %d, token ID: %s, tier: %d, URI: %s\n",
+		receipt.BlockNumber, flag.Puzzle, flag.Season, flag.N, flag.TokenID, flag.Tier, flag.URI)
+	state.Captures = append(state.Captures, capturedFlag{
+		TokenID:     flag.TokenID.Uint64(),
+		Season:      flag.Season,
+		Tier:        flag.Tier,
+		N:           current.n,
+		URI:         flag.URI,
+		TxHash:      tx.Hash(),
+		BlockNumber: receipt.BlockNumber.Uint64(),
+	})
+	return state.save(wcfg.stateFile)
+}
+
+// newMintNotifier returns a channel that receives a value every time a token of puzzle is minted. The SC state is also
+// polled every pollInterval, which is the only source of notifications if the client doesn't support
+// subscriptions (HTTP RPC)
+func newMintNotifier(ctx context.Context, zkOnacci *contracts.ZKOnacci, puzzle *big.Int, pollInterval time.Duration) <-chan struct{} {
+	notify := make(chan struct{}, 1)
+	send := func() {
+		select {
+		case notify <- struct{}{}:
+		default:
+		}
+	}
+	captures := make(chan *contracts.ZKOnacciFlagCaptured)
+	sub, err := zkOnacci.WatchFlagCaptured(&bind.WatchOpts{Context: ctx}, captures, nil, nil, []*big.Int{puzzle})
+	var subErr <-chan error
+	if err != nil {
+		fmt.Println("Subscriptions not available (", err, "), polling every", pollInterval)
+	} else {
+		subErr = sub.Err()
+	}
+	go func() {
+		if sub != nil {
+			defer sub.Unsubscribe()
+		}
+		ticker := time.NewTicker(pollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case err := <-subErr:
+				fmt.Println("Mint subscription closed (", err, "), polling every", pollInterval)
+				subErr = nil
+			case <-captures:
+				send()
+			case <-ticker.C:
+				send()
+			}
+		}
+	}()
+	return notify
+}
+
+// readTokenTiers returns the last token ID of each tier of puzzle
+func readTokenTiers(callOpts *bind.CallOpts, zkOnacci *contracts.ZKOnacci, puzzle *big.Int) ([]uint16, error) {
+	nTiers, err := zkOnacci.NTiers(callOpts, puzzle)
+	if err != nil {
+		return nil, err
+	}
+	tokenTiers := make([]uint16, nTiers)
+	for i := range tokenTiers {
+		if tokenTiers[i], err = zkOnacci.TokenTiers(callOpts, puzzle, big.NewInt(int64(i))); err != nil {
+			return nil, err
+		}
+	}
+	return tokenTiers, nil
+}
+
+// tierOf returns the tier of the token minted in the position index of its season, following the same logic
+// as the tokenURI function of the SC
+func tierOf(index uint64, tokenTiers []uint16) int {
+	tier := 0
+	for tier < len(tokenTiers)-1 && index > uint64(tokenTiers[tier]) {
+		tier++
+	}
+	return tier
+}
+
+// tierWanted returns true if more flags should be captured on tier
+func tierWanted(byTier, targets []int, tier int) bool {
+	if targets == nil {
+		return true
+	}
+	return tier < len(targets) && byTier[tier] < targets[tier]
+}
+
+// targetsMet returns true if no more flags are wanted on the tiers that still have tokens to be minted
+func targetsMet(byTier, targets []int, nextIndex uint64, tokenTiers []uint16) bool {
+	if targets == nil {
+		return false
+	}
+	for tier := tierOf(nextIndex, tokenTiers); tier < len(tokenTiers); tier++ {
+		if tierWanted(byTier, targets, tier) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTierTargets(t *testing.T) {
+	tokenTiers := []uint16{2, 4, 8, 16}
+	assert.Equal(t, 0, tierOf(0, tokenTiers))
+	assert.Equal(t, 0, tierOf(2, tokenTiers))
+	assert.Equal(t, 1, tierOf(3, tokenTiers))
+	assert.Equal(t, 3, tierOf(16, tokenTiers))
+	// No targets: capture everything
+	assert.True(t, tierWanted([]int{5, 0, 0, 0}, nil, 0))
+	assert.False(t, targetsMet([]int{5, 0, 0, 0}, nil, 3, tokenTiers))
+	// One flag of tier 1 and two of tier 3
+	targets := []int{0, 1, 0, 2}
+	assert.False(t, tierWanted([]int{0, 0, 0, 0}, targets, 0))
+	assert.True(t, tierWanted([]int{0, 0, 0, 0}, targets, 1))
+	assert.False(t, targetsMet([]int{0, 1, 0, 1}, targets, 9, tokenTiers))
+	assert.True(t, targetsMet([]int{0, 1, 0, 2}, targets, 9, tokenTiers))
+	// Tier 1 sold out without capturing it: only the remaining tiers count
+	assert.True(t, targetsMet([]int{0, 0, 0, 2}, targets, 9, tokenTiers))
+}
+
+func TestWatchStatePersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	scAddr := common.HexToAddress("0x36E9CA815e61d1C7a171E638Af5681e4aB8ACc65")
+	state, err := loadWatchState(path, scAddr, 1)
+	require.NoError(t, err)
+	assert.Empty(t, state.Captures)
+	state.Captures = append(state.Captures, capturedFlag{TokenID: 3, Tier: 1, N: 5, BlockNumber: 10})
+	require.NoError(t, state.save(path))
+	loaded, err := loadWatchState(path, scAddr, 1)
+	require.NoError(t, err)
+	assert.Equal(t, state, loaded)
+	assert.Equal(t, []int{0, 1}, loaded.capturesByTier(0, 2))
+	// Captures of other seasons don't count
+	state.Captures = append(state.Captures, capturedFlag{TokenID: 1<<16 + 1, Season: 1, Tier: 1, N: 3, BlockNumber: 20})
+	assert.Equal(t, []int{0, 1}, state.capturesByTier(0, 2))
+	assert.Equal(t, []int{0, 1}, state.capturesByTier(1, 2))
+	// Other contract
+	_, err = loadWatchState(path, common.HexToAddress("0x01"), 1)
+	assert.Error(t, err)
+	// Other puzzle
+	_, err = loadWatchState(path, scAddr, 0)
+	assert.Error(t, err)
+}
+
+func TestWatchFlagsLosingTheRace(t *testing.T) {
+	env := newCaptureEnv(t)
+	var sent []*types.Transaction
+	env.backend.afterSend = func(tx *types.Transaction) {
+		from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+		require.NoError(t, err)
+		if from == env.player.Address() {
+			sent = append(sent, tx)
+		}
+	}
+	receiptChecks := 0
+	env.backend.afterReceipt = func() {
+		receiptChecks++
+		if receiptChecks == 1 {
+			// Another player captures the flag before the tx of the player is mined
+			env.sim.Rollback()
+			env.captureFirstFlag(t)
+			return
+		}
+		env.sim.Commit()
+	}
+	state := &watchState{Contract: env.scAddr}
+	wcfg := watchConfig{
+		stateFile:    filepath.Join(t.TempDir(), "state.json"),
+		pollInterval: time.Second,
+		// Stop after capturing a flag of the first tier
+		tierTargets: []int{1},
+	}
+	require.NoError(t, watchFlags(context.Background(), env.backend, env.zkOnacci, env.scAddr, env.player, env.captureConfig(), wcfg, state))
+	require.Len(t, state.Captures, 1)
+	assert.Equal(t, uint64(1), state.Captures[0].TokenID)
+	assert.Equal(t, 3, state.Captures[0].N)
+	// The tx that lost the race is replaced by the capture of the next flag
+	require.Len(t, sent, 2)
+	assert.Equal(t, sent[0].Nonce(), sent[1].Nonce())
+	assert.Equal(t, 1, sent[1].GasFeeCap().Cmp(sent[0].GasFeeCap()))
+	assert.Equal(t, sent[1].Hash(), state.Captures[0].TxHash)
+	owner, err := env.zkOnacci.OwnerOf(&bind.CallOpts{}, big.NewInt(1))
+	require.NoError(t, err)
+	assert.Equal(t, env.player.Address(), owner)
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/arnaubennassar/zkOnacci/config"
+	"github.com/arnaubennassar/zkOnacci/recurrence"
+)
+
+func main() {
+	configFlags := config.RegisterFlags(flag.CommandLine)
+	build := flag.Bool("build", false, "compile the circuits and run the circuit specific setup (needs circom, snarkjs and the ptau of postinstall)")
+	flag.Parse()
+	configFlags.SkipManifest = true
+	conf, err := configFlags.Load()
+	if err != nil {
+		panic(err)
+	}
+	// All the built-in variants are generated unless one is selected
+	names := recurrence.Builtins()
+	if conf.Recurrence != "" {
+		names = []string{conf.Recurrence}
+	}
+	for _, name := range names {
+		r, err := recurrence.Load(name)
+		if err != nil {
+			panic(err)
+		}
+		dir := r.ArtifactsPath(conf.ArtifactsPath)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			panic(err)
+		}
+		circuitPath := filepath.Join(dir, r.Name+".circom")
+		if err := ioutil.WriteFile(circuitPath, []byte(r.Circuit(conf.NLevels)), 0644); err != nil {
+			panic(err)
+		}
+		fmt.Println(r.Name, "circuit written to", circuitPath)
+		if *build {
+			if err := buildCircuit(r.Name, dir); err != nil {
+				panic(fmt.Errorf("error building %s: %w", r.Name, err))
+			}
+		}
+	}
+}
+
+// buildCircuit runs the steps of the build-circuits script for the circuit name of dir, except the verifier export:
+// the verification key is given to RecurrenceVerifier on deployment
+func buildCircuit(name, dir string) error {
+	for _, args := range [][]string{
+		{"circom", name + ".circom", "--r1cs", "--wasm", "--sym"},
+		{"snarkjs", "zkey", "new", name + ".r1cs", "../pot15_final.ptau", name + "_0000.zkey"},
+		{"snarkjs", "zkey", "contribute", name + "_0000.zkey", name + "_final.zkey", "--name=1st Contributor Name", "-v"},
+		{"snarkjs", "zkey", "export", "verificationkey", name + "_final.zkey", recurrence.VerificationKeyFile},
+	} {
+		cmd := exec.Command(args[0], args[1:]...)
+		cmd.Dir = dir
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
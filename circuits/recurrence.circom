@@ -0,0 +1,83 @@
+include "../node_modules/circomlib/circuits/smt/smtverifier.circom";
+include "../node_modules/circomlib/circuits/smt/smtprocessor.circom";
+
+/**
+ * Process the next number of a linear recurrence F(n) = c[0]*F(n-1) + c[1]*F(n-2) + ... + c[order-1]*F(n-order).
+ * The coefficients are given by the function coefficient(i), defined by the main circuit of each variant
+ * (generated by circuitgen)
+ * @param nLevels - merkle tree depth
+ * @param order - amount of previous numbers of the sequence needed to get the next one
+ * @input senderInput - {Field} - Ethereum address of the sender, used to prevent front running attacks
+ * @input stateRoot - {Field} - root of the Merkle tree
+ * @input n - {Uint32} - the Nth element of the sequence that is being added
+ * @input Fn - {Field} - the value of the Nth element of the sequence
+ * @input siblingsFn[nLevels+1] - {Array(Field)} - Siblings Merkle proof to process (add) the new number of the sequence
+ * @input Fprev[order] - {Array(Field)} - the values of the N-1th ... N-orderth elements of the sequence
+ * @input siblingsFprev[order][nLevels+1] - {Array(Array(Field))} - Siblings Merkle proofs to demonstrate that the previous elements are already on the tree
+ * @output senderOutput - {Field} - address of the sender to avoid front running attacks
+ * @output currentRoot - {Field} - root of the Merkle Tree BEFORE adding the next element into the tree
+ * @output newRoot - {Field} - root of the Merkle Tree After adding the next element into the tree
+ */
+template Recurrence(nLevels, order) {
+    signal private input senderInput;
+    signal private input stateRoot;
+    signal private input n;
+    signal private input Fn;
+    signal private input siblingsFn[nLevels+1];
+    signal private input oldKeyFn;
+    signal private input oldValueFn;
+    signal private input isOld0Fn;
+    signal private input Fprev[order];
+    signal private input siblingsFprev[order][nLevels+1];
+
+    signal output senderOutput;
+    signal output currentRoot;
+    signal output newRoot;
+
+    var i;
+    var j;
+
+    // Proof that Fn-1 ... Fn-order are already on the tree
+    component smtFprevExists[order];
+    for (i=0; i<order; i++) {
+        smtFprevExists[i] = SMTVerifier(nLevels+1);
+        smtFprevExists[i].enabled <== 1;
+        smtFprevExists[i].fnc <== 0;
+        smtFprevExists[i].root <== stateRoot;
+        for (j=0; j<nLevels+1; j++) {
+            smtFprevExists[i].siblings[j] <== siblingsFprev[i][j];
+        }
+        smtFprevExists[i].oldKey <== 0;
+        smtFprevExists[i].oldValue <== 0;
+        smtFprevExists[i].isOld0 <== 0;
+        smtFprevExists[i].key <== n-1-i;
+        smtFprevExists[i].value <== Fprev[i];
+    }
+
+    // Assert that c[0]*Fn-1 + ... + c[order-1]*Fn-order = Fn
+    var next = 0;
+    for (i=0; i<order; i++) {
+        next += coefficient(i) * Fprev[i];
+    }
+    Fn === next;
+
+    // Process Fn: add it to the tree to get new root
+    component processor = SMTProcessor(nLevels+1);
+    processor.oldRoot <== stateRoot;
+    for (i = 0; i < nLevels+1; i++) {
+        processor.siblings[i] <== siblingsFn[i];
+    }
+    processor.oldKey <== oldKeyFn;
+    processor.oldValue <== oldValueFn;
+    processor.isOld0 <== 0;
+    processor.newKey <== n;
+    processor.newValue <== Fn;
+    // INSERT
+    processor.fnc[0] <== 1
+    processor.fnc[1] <== 0
+
+    // Output
+    senderOutput <== senderInput;
+    currentRoot <== stateRoot;
+    newRoot <== processor.newRoot;
+}
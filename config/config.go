@@ -0,0 +1,389 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/arnaubennassar/zkOnacci/game"
+	"github.com/arnaubennassar/zkOnacci/manifest"
+	"github.com/arnaubennassar/zkOnacci/signer"
+	"github.com/arnaubennassar/zkOnacci/txutil"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/naoina/toml"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// DefaultNLevels is the amount of levels of the MT of the circuit
+	DefaultNLevels = 6
+	// DefaultArtifactsPath is the path of the circom artifacts when nothing else is configured,
+	// relative to the directory of the commands
+	DefaultArtifactsPath = "../circuits"
+	// DefaultNFTsPath is the path of the NFTs metadata when nothing else is configured,
+	// relative to the directory of the commands
+	DefaultNFTsPath = "../NFTs"
+)
+
+// File is the content of a configuration file (YAML or TOML)
+type File struct {
+	// DefaultProfile is used when no profile is selected
+	DefaultProfile string             `yaml:"defaultProfile" toml:"defaultProfile"`
+	Profiles       map[string]Profile `yaml:"profiles" toml:"profiles"`
+}
+
+// Profile holds the settings of a network (e.g. devnet, testnet or production).
+// Relative paths are resolved from the directory of the configuration file
+type Profile struct {
+	Web3URL   string        `yaml:"web3URL" toml:"web3URL"`
+	Contracts ContractsFile `yaml:"contracts" toml:"contracts"`
+	// Manifest is the path of a deployment manifest, its addresses override the ones of Contracts
+	Manifest      string     `yaml:"manifest" toml:"manifest"`
+	Signer        SignerFile `yaml:"signer" toml:"signer"`
+	ArtifactsPath string     `yaml:"artifactsPath" toml:"artifactsPath"`
+	NFTsPath      string     `yaml:"nftsPath" toml:"nftsPath"`
+	// Game is the path of the game definition file, defaults to the one of the NFTs directory
+	Game    string `yaml:"game" toml:"game"`
+	NLevels int    `yaml:"nLevels" toml:"nLevels"`
+	// Recurrence is the puzzle variant (a built-in name or the path of a JSON definition), empty for the zkOnacci circuit
+	Recurrence string `yaml:"recurrence" toml:"recurrence"`
+	// Puzzle is the ID of the puzzle of zkOnacci to play, 0 (the puzzle set up on deployment) by default
+	Puzzle uint64  `yaml:"puzzle" toml:"puzzle"`
+	Gas    GasFile `yaml:"gas" toml:"gas"`
+}
+
+// ContractsFile holds the addresses of the deployed contracts
+type ContractsFile struct {
+	ZKOnacci string `yaml:"zkOnacci" toml:"zkOnacci"`
+	Verifier string `yaml:"verifier" toml:"verifier"`
+	Hints    string `yaml:"hints" toml:"hints"`
+}
+
+// SignerFile holds the signer settings. Raw private keys can't be stored in configuration files
+type SignerFile struct {
+	URL          string `yaml:"url" toml:"url"`
+	Address      string `yaml:"address" toml:"address"`
+	KeystorePath string `yaml:"keystorePath" toml:"keystorePath"`
+	PasswordFile string `yaml:"passwordFile" toml:"passwordFile"`
+}
+
+// GasFile holds the gas policy. Fees are in wei, encoded as decimal strings
+type GasFile struct {
+	GasMargin            *uint64 `yaml:"gasMargin" toml:"gasMargin"`
+	MaxFeePerGas         string  `yaml:"maxFeePerGas" toml:"maxFeePerGas"`
+	MaxPriorityFeePerGas string  `yaml:"maxPriorityFeePerGas" toml:"maxPriorityFeePerGas"`
+}
+
+// Config is the resolved configuration used by the commands
+type Config struct {
+	// Profile is the name of the selected profile (empty if no configuration file is used)
+	Profile      string
+	Web3URL      string
+	ZKOnacciAddr common.Address
+	VerifierAddr common.Address
+	HintsAddr    common.Address
+	// ManifestPath is the path of the deployment manifest (if any)
+	ManifestPath string
+	// Manifest is the deployment manifest the addresses have been taken from (if any)
+	Manifest      *manifest.Manifest
+	Signer        signer.Config
+	ArtifactsPath string
+	NFTsPath      string
+	// GamePath is the path of the game definition file used to deploy zkOnacci
+	GamePath string
+	NLevels  int
+	// Recurrence is the puzzle variant of the recurrence circuits (empty for the zkOnacci circuit)
+	Recurrence string
+	// Puzzle is the ID of the puzzle of zkOnacci to play
+	Puzzle uint64
+	Fees   txutil.FeeConfig
+}
+
+// Flags are the command line settings shared by all the commands
+type Flags struct {
+	ConfigPath      string
+	Profile         string
+	Web3URL         string
+	ZKOnacciAddr    string
+	ManifestPath    string
+	ArtifactsPath   string
+	NFTsPath        string
+	GamePath        string
+	Recurrence      string
+	Puzzle          string
+	AllowPrivateKey bool
+	// SkipManifest avoids loading the deployment manifest (used by the commands that write it)
+	SkipManifest bool
+}
+
+// RegisterFlags defines the shared flags on fs
+func RegisterFlags(fs *flag.FlagSet) *Flags {
+	f := &Flags{}
+	fs.StringVar(&f.ConfigPath, "config", "", "path of the YAML or TOML configuration file (env var CONFIG_FILE)")
+	fs.StringVar(&f.Profile, "profile", "", "profile of the configuration file to use (env var PROFILE)")
+	fs.StringVar(&f.Web3URL, "web3-url", "", "URL of the Ethereum node, overrides the configuration")
+	fs.StringVar(&f.ZKOnacciAddr, "sc-addr", "", "address of the zkOnacci SC, overrides the configuration")
+	fs.StringVar(&f.ManifestPath, "manifest", "", "deployment manifest to take the contract addresses from (env var MANIFEST)")
+	fs.StringVar(&f.ArtifactsPath, "artifacts", "", "path of the circom artifacts, overrides the configuration")
+	fs.StringVar(&f.NFTsPath, "nfts", "", "path of the NFTs metadata, overrides the configuration")
+	fs.StringVar(&f.GamePath, "game", "", "path of the game definition file, overrides the configuration")
+	fs.StringVar(&f.Recurrence, "recurrence", "", "puzzle variant (built-in name or JSON file), overrides the configuration")
+	fs.StringVar(&f.Puzzle, "puzzle", "", "ID of the puzzle of zkOnacci, overrides the configuration")
+	fs.BoolVar(&f.AllowPrivateKey, "allow-private-key-env", false, "allow reading a raw private key from the PRIVATE_KEY env var")
+	return f
+}
+
+// Load builds the configuration: the profile of the configuration file (if any) is overridden by the addresses
+// of the deployment manifest (if any), then by the env vars and finally by the flags
+func (f *Flags) Load() (*Config, error) {
+	configPath := f.ConfigPath
+	if configPath == "" {
+		configPath = os.Getenv("CONFIG_FILE")
+	}
+	profileName := f.Profile
+	if profileName == "" {
+		profileName = os.Getenv("PROFILE")
+	}
+	cfg := &Config{
+		ArtifactsPath: DefaultArtifactsPath,
+		NFTsPath:      DefaultNFTsPath,
+		NLevels:       DefaultNLevels,
+		Fees:          txutil.FeeConfig{GasMargin: txutil.DefaultGasMargin},
+	}
+	if configPath != "" {
+		file, err := LoadFile(configPath)
+		if err != nil {
+			return nil, err
+		}
+		if err := cfg.applyProfile(file, profileName, filepath.Dir(configPath)); err != nil {
+			return nil, err
+		}
+	} else if profileName != "" {
+		return nil, fmt.Errorf("profile %s selected without a configuration file", profileName)
+	}
+	if f.ManifestPath != "" {
+		cfg.ManifestPath = f.ManifestPath
+	} else if manifestPath := os.Getenv("MANIFEST"); manifestPath != "" {
+		cfg.ManifestPath = manifestPath
+	}
+	if cfg.ManifestPath != "" && !f.SkipManifest {
+		m, err := manifest.Load(cfg.ManifestPath)
+		if err != nil {
+			return nil, fmt.Errorf("error loading the manifest %s: %w", cfg.ManifestPath, err)
+		}
+		cfg.Manifest = m
+		cfg.ZKOnacciAddr = m.ZKOnacci.Address
+		cfg.VerifierAddr = m.Verifier.Address
+		// Older manifests don't have the hints contract
+		if m.Hints.Address != (common.Address{}) {
+			cfg.HintsAddr = m.Hints.Address
+		}
+	}
+	if err := cfg.applyEnv(); err != nil {
+		return nil, err
+	}
+	// Flags
+	if f.Web3URL != "" {
+		cfg.Web3URL = f.Web3URL
+	}
+	if f.ZKOnacciAddr != "" {
+		addr, err := parseAddress("-sc-addr", f.ZKOnacciAddr)
+		if err != nil {
+			return nil, err
+		}
+		cfg.ZKOnacciAddr = addr
+	}
+	if f.ArtifactsPath != "" {
+		cfg.ArtifactsPath = f.ArtifactsPath
+	}
+	if f.NFTsPath != "" {
+		cfg.NFTsPath = f.NFTsPath
+	}
+	if f.GamePath != "" {
+		cfg.GamePath = f.GamePath
+	}
+	if f.Recurrence != "" {
+		cfg.Recurrence = f.Recurrence
+	}
+	if f.Puzzle != "" {
+		puzzle, err := strconv.ParseUint(f.Puzzle, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid -puzzle: %w", err)
+		}
+		cfg.Puzzle = puzzle
+	}
+	if cfg.GamePath == "" {
+		cfg.GamePath = filepath.Join(cfg.NFTsPath, game.DefinitionFile)
+	}
+	cfg.Signer.AllowPrivateKey = f.AllowPrivateKey
+	return cfg, nil
+}
+
+// LoadFile reads a configuration file, the format is chosen by its extension (.yaml, .yml or .toml)
+func LoadFile(path string) (*File, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	file := &File{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, file)
+	case ".toml":
+		err = toml.Unmarshal(content, file)
+	default:
+		return nil, fmt.Errorf("unsupported configuration file %s, use .yaml, .yml or .toml", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", path, err)
+	}
+	return file, nil
+}
+
+// applyProfile sets the values of the selected profile of file. Relative paths are resolved from baseDir
+func (cfg *Config) applyProfile(file *File, profileName, baseDir string) error {
+	if profileName == "" {
+		profileName = file.DefaultProfile
+	}
+	if profileName == "" {
+		return errors.New("no profile selected and the configuration file has no defaultProfile")
+	}
+	profile, ok := file.Profiles[profileName]
+	if !ok {
+		return fmt.Errorf("profile %s not found in the configuration file", profileName)
+	}
+	cfg.Profile = profileName
+	resolve := func(path string) string {
+		if path == "" || filepath.IsAbs(path) {
+			return path
+		}
+		return filepath.Join(baseDir, path)
+	}
+	cfg.Web3URL = profile.Web3URL
+	var err error
+	if profile.Contracts.ZKOnacci != "" {
+		if cfg.ZKOnacciAddr, err = parseAddress("contracts.zkOnacci", profile.Contracts.ZKOnacci); err != nil {
+			return err
+		}
+	}
+	if profile.Contracts.Verifier != "" {
+		if cfg.VerifierAddr, err = parseAddress("contracts.verifier", profile.Contracts.Verifier); err != nil {
+			return err
+		}
+	}
+	if profile.Contracts.Hints != "" {
+		if cfg.HintsAddr, err = parseAddress("contracts.hints", profile.Contracts.Hints); err != nil {
+			return err
+		}
+	}
+	cfg.ManifestPath = resolve(profile.Manifest)
+	cfg.Signer = signer.Config{
+		ExternalSigner: profile.Signer.URL,
+		KeystorePath:   resolve(profile.Signer.KeystorePath),
+		PasswordFile:   resolve(profile.Signer.PasswordFile),
+	}
+	if profile.Signer.Address != "" {
+		if cfg.Signer.Address, err = parseAddress("signer.address", profile.Signer.Address); err != nil {
+			return err
+		}
+	}
+	if profile.ArtifactsPath != "" {
+		cfg.ArtifactsPath = resolve(profile.ArtifactsPath)
+	}
+	if profile.NFTsPath != "" {
+		cfg.NFTsPath = resolve(profile.NFTsPath)
+	}
+	cfg.GamePath = resolve(profile.Game)
+	if profile.NLevels != 0 {
+		cfg.NLevels = profile.NLevels
+	}
+	if strings.HasSuffix(profile.Recurrence, ".json") {
+		cfg.Recurrence = resolve(profile.Recurrence)
+	} else {
+		cfg.Recurrence = profile.Recurrence
+	}
+	cfg.Puzzle = profile.Puzzle
+	if profile.Gas.GasMargin != nil {
+		cfg.Fees.GasMargin = *profile.Gas.GasMargin
+	}
+	if cfg.Fees.MaxFeePerGas, err = parseWei("gas.maxFeePerGas", profile.Gas.MaxFeePerGas); err != nil {
+		return err
+	}
+	if cfg.Fees.MaxPriorityFeePerGas, err = parseWei("gas.maxPriorityFeePerGas", profile.Gas.MaxPriorityFeePerGas); err != nil {
+		return err
+	}
+	return nil
+}
+
+// applyEnv overrides the configuration with the env vars WEB3_URL, SC_ADDR, VERIFIER_ADDR, HINTS_ADDR, ARTIFACTS_PATH,
+// NFTS_PATH, GAME_FILE, N_LEVELS, RECURRENCE, PUZZLE, and the signer and fee env vars
+func (cfg *Config) applyEnv() error {
+	var err error
+	if web3URL := os.Getenv("WEB3_URL"); web3URL != "" {
+		cfg.Web3URL = web3URL
+	}
+	if scAddr := os.Getenv("SC_ADDR"); scAddr != "" {
+		if cfg.ZKOnacciAddr, err = parseAddress("SC_ADDR", scAddr); err != nil {
+			return err
+		}
+	}
+	if verifierAddr := os.Getenv("VERIFIER_ADDR"); verifierAddr != "" {
+		if cfg.VerifierAddr, err = parseAddress("VERIFIER_ADDR", verifierAddr); err != nil {
+			return err
+		}
+	}
+	if hintsAddr := os.Getenv("HINTS_ADDR"); hintsAddr != "" {
+		if cfg.HintsAddr, err = parseAddress("HINTS_ADDR", hintsAddr); err != nil {
+			return err
+		}
+	}
+	if artifactsPath := os.Getenv("ARTIFACTS_PATH"); artifactsPath != "" {
+		cfg.ArtifactsPath = artifactsPath
+	}
+	if nftsPath := os.Getenv("NFTS_PATH"); nftsPath != "" {
+		cfg.NFTsPath = nftsPath
+	}
+	if gamePath := os.Getenv("GAME_FILE"); gamePath != "" {
+		cfg.GamePath = gamePath
+	}
+	if nLevelsStr := os.Getenv("N_LEVELS"); nLevelsStr != "" {
+		if cfg.NLevels, err = strconv.Atoi(nLevelsStr); err != nil {
+			return fmt.Errorf("invalid N_LEVELS: %w", err)
+		}
+	}
+	if recurrence := os.Getenv("RECURRENCE"); recurrence != "" {
+		cfg.Recurrence = recurrence
+	}
+	if puzzleStr := os.Getenv("PUZZLE"); puzzleStr != "" {
+		if cfg.Puzzle, err = strconv.ParseUint(puzzleStr, 10, 64); err != nil {
+			return fmt.Errorf("invalid PUZZLE: %w", err)
+		}
+	}
+	cfg.Signer = cfg.Signer.WithEnv()
+	cfg.Fees, err = cfg.Fees.WithEnv()
+	return err
+}
+
+func parseAddress(name, addr string) (common.Address, error) {
+	if !common.IsHexAddress(addr) {
+		return common.Address{}, fmt.Errorf("invalid %s: %q is not an address", name, addr)
+	}
+	return common.HexToAddress(addr), nil
+}
+
+func parseWei(name, amount string) (*big.Int, error) {
+	if amount == "" {
+		return nil, nil
+	}
+	wei, ok := new(big.Int).SetString(amount, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid %s: %s", name, amount)
+	}
+	return wei, nil
+}
@@ -0,0 +1,216 @@
+package config
+
+import (
+	"flag"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/arnaubennassar/zkOnacci/manifest"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const yamlConfig = `
+defaultProfile: devnet
+profiles:
+  devnet:
+    web3URL: http://localhost:8545
+    contracts:
+      zkOnacci: "0x36E9CA815e61d1C7a171E638Af5681e4aB8ACc65"
+    signer:
+      keystorePath: keys/devnet.json
+      passwordFile: /etc/zkonacci/password
+    artifactsPath: circuits
+    nftsPath: nfts
+    game: events/summer.json
+    nLevels: 8
+    recurrence: variants/padovan.json
+  testnet:
+    web3URL: https://rinkeby.example.com
+    signer:
+      url: http://localhost:8550
+    recurrence: lucas
+    puzzle: 2
+    gas:
+      gasMargin: 50
+      maxFeePerGas: "3000000000"
+`
+
+const tomlConfig = `
+defaultProfile = "production"
+
+[profiles.production]
+web3URL = "https://mainnet.example.com"
+
+[profiles.production.contracts]
+zkOnacci = "0x36E9CA815e61d1C7a171E638Af5681e4aB8ACc65"
+verifier = "0x09aC8A7DD8D00C049af7C6117ECa9E3aeD8a43Ac"
+hints = "0x0000000000000000000000000000000000000003"
+
+[profiles.production.gas]
+maxPriorityFeePerGas = "1000000000"
+`
+
+func writeConfig(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+// setEnv sets an env var for the duration of the test
+func setEnv(t *testing.T, key, value string) {
+	previous, existed := os.LookupEnv(key)
+	require.NoError(t, os.Setenv(key, value))
+	t.Cleanup(func() {
+		if existed {
+			os.Setenv(key, previous)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+}
+
+func loadWithArgs(t *testing.T, args ...string) (*Config, error) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	f := RegisterFlags(fs)
+	require.NoError(t, fs.Parse(args))
+	return f.Load()
+}
+
+func TestLoadYAMLProfiles(t *testing.T) {
+	path := writeConfig(t, "config.yaml", yamlConfig)
+	dir := filepath.Dir(path)
+	// Default profile, relative paths are resolved from the directory of the file
+	cfg, err := loadWithArgs(t, "-config", path)
+	require.NoError(t, err)
+	assert.Equal(t, "devnet", cfg.Profile)
+	assert.Equal(t, "http://localhost:8545", cfg.Web3URL)
+	assert.Equal(t, common.HexToAddress("0x36E9CA815e61d1C7a171E638Af5681e4aB8ACc65"), cfg.ZKOnacciAddr)
+	assert.Equal(t, filepath.Join(dir, "keys/devnet.json"), cfg.Signer.KeystorePath)
+	assert.Equal(t, "/etc/zkonacci/password", cfg.Signer.PasswordFile)
+	assert.Equal(t, filepath.Join(dir, "circuits"), cfg.ArtifactsPath)
+	assert.Equal(t, filepath.Join(dir, "nfts"), cfg.NFTsPath)
+	assert.Equal(t, filepath.Join(dir, "events/summer.json"), cfg.GamePath)
+	assert.Equal(t, 8, cfg.NLevels)
+	assert.Equal(t, filepath.Join(dir, "variants/padovan.json"), cfg.Recurrence)
+	assert.Equal(t, uint64(20), cfg.Fees.GasMargin)
+	// Selected profile
+	cfg, err = loadWithArgs(t, "-config", path, "-profile", "testnet")
+	require.NoError(t, err)
+	assert.Equal(t, "https://rinkeby.example.com", cfg.Web3URL)
+	assert.Equal(t, "http://localhost:8550", cfg.Signer.ExternalSigner)
+	assert.Equal(t, DefaultArtifactsPath, cfg.ArtifactsPath)
+	assert.Equal(t, DefaultNFTsPath, cfg.NFTsPath)
+	assert.Equal(t, filepath.Join(DefaultNFTsPath, "game.json"), cfg.GamePath)
+	assert.Equal(t, DefaultNLevels, cfg.NLevels)
+	assert.Equal(t, "lucas", cfg.Recurrence)
+	assert.Equal(t, uint64(2), cfg.Puzzle)
+	assert.Equal(t, uint64(50), cfg.Fees.GasMargin)
+	assert.Equal(t, big.NewInt(3000000000), cfg.Fees.MaxFeePerGas)
+	assert.Nil(t, cfg.Fees.MaxPriorityFeePerGas)
+	// Unknown profile
+	_, err = loadWithArgs(t, "-config", path, "-profile", "mainnet")
+	assert.Error(t, err)
+}
+
+func TestLoadTOMLProfile(t *testing.T) {
+	path := writeConfig(t, "config.toml", tomlConfig)
+	cfg, err := loadWithArgs(t, "-config", path)
+	require.NoError(t, err)
+	assert.Equal(t, "production", cfg.Profile)
+	assert.Equal(t, "https://mainnet.example.com", cfg.Web3URL)
+	assert.Equal(t, common.HexToAddress("0x09aC8A7DD8D00C049af7C6117ECa9E3aeD8a43Ac"), cfg.VerifierAddr)
+	assert.Equal(t, common.HexToAddress("0x03"), cfg.HintsAddr)
+	assert.Equal(t, big.NewInt(1000000000), cfg.Fees.MaxPriorityFeePerGas)
+}
+
+func TestOverrides(t *testing.T) {
+	path := writeConfig(t, "config.yml", yamlConfig)
+	setEnv(t, "PROFILE", "testnet")
+	setEnv(t, "WEB3_URL", "http://env:8545")
+	setEnv(t, "SC_ADDR", "0x09aC8A7DD8D00C049af7C6117ECa9E3aeD8a43Ac")
+	setEnv(t, "GAS_MARGIN", "10")
+	setEnv(t, "KEYSTORE_PATH", "/env/key.json")
+	setEnv(t, "NFTS_PATH", "/env/nfts")
+	setEnv(t, "PUZZLE", "3")
+	// Env vars override the file
+	cfg, err := loadWithArgs(t, "-config", path)
+	require.NoError(t, err)
+	assert.Equal(t, "testnet", cfg.Profile)
+	assert.Equal(t, "http://env:8545", cfg.Web3URL)
+	assert.Equal(t, common.HexToAddress("0x09aC8A7DD8D00C049af7C6117ECa9E3aeD8a43Ac"), cfg.ZKOnacciAddr)
+	assert.Equal(t, uint64(10), cfg.Fees.GasMargin)
+	assert.Equal(t, big.NewInt(3000000000), cfg.Fees.MaxFeePerGas)
+	assert.Equal(t, "http://localhost:8550", cfg.Signer.ExternalSigner)
+	assert.Equal(t, "/env/key.json", cfg.Signer.KeystorePath)
+	assert.Equal(t, "/env/nfts/game.json", cfg.GamePath)
+	assert.Equal(t, uint64(3), cfg.Puzzle)
+	assert.False(t, cfg.Signer.AllowPrivateKey)
+	// Flags override the env vars
+	cfg, err = loadWithArgs(t, "-config", path, "-profile", "devnet", "-web3-url", "http://flag:8545",
+		"-sc-addr", "0x36E9CA815e61d1C7a171E638Af5681e4aB8ACc65", "-artifacts", "/artifacts", "-nfts", "/nfts", "-game", "/game.json",
+		"-puzzle", "4", "-allow-private-key-env")
+	require.NoError(t, err)
+	assert.Equal(t, "devnet", cfg.Profile)
+	assert.Equal(t, "http://flag:8545", cfg.Web3URL)
+	assert.Equal(t, common.HexToAddress("0x36E9CA815e61d1C7a171E638Af5681e4aB8ACc65"), cfg.ZKOnacciAddr)
+	assert.Equal(t, "/artifacts", cfg.ArtifactsPath)
+	assert.Equal(t, "/nfts", cfg.NFTsPath)
+	assert.Equal(t, "/game.json", cfg.GamePath)
+	assert.Equal(t, uint64(4), cfg.Puzzle)
+	assert.True(t, cfg.Signer.AllowPrivateKey)
+	_, err = loadWithArgs(t, "-config", path, "-puzzle", "first")
+	assert.Error(t, err)
+	// Without file
+	setEnv(t, "PROFILE", "")
+	cfg, err = loadWithArgs(t)
+	require.NoError(t, err)
+	assert.Equal(t, "http://env:8545", cfg.Web3URL)
+	assert.Equal(t, DefaultArtifactsPath, cfg.ArtifactsPath)
+	_, err = loadWithArgs(t, "-profile", "devnet")
+	assert.Error(t, err)
+}
+
+func TestManifestAddresses(t *testing.T) {
+	dir := t.TempDir()
+	m := &manifest.Manifest{
+		ChainID:  1337,
+		Verifier: manifest.Deployment{Address: common.HexToAddress("0x01")},
+		ZKOnacci: manifest.Deployment{Address: common.HexToAddress("0x02")},
+		Hints:    manifest.Deployment{Address: common.HexToAddress("0x03")},
+	}
+	require.NoError(t, m.Save(filepath.Join(dir, "deployment.json")))
+	path := filepath.Join(dir, "config.yaml")
+	require.NoError(t, ioutil.WriteFile(path, []byte(yamlConfig+"    manifest: deployment.json\n"), 0644))
+	// The manifest of the profile overrides its contracts
+	cfg, err := loadWithArgs(t, "-config", path, "-profile", "testnet")
+	require.NoError(t, err)
+	assert.Equal(t, m, cfg.Manifest)
+	assert.Equal(t, common.HexToAddress("0x01"), cfg.VerifierAddr)
+	assert.Equal(t, common.HexToAddress("0x02"), cfg.ZKOnacciAddr)
+	assert.Equal(t, common.HexToAddress("0x03"), cfg.HintsAddr)
+	// SC_ADDR and HINTS_ADDR override the manifest
+	setEnv(t, "SC_ADDR", "0x36E9CA815e61d1C7a171E638Af5681e4aB8ACc65")
+	setEnv(t, "HINTS_ADDR", "0x09aC8A7DD8D00C049af7C6117ECa9E3aeD8a43Ac")
+	cfg, err = loadWithArgs(t, "-config", path, "-profile", "testnet")
+	require.NoError(t, err)
+	assert.Equal(t, common.HexToAddress("0x36E9CA815e61d1C7a171E638Af5681e4aB8ACc65"), cfg.ZKOnacciAddr)
+	assert.Equal(t, common.HexToAddress("0x09aC8A7DD8D00C049af7C6117ECa9E3aeD8a43Ac"), cfg.HintsAddr)
+	// Missing manifest
+	missingPath := filepath.Join(dir, "missing.json")
+	_, err = loadWithArgs(t, "-manifest", missingPath)
+	assert.Error(t, err)
+	// Not loaded by the commands that write it
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	f := RegisterFlags(fs)
+	require.NoError(t, fs.Parse([]string{"-manifest", missingPath}))
+	f.SkipManifest = true
+	cfg, err = f.Load()
+	require.NoError(t, err)
+	assert.Equal(t, missingPath, cfg.ManifestPath)
+	assert.Nil(t, cfg.Manifest)
+}
@@ -0,0 +1,15 @@
+pragma solidity ^0.8.6;
+
+// Deploys contracts with CREATE2, so their addresses only depend on the address of the factory, the salt and
+// the init code (and not on the nonce of the deployer)
+contract Create2Factory {
+    event Deployed(address indexed addr, bytes32 indexed salt);
+
+    function deploy(bytes32 salt, bytes memory initCode) public returns (address addr) {
+        assembly {
+            addr := create2(0, add(initCode, 0x20), mload(initCode), salt)
+        }
+        require(addr != address(0), "Create2Factory::deploy: DEPLOYMENT_FAILED");
+        emit Deployed(addr, salt);
+    }
+}
@@ -0,0 +1,93 @@
+pragma solidity ^0.8.6;
+
+interface IZKOnacciSeasons {
+    function nPuzzles() external view returns (uint256);
+    function currentSeason(uint256 puzzleId) external view returns (uint256);
+    function season(uint256 puzzleId, uint256 seasonId) external view returns (
+        uint256 genesisRoot,
+        string memory seasonBaseURI,
+        uint16[] memory seasonTokenTiers,
+        string[] memory seasonTokenURIs,
+        address verifierAddr
+    );
+}
+
+// Hints of a zkOnacci game, published by the owner as the flags get captured (e.g. once a tier is sold out).
+// Each hint belongs to a tier of a season of a puzzle of zkOnacci
+contract ZKOnacciHints {
+    struct Hint {
+        // Where the hint can be downloaded (e.g. an IPFS URI)
+        string uri;
+        // keccak256 of the content of the hint, so players can check what they download
+        bytes32 contentHash;
+        uint256 blockNumber;
+    }
+
+    address public immutable zkOnacci;
+    address public owner;
+    // Hints of each puzzle, season and tier, in publication order
+    mapping(uint256 => mapping(uint256 => mapping(uint8 => Hint[]))) private hints;
+
+    event HintPublished(
+        uint256 indexed puzzleId,
+        uint256 indexed season,
+        uint8 indexed tier,
+        uint256 index,
+        string uri,
+        bytes32 contentHash
+    );
+    event OwnershipTransferred(address indexed previousOwner, address indexed newOwner);
+
+    modifier onlyOwner() {
+        require(msg.sender == owner, "ZKOnacciHints: NOT_OWNER");
+        _;
+    }
+
+    // The owner is a param (instead of the sender) so the contract can be deployed through a factory
+    constructor(address zkOnacciAddr, address _owner) {
+        require(_owner != address(0), "ZKOnacciHints::constructor: INVALID_OWNER");
+        zkOnacci = zkOnacciAddr;
+        owner = _owner;
+        emit OwnershipTransferred(address(0), _owner);
+    }
+
+    function publishHint(
+        uint256 puzzleId,
+        uint256 seasonId,
+        uint8 tier,
+        string memory uri,
+        bytes32 contentHash
+    ) public onlyOwner returns (uint256) {
+        require(bytes(uri).length > 0, "ZKOnacciHints::publishHint: EMPTY_URI");
+        IZKOnacciSeasons game = IZKOnacciSeasons(zkOnacci);
+        require(puzzleId < game.nPuzzles(), "ZKOnacciHints::publishHint: INVALID_PUZZLE");
+        require(seasonId <= game.currentSeason(puzzleId), "ZKOnacciHints::publishHint: INVALID_SEASON");
+        (, , uint16[] memory seasonTokenTiers, , ) = game.season(puzzleId, seasonId);
+        require(tier < seasonTokenTiers.length, "ZKOnacciHints::publishHint: INVALID_TIER");
+        Hint[] storage tierHints = hints[puzzleId][seasonId][tier];
+        tierHints.push(Hint(uri, contentHash, block.number));
+        uint256 index = tierHints.length - 1;
+        emit HintPublished(puzzleId, seasonId, tier, index, uri, contentHash);
+        return index;
+    }
+
+    function transferOwnership(address newOwner) public onlyOwner {
+        require(newOwner != address(0), "ZKOnacciHints::transferOwnership: INVALID_OWNER");
+        emit OwnershipTransferred(owner, newOwner);
+        owner = newOwner;
+    }
+
+    function hintsCount(uint256 puzzleId, uint256 seasonId, uint8 tier) public view returns (uint256) {
+        return hints[puzzleId][seasonId][tier].length;
+    }
+
+    function hint(
+        uint256 puzzleId,
+        uint256 seasonId,
+        uint8 tier,
+        uint256 index
+    ) public view returns (string memory uri, bytes32 contentHash, uint256 blockNumber) {
+        Hint storage h = hints[puzzleId][seasonId][tier][index];
+        return (h.uri, h.contentHash, h.blockNumber);
+    }
+}
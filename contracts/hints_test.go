@@ -0,0 +1,124 @@
+package contracts
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-playground/assert.v1"
+)
+
+func TestHintsAccessControl(t *testing.T) {
+	testEnv, err := newTestingEnv(big.NewInt(1), tierConfigs[0])
+	require.NoError(t, err)
+	ctx := context.Background()
+	callOpts := &bind.CallOpts{}
+	hintsAddr, _, hints, err := DeployZKOnacciHints(testEnv.auth, testEnv.client, testEnv.scAddr, testEnv.auth.From)
+	require.NoError(t, err)
+	testEnv.client.Commit()
+	owner, err := hints.Owner(callOpts)
+	require.NoError(t, err)
+	assert.Equal(t, testEnv.auth.From, owner)
+	zkOnacci, err := hints.ZkOnacci(callOpts)
+	require.NoError(t, err)
+	assert.Equal(t, testEnv.scAddr, zkOnacci)
+
+	// The owner publishes a hint
+	puzzle, season := big.NewInt(0), big.NewInt(0)
+	contentHash := crypto.Keccak256Hash([]byte("the sequence starts with 0, 1"))
+	tx, err := hints.PublishHint(testEnv.auth, puzzle, season, 1, "ipfs://hint", contentHash)
+	require.NoError(t, err)
+	testEnv.client.Commit()
+	receipt, err := testEnv.client.TransactionReceipt(ctx, tx.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), receipt.Status)
+	require.Equal(t, 1, len(receipt.Logs))
+	published, err := hints.ParseHintPublished(*receipt.Logs[0])
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), published.PuzzleId.Int64())
+	assert.Equal(t, int64(0), published.Season.Int64())
+	assert.Equal(t, uint8(1), published.Tier)
+	assert.Equal(t, int64(0), published.Index.Int64())
+	assert.Equal(t, "ipfs://hint", published.Uri)
+	assert.Equal(t, [32]byte(contentHash), published.ContentHash)
+	count, err := hints.HintsCount(callOpts, puzzle, season, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count.Int64())
+	count, err = hints.HintsCount(callOpts, puzzle, season, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count.Int64())
+	hint, err := hints.Hint(callOpts, puzzle, season, 1, big.NewInt(0))
+	require.NoError(t, err)
+	assert.Equal(t, "ipfs://hint", hint.Uri)
+	assert.Equal(t, [32]byte(contentHash), hint.ContentHash)
+	assert.Equal(t, receipt.BlockNumber.Int64(), hint.BlockNumber.Int64())
+
+	// Without a gas limit, the reverts are caught by the gas estimation
+	opts := *testEnv.auth
+	opts.GasLimit = 0
+	_, err = hints.PublishHint(&opts, puzzle, season, 4, "ipfs://hint", contentHash)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "INVALID_TIER")
+	_, err = hints.PublishHint(&opts, puzzle, season, 0, "", contentHash)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "EMPTY_URI")
+	_, err = hints.PublishHint(&opts, puzzle, big.NewInt(1), 0, "ipfs://hint", contentHash)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "INVALID_SEASON")
+	_, err = hints.PublishHint(&opts, big.NewInt(1), season, 0, "ipfs://hint", contentHash)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "INVALID_PUZZLE")
+
+	// The tiers are checked against the ones of the puzzle of the hint: the puzzle 1 has a single tier
+	verifierAddr, err := testEnv.zkOnacci.Verifier(callOpts, puzzle)
+	require.NoError(t, err)
+	_, err = testEnv.zkOnacci.AddPuzzle(testEnv.auth, verifierAddr, 2, big.NewInt(1), "ipfs://", []uint16{0}, []string{"single"})
+	require.NoError(t, err)
+	testEnv.client.Commit()
+	_, err = hints.PublishHint(&opts, big.NewInt(1), season, 1, "ipfs://hint", contentHash)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "INVALID_TIER")
+	_, err = hints.PublishHint(testEnv.auth, big.NewInt(1), season, 0, "ipfs://other", contentHash)
+	require.NoError(t, err)
+	testEnv.client.Commit()
+	// Each puzzle has its own hints
+	count, err = hints.HintsCount(callOpts, big.NewInt(1), season, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count.Int64())
+	count, err = hints.HintsCount(callOpts, puzzle, season, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count.Int64())
+
+	// Once the ownership is transferred, the previous owner can't publish hints nor get the ownership back
+	newOwner := common.HexToAddress("0x1234")
+	_, err = hints.TransferOwnership(&opts, newOwner)
+	require.NoError(t, err)
+	testEnv.client.Commit()
+	owner, err = hints.Owner(callOpts)
+	require.NoError(t, err)
+	assert.Equal(t, newOwner, owner)
+	_, err = hints.PublishHint(&opts, puzzle, season, 0, "ipfs://other", contentHash)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "NOT_OWNER")
+	_, err = hints.TransferOwnership(&opts, testEnv.auth.From)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "NOT_OWNER")
+	count, err = hints.HintsCount(callOpts, puzzle, season, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count.Int64())
+
+	// The owner can't be the zero address
+	noOwnerAddr, _, _, err := DeployZKOnacciHints(testEnv.auth, testEnv.client, testEnv.scAddr, common.Address{})
+	require.NoError(t, err)
+	testEnv.client.Commit()
+	code, err := testEnv.client.CodeAt(ctx, noOwnerAddr, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(code))
+	code, err = testEnv.client.CodeAt(ctx, hintsAddr, nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, 0, len(code))
+}
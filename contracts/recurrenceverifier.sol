@@ -0,0 +1,93 @@
+pragma solidity ^0.8.6;
+
+// Groth16 verifier of the recurrence circuits. Unlike the verifier exported by snarkjs, the verification key is set on
+// deployment, so the same bytecode verifies the proofs of any variant (one deployment per variant).
+// It has the same interface as the snarkjs verifier, so zkOnacci can use either of them
+contract RecurrenceVerifier {
+    // Public signals of the circuits: senderOutput, currentRoot and newRoot
+    uint256 public constant N_PUBLIC = 3;
+    uint256 internal constant SNARK_SCALAR_FIELD = 21888242871839275222246405745257275088548364400416034343698204186575808495617;
+    uint256 internal constant PRIME_Q = 21888242871839275222246405745257275088696311157297823662689037894645226208583;
+
+    // Verification key. G1 points are [x, y] and G2 points [[x1, x0], [y1, y0]], as exported by snarkjs for solidity
+    uint256[2] public alpha1;
+    uint256[2][2] public beta2;
+    uint256[2][2] public gamma2;
+    uint256[2][2] public delta2;
+    uint256[2][N_PUBLIC + 1] public ic;
+
+    constructor(
+        uint256[2] memory _alpha1,
+        uint256[2][2] memory _beta2,
+        uint256[2][2] memory _gamma2,
+        uint256[2][2] memory _delta2,
+        uint256[2][N_PUBLIC + 1] memory _ic
+    ) {
+        alpha1 = _alpha1;
+        beta2 = _beta2;
+        gamma2 = _gamma2;
+        delta2 = _delta2;
+        ic = _ic;
+    }
+
+    function verifyProof(
+        uint256[2] memory a,
+        uint256[2][2] memory b,
+        uint256[2] memory c,
+        uint256[N_PUBLIC] memory input
+    ) public view returns (bool r) {
+        // vkX = ic[0] + sum(input[i] * ic[i+1])
+        uint256[2] memory vkX = ic[0];
+        for (uint256 i = 0; i < N_PUBLIC; i++) {
+            require(input[i] < SNARK_SCALAR_FIELD, "RecurrenceVerifier: INVALID_INPUT");
+            vkX = _add(vkX, _mul(ic[i + 1], input[i]));
+        }
+        // e(-a, b) * e(alpha1, beta2) * e(vkX, gamma2) * e(c, delta2) == 1
+        uint256[24] memory pairingInput;
+        _setPair(pairingInput, 0, _negate(a), b);
+        _setPair(pairingInput, 1, alpha1, beta2);
+        _setPair(pairingInput, 2, vkX, gamma2);
+        _setPair(pairingInput, 3, c, delta2);
+        uint256[1] memory out;
+        bool success;
+        assembly {
+            success := staticcall(sub(gas(), 2000), 8, pairingInput, 768, out, 0x20)
+        }
+        require(success, "RecurrenceVerifier: PAIRING_FAILED");
+        return out[0] != 0;
+    }
+
+    function _setPair(uint256[24] memory pairingInput, uint256 i, uint256[2] memory g1, uint256[2][2] memory g2) private pure {
+        pairingInput[i * 6] = g1[0];
+        pairingInput[i * 6 + 1] = g1[1];
+        pairingInput[i * 6 + 2] = g2[0][0];
+        pairingInput[i * 6 + 3] = g2[0][1];
+        pairingInput[i * 6 + 4] = g2[1][0];
+        pairingInput[i * 6 + 5] = g2[1][1];
+    }
+
+    function _negate(uint256[2] memory p) private pure returns (uint256[2] memory) {
+        if (p[0] == 0 && p[1] == 0) {
+            return p;
+        }
+        return [p[0], PRIME_Q - (p[1] % PRIME_Q)];
+    }
+
+    function _add(uint256[2] memory p1, uint256[2] memory p2) private view returns (uint256[2] memory r) {
+        uint256[4] memory input = [p1[0], p1[1], p2[0], p2[1]];
+        bool success;
+        assembly {
+            success := staticcall(sub(gas(), 2000), 6, input, 0x80, r, 0x40)
+        }
+        require(success, "RecurrenceVerifier: ADD_FAILED");
+    }
+
+    function _mul(uint256[2] memory p, uint256 s) private view returns (uint256[2] memory r) {
+        uint256[3] memory input = [p[0], p[1], s];
+        bool success;
+        assembly {
+            success := staticcall(sub(gas(), 2000), 7, input, 0x60, r, 0x40)
+        }
+        require(success, "RecurrenceVerifier: MUL_FAILED");
+    }
+}
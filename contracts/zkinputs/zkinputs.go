@@ -32,6 +32,16 @@ func GenerateProof(input ZKInput, circomArtifactsPath string) (
 	proofB [2][2]*big.Int,
 	proofC [2]*big.Int,
 	err error,
+) {
+	return GenerateCircuitProof(input, circomArtifactsPath, "zkOnacci")
+}
+
+// GenerateCircuitProof proves input with the artifacts of circuit (<circuit>.wasm and <circuit>_final.zkey) of circomArtifactsPath
+func GenerateCircuitProof(input interface{}, circomArtifactsPath, circuit string) (
+	proofA [2]*big.Int,
+	proofB [2][2]*big.Int,
+	proofC [2]*big.Int,
+	err error,
 ) {
 	inputJson, err := json.Marshal(input)
 	if err != nil {
@@ -44,14 +54,14 @@ func GenerateProof(input ZKInput, circomArtifactsPath string) (
 	var cmdOut []byte
 	if cmdOut, err = exec.Command(
 		`snarkjs`, `wtns`, `calculate`,
-		circomArtifactsPath+`/zkOnacci.wasm`, circomArtifactsPath+`/input.json`, circomArtifactsPath+`/witness.wtns`,
+		circomArtifactsPath+`/`+circuit+`.wasm`, circomArtifactsPath+`/input.json`, circomArtifactsPath+`/witness.wtns`,
 	).Output(); err != nil {
 		fmt.Println(string(cmdOut))
 		return
 	}
 	// Generate proof
 	if cmdOut, err = exec.Command(`snarkjs`, `groth16`, `prove`,
-		circomArtifactsPath+`/zkOnacci_final.zkey`, circomArtifactsPath+`/witness.wtns`,
+		circomArtifactsPath+`/`+circuit+`_final.zkey`, circomArtifactsPath+`/witness.wtns`,
 		circomArtifactsPath+`/proof.json`, circomArtifactsPath+`/public.json`,
 	).Output(); err != nil {
 		fmt.Println(string(cmdOut))
@@ -30,7 +30,7 @@ var (
 const AddressABI = "[]"
 
 // AddressBin is the compiled bytecode used for deploying new contracts.
-var AddressBin = "0x60566037600b82828239805160001a607314602a57634e487b7160e01b600052600060045260246000fd5b30600052607381538281f3fe73000000000000000000000000000000000000000030146080604052600080fdfea2646970667358221220e1e9922bd7fc75cb07c9d0f68e6bc59203110dacc21af986364bd0ccf07cfafe64736f6c63430008060033"
+var AddressBin = "0x60566037600b82828239805160001a607314602a57634e487b7160e01b600052600060045260246000fd5b30600052607381538281f3fe73000000000000000000000000000000000000000030146080604052600080fdfea2646970667358221220b97cdfe5864b11c35dc65e81c5a02036afb13a404bb43870899dd1e9d2bb81cb64736f6c63430008150033"
 
 // DeployAddress deploys a new Ethereum contract, binding an instance of Address to it.
 func DeployAddress(auth *bind.TransactOpts, backend bind.ContractBackend) (common.Address, *types.Transaction, *Address, error) {
@@ -535,7 +535,7 @@ var ERC721FuncSigs = map[string]string{
 }
 
 // ERC721Bin is the compiled bytecode used for deploying new contracts.
-var ERC721Bin = "0x60806040523480156200001157600080fd5b50604051620014d8380380620014d88339810160408190526200003491620001c5565b81516200004990600090602085019062000068565b5080516200005f90600190602084019062000068565b50505062000282565b82805462000076906200022f565b90600052602060002090601f0160209004810192826200009a5760008555620000e5565b82601f10620000b557805160ff1916838001178555620000e5565b82800160010185558215620000e5579182015b82811115620000e5578251825591602001919060010190620000c8565b50620000f3929150620000f7565b5090565b5b80821115620000f35760008155600101620000f8565b600082601f8301126200012057600080fd5b81516001600160401b03808211156200013d576200013d6200026c565b604051601f8301601f19908116603f011681019082821181831017156200016857620001686200026c565b816040528381526020925086838588010111156200018557600080fd5b600091505b83821015620001a957858201830151818301840152908201906200018a565b83821115620001bb5760008385830101525b9695505050505050565b60008060408385031215620001d957600080fd5b82516001600160401b0380821115620001f157600080fd5b620001ff868387016200010e565b935060208501519150808211156200021657600080fd5b5062000225858286016200010e565b9150509250929050565b600181811c908216806200024457607f821691505b602082108114156200026657634e487b7160e01b600052602260045260246000fd5b50919050565b634e487b7160e01b600052604160045260246000fd5b61124680620002926000396000f3fe608060405234801561001057600080fd5b50600436106100cf5760003560e01c80636352211e1161008c578063a22cb46511610066578063a22cb465146101b3578063b88d4fde146101c6578063c87b56dd146101d9578063e985e9c5146101ec57600080fd5b80636352211e1461017757806370a082311461018a57806395d89b41146101ab57600080fd5b806301ffc9a7146100d457806306fdde03146100fc578063081812fc14610111578063095ea7b31461013c57806323b872dd1461015157806342842e0e14610164575b600080fd5b6100e76100e2366004610f25565b610228565b60405190151581526020015b60405180910390f35b61010461027a565b6040516100f39190611010565b61012461011f366004610f5f565b61030c565b6040516001600160a01b0390911681526020016100f3565b61014f61014a366004610efb565b6103a6565b005b61014f61015f366004610da7565b6104bc565b61014f610172366004610da7565b6104ed565b610124610185366004610f5f565b610508565b61019d610198366004610d59565b61057f565b6040519081526020016100f3565b610104610606565b61014f6101c1366004610ebf565b610615565b61014f6101d4366004610de3565b6106da565b6101046101e7366004610f5f565b610712565b6100e76101fa366004610d74565b6001600160a01b03918216600090815260056020908152604080832093909416825291909152205460ff1690565b60006001600160e01b031982166380ac58cd60e01b148061025957506001600160e01b03198216635b5e139f60e01b145b8061027457506301ffc9a760e01b6001600160e01b03198316145b92915050565b60606000805461028990611135565b80601f01602080910402602001604051908101604052809291908181526020018280546102b590611135565b80156103025780601f106102d757610100808354040283529160200191610302565b820191906000526020600020905b8154815290600101906020018083116102e557829003601f168201915b5050505050905090565b6000818152600260205260408120546001600160a01b031661038a5760405162461bcd60e51b815260206004820152602c60248201527f4552433732313a20617070726f76656420717565727920666f72206e6f6e657860448201526b34b9ba32b73a103a37b5b2b760a11b60648201526084015b60405180910390fd5b506000908152600460205260409020546001600160a01b031690565b60006103b182610508565b9050806001600160a01b0316836001600160a01b0316141561041f5760405162461bcd60e51b815260206004820152602160248201527f4552433732313a20617070726f76616c20746f2063757272656e74206f776e656044820152603960f91b6064820152608401610381565b336001600160a01b038216148061043b575061043b81336101fa565b6104ad5760405162461bcd60e51b815260206004820152603860248201527f4552433732313a20617070726f76652063616c6c6572206973206e6f74206f7760448201527f6e6572206e6f7220617070726f76656420666f7220616c6c00000000000000006064820152608401610381565b6104b783836107fa565b505050565b6104c63382610868565b6104e25760405162461bcd60e51b815260040161038190611075565b6104b783838361095f565b6104b7838383604051806020016040528060008152506106da565b6000818152600260205260408120546001600160a01b0316806102745760405162461bcd60e51b815260206004820152602960248201527f4552433732313a206f776e657220717565727920666f72206e6f6e657869737460448201526832b73a103a37b5b2b760b91b6064820152608401610381565b60006001600160a01b0382166105ea5760405162461bcd60e51b815260206004820152602a60248201527f4552433732313a2062616c616e636520717565727920666f7220746865207a65604482015269726f206164647265737360b01b6064820152608401610381565b506001600160a01b031660009081526003602052604090205490565b60606001805461028990611135565b6001600160a01b03821633141561066e5760405162461bcd60e51b815260206004820152601960248201527f4552433732313a20617070726f766520746f2063616c6c6572000000000000006044820152606401610381565b3360008181526005602090815260408083206001600160a01b03871680855290835292819020805460ff191686151590811790915590519081529192917f17307eab39ab6107e8899845ad3d59bd9653f200f220920489ca2b5937696c31910160405180910390a35050565b6106e43383610868565b6107005760405162461bcd60e51b815260040161038190611075565b61070c84848484610aff565b50505050565b6000818152600260205260409020546060906001600160a01b03166107915760405162461bcd60e51b815260206004820152602f60248201527f4552433732314d657461646174613a2055524920717565727920666f72206e6f60448201526e3732bc34b9ba32b73a103a37b5b2b760891b6064820152608401610381565b60006107a860408051602081019091526000815290565b905060008151116107c857604051806020016040528060008152506107f3565b806107d284610b32565b6040516020016107e3929190610fa4565b6040516020818303038152906040525b9392505050565b600081815260046020526040902080546001600160a01b0319166001600160a01b038416908117909155819061082f82610508565b6001600160a01b03167f8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b92560405160405180910390a45050565b6000818152600260205260408120546001600160a01b03166108e15760405162461bcd60e51b815260206004820152602c60248201527f4552433732313a206f70657261746f7220717565727920666f72206e6f6e657860448201526b34b9ba32b73a103a37b5b2b760a11b6064820152608401610381565b60006108ec83610508565b9050806001600160a01b0316846001600160a01b031614806109275750836001600160a01b031661091c8461030c565b6001600160a01b0316145b8061095757506001600160a01b0380821660009081526005602090815260408083209388168352929052205460ff165b949350505050565b826001600160a01b031661097282610508565b6001600160a01b0316146109da5760405162461bcd60e51b815260206004820152602960248201527f4552433732313a207472616e73666572206f6620746f6b656e2074686174206960448201526839903737ba1037bbb760b91b6064820152608401610381565b6001600160a01b038216610a3c5760405162461bcd60e51b8152602060048201526024808201527f4552433732313a207472616e7366657220746f20746865207a65726f206164646044820152637265737360e01b6064820152608401610381565b610a476000826107fa565b6001600160a01b0383166000908152600360205260408120805460019290610a709084906110f2565b90915550506001600160a01b0382166000908152600360205260408120805460019290610a9e9084906110c6565b909155505060008181526002602052604080822080546001600160a01b0319166001600160a01b0386811691821790925591518493918716917fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef91a4505050565b610b0a84848461095f565b610b1684848484610c30565b61070c5760405162461bcd60e51b815260040161038190611023565b606081610b565750506040805180820190915260018152600360fc1b602082015290565b8160005b8115610b805780610b6a81611170565b9150610b799050600a836110de565b9150610b5a565b60008167ffffffffffffffff811115610b9b57610b9b6111e1565b6040519080825280601f01601f191660200182016040528015610bc5576020820181803683370190505b5090505b841561095757610bda6001836110f2565b9150610be7600a8661118b565b610bf29060306110c6565b60f81b818381518110610c0757610c076111cb565b60200101906001600160f81b031916908160001a905350610c29600a866110de565b9450610bc9565b60006001600160a01b0384163b15610d3257604051630a85bd0160e11b81526001600160a01b0385169063150b7a0290610c74903390899088908890600401610fd3565b602060405180830381600087803b158015610c8e57600080fd5b505af1925050508015610cbe575060408051601f3d908101601f19168201909252610cbb91810190610f42565b60015b610d18573d808015610cec576040519150601f19603f3d011682016040523d82523d6000602084013e610cf1565b606091505b508051610d105760405162461bcd60e51b815260040161038190611023565b805181602001fd5b6001600160e01b031916630a85bd0160e11b149050610957565b506001949350505050565b80356001600160a01b0381168114610d5457600080fd5b919050565b600060208284031215610d6b57600080fd5b6107f382610d3d565b60008060408385031215610d8757600080fd5b610d9083610d3d565b9150610d9e60208401610d3d565b90509250929050565b600080600060608486031215610dbc57600080fd5b610dc584610d3d565b9250610dd360208501610d3d565b9150604084013590509250925092565b60008060008060808587031215610df957600080fd5b610e0285610d3d565b9350610e1060208601610d3d565b925060408501359150606085013567ffffffffffffffff80821115610e3457600080fd5b818701915087601f830112610e4857600080fd5b813581811115610e5a57610e5a6111e1565b604051601f8201601f19908116603f01168101908382118183101715610e8257610e826111e1565b816040528281528a6020848701011115610e9b57600080fd5b82602086016020830137600060208483010152809550505050505092959194509250565b60008060408385031215610ed257600080fd5b610edb83610d3d565b915060208301358015158114610ef057600080fd5b809150509250929050565b60008060408385031215610f0e57600080fd5b610f1783610d3d565b946020939093013593505050565b600060208284031215610f3757600080fd5b81356107f3816111f7565b600060208284031215610f5457600080fd5b81516107f3816111f7565b600060208284031215610f7157600080fd5b5035919050565b60008151808452610f90816020860160208601611109565b601f01601f19169290920160200192915050565b60008351610fb6818460208801611109565b835190830190610fca818360208801611109565b01949350505050565b6001600160a01b038581168252841660208201526040810183905260806060820181905260009061100690830184610f78565b9695505050505050565b6020815260006107f36020830184610f78565b60208082526032908201527f4552433732313a207472616e7366657220746f206e6f6e20455243373231526560408201527131b2b4bb32b91034b6b83632b6b2b73a32b960711b606082015260800190565b60208082526031908201527f4552433732313a207472616e736665722063616c6c6572206973206e6f74206f6040820152701ddb995c881b9bdc88185c1c1c9bdd9959607a1b606082015260800190565b600082198211156110d9576110d961119f565b500190565b6000826110ed576110ed6111b5565b500490565b6000828210156111045761110461119f565b500390565b60005b8381101561112457818101518382015260200161110c565b8381111561070c5750506000910152565b600181811c9082168061114957607f821691505b6020821081141561116a57634e487b7160e01b600052602260045260246000fd5b50919050565b60006000198214156111845761118461119f565b5060010190565b60008261119a5761119a6111b5565b500690565b634e487b7160e01b600052601160045260246000fd5b634e487b7160e01b600052601260045260246000fd5b634e487b7160e01b600052603260045260246000fd5b634e487b7160e01b600052604160045260246000fd5b6001600160e01b03198116811461120d57600080fd5b5056fea26469706673582212206cd9be29bb9f05d367d0b8d532fbb7f42eae34ba6e20b22c300e6d6a1f2094ff64736f6c63430008060033"
+var ERC721Bin = "0x60806040523480156200001157600080fd5b50604051620015003803806200150083398101604081905262000034916200011f565b600062000042838262000218565b50600162000051828262000218565b505050620002e4565b634e487b7160e01b600052604160045260246000fd5b600082601f8301126200008257600080fd5b81516001600160401b03808211156200009f576200009f6200005a565b604051601f8301601f19908116603f01168101908282118183101715620000ca57620000ca6200005a565b81604052838152602092508683858801011115620000e757600080fd5b600091505b838210156200010b5785820183015181830184015290820190620000ec565b600093810190920192909252949350505050565b600080604083850312156200013357600080fd5b82516001600160401b03808211156200014b57600080fd5b620001598683870162000070565b935060208501519150808211156200017057600080fd5b506200017f8582860162000070565b9150509250929050565b600181811c908216806200019e57607f821691505b602082108103620001bf57634e487b7160e01b600052602260045260246000fd5b50919050565b601f8211156200021357600081815260208120601f850160051c81016020861015620001ee5750805b601f850160051c820191505b818110156200020f57828155600101620001fa565b5050505b505050565b81516001600160401b038111156200023457620002346200005a565b6200024c8162000245845462000189565b84620001c5565b602080601f8311600181146200028457600084156200026b5750858301515b600019600386901b1c1916600185901b1785556200020f565b600085815260208120601f198616915b82811015620002b55788860151825594840194600190910190840162000294565b5085821015620002d45787850151600019600388901b60f8161c191681555b5050505050600190811b01905550565b61120c80620002f46000396000f3fe608060405234801561001057600080fd5b50600436106100cf5760003560e01c80636352211e1161008c578063a22cb46511610066578063a22cb465146101b3578063b88d4fde146101c6578063c87b56dd146101d9578063e985e9c5146101ec57600080fd5b80636352211e1461017757806370a082311461018a57806395d89b41146101ab57600080fd5b806301ffc9a7146100d457806306fdde03146100fc578063081812fc14610111578063095ea7b31461013c57806323b872dd1461015157806342842e0e14610164575b600080fd5b6100e76100e2366004610d30565b6101ff565b60405190151581526020015b60405180910390f35b610104610251565b6040516100f39190610d9d565b61012461011f366004610db0565b6102e3565b6040516001600160a01b0390911681526020016100f3565b61014f61014a366004610de5565b61037d565b005b61014f61015f366004610e0f565b610492565b61014f610172366004610e0f565b6104c3565b610124610185366004610db0565b6104de565b61019d610198366004610e4b565b610555565b6040519081526020016100f3565b6101046105dc565b61014f6101c1366004610e66565b6105eb565b61014f6101d4366004610eb8565b6106af565b6101046101e7366004610db0565b6106e7565b6100e76101fa366004610f94565b6107cf565b60006001600160e01b031982166380ac58cd60e01b148061023057506001600160e01b03198216635b5e139f60e01b145b8061024b57506301ffc9a760e01b6001600160e01b03198316145b92915050565b60606000805461026090610fc7565b80601f016020809104026020016040519081016040528092919081815260200182805461028c90610fc7565b80156102d95780601f106102ae576101008083540402835291602001916102d9565b820191906000526020600020905b8154815290600101906020018083116102bc57829003601f168201915b5050505050905090565b6000818152600260205260408120546001600160a01b03166103615760405162461bcd60e51b815260206004820152602c60248201527f4552433732313a20617070726f76656420717565727920666f72206e6f6e657860448201526b34b9ba32b73a103a37b5b2b760a11b60648201526084015b60405180910390fd5b506000908152600460205260409020546001600160a01b031690565b6000610388826104de565b9050806001600160a01b0316836001600160a01b0316036103f55760405162461bcd60e51b815260206004820152602160248201527f4552433732313a20617070726f76616c20746f2063757272656e74206f776e656044820152603960f91b6064820152608401610358565b336001600160a01b0382161480610411575061041181336107cf565b6104835760405162461bcd60e51b815260206004820152603860248201527f4552433732313a20617070726f76652063616c6c6572206973206e6f74206f7760448201527f6e6572206e6f7220617070726f76656420666f7220616c6c00000000000000006064820152608401610358565b61048d83836107fd565b505050565b61049c338261086b565b6104b85760405162461bcd60e51b815260040161035890611001565b61048d838383610942565b61048d838383604051806020016040528060008152506106af565b6000818152600260205260408120546001600160a01b03168061024b5760405162461bcd60e51b815260206004820152602960248201527f4552433732313a206f776e657220717565727920666f72206e6f6e657869737460448201526832b73a103a37b5b2b760b91b6064820152608401610358565b60006001600160a01b0382166105c05760405162461bcd60e51b815260206004820152602a60248201527f4552433732313a2062616c616e636520717565727920666f7220746865207a65604482015269726f206164647265737360b01b6064820152608401610358565b506001600160a01b031660009081526003602052604090205490565b60606001805461026090610fc7565b336001600160a01b038316036106435760405162461bcd60e51b815260206004820152601960248201527f4552433732313a20617070726f766520746f2063616c6c6572000000000000006044820152606401610358565b3360008181526005602090815260408083206001600160a01b03871680855290835292819020805460ff191686151590811790915590519081529192917f17307eab39ab6107e8899845ad3d59bd9653f200f220920489ca2b5937696c31910160405180910390a35050565b6106b9338361086b565b6106d55760405162461bcd60e51b815260040161035890611001565b6106e184848484610ae2565b50505050565b6000818152600260205260409020546060906001600160a01b03166107665760405162461bcd60e51b815260206004820152602f60248201527f4552433732314d657461646174613a2055524920717565727920666f72206e6f60448201526e3732bc34b9ba32b73a103a37b5b2b760891b6064820152608401610358565b600061077d60408051602081019091526000815290565b9050600081511161079d57604051806020016040528060008152506107c8565b806107a784610b15565b6040516020016107b8929190611052565b6040516020818303038152906040525b9392505050565b6001600160a01b03918216600090815260056020908152604080832093909416825291909152205460ff1690565b600081815260046020526040902080546001600160a01b0319166001600160a01b0384169081179091558190610832826104de565b6001600160a01b03167f8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b92560405160405180910390a45050565b6000818152600260205260408120546001600160a01b03166108e45760405162461bcd60e51b815260206004820152602c60248201527f4552433732313a206f70657261746f7220717565727920666f72206e6f6e657860448201526b34b9ba32b73a103a37b5b2b760a11b6064820152608401610358565b60006108ef836104de565b9050806001600160a01b0316846001600160a01b0316148061092a5750836001600160a01b031661091f846102e3565b6001600160a01b0316145b8061093a575061093a81856107cf565b949350505050565b826001600160a01b0316610955826104de565b6001600160a01b0316146109bd5760405162461bcd60e51b815260206004820152602960248201527f4552433732313a207472616e73666572206f6620746f6b656e2074686174206960448201526839903737ba1037bbb760b91b6064820152608401610358565b6001600160a01b038216610a1f5760405162461bcd60e51b8152602060048201526024808201527f4552433732313a207472616e7366657220746f20746865207a65726f206164646044820152637265737360e01b6064820152608401610358565b610a2a6000826107fd565b6001600160a01b0383166000908152600360205260408120805460019290610a53908490611097565b90915550506001600160a01b0382166000908152600360205260408120805460019290610a819084906110aa565b909155505060008181526002602052604080822080546001600160a01b0319166001600160a01b0386811691821790925591518493918716917fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef91a4505050565b610aed848484610942565b610af984848484610c16565b6106e15760405162461bcd60e51b8152600401610358906110bd565b606081600003610b3c5750506040805180820190915260018152600360fc1b602082015290565b8160005b8115610b665780610b508161110f565b9150610b5f9050600a8361113e565b9150610b40565b60008167ffffffffffffffff811115610b8157610b81610ea2565b6040519080825280601f01601f191660200182016040528015610bab576020820181803683370190505b5090505b841561093a57610bc0600183611097565b9150610bcd600a86611152565b610bd89060306110aa565b60f81b818381518110610bed57610bed611166565b60200101906001600160f81b031916908160001a905350610c0f600a8661113e565b9450610baf565b60006001600160a01b0384163b15610d0c57604051630a85bd0160e11b81526001600160a01b0385169063150b7a0290610c5a90339089908890889060040161117c565b6020604051808303816000875af1925050508015610c95575060408051601f3d908101601f19168201909252610c92918101906111b9565b60015b610cf2573d808015610cc3576040519150601f19603f3d011682016040523d82523d6000602084013e610cc8565b606091505b508051600003610cea5760405162461bcd60e51b8152600401610358906110bd565b805181602001fd5b6001600160e01b031916630a85bd0160e11b14905061093a565b506001949350505050565b6001600160e01b031981168114610d2d57600080fd5b50565b600060208284031215610d4257600080fd5b81356107c881610d17565b60005b83811015610d68578181015183820152602001610d50565b50506000910152565b60008151808452610d89816020860160208601610d4d565b601f01601f19169290920160200192915050565b6020815260006107c86020830184610d71565b600060208284031215610dc257600080fd5b5035919050565b80356001600160a01b0381168114610de057600080fd5b919050565b60008060408385031215610df857600080fd5b610e0183610dc9565b946020939093013593505050565b600080600060608486031215610e2457600080fd5b610e2d84610dc9565b9250610e3b60208501610dc9565b9150604084013590509250925092565b600060208284031215610e5d57600080fd5b6107c882610dc9565b60008060408385031215610e7957600080fd5b610e8283610dc9565b915060208301358015158114610e9757600080fd5b809150509250929050565b634e487b7160e01b600052604160045260246000fd5b60008060008060808587031215610ece57600080fd5b610ed785610dc9565b9350610ee560208601610dc9565b925060408501359150606085013567ffffffffffffffff80821115610f0957600080fd5b818701915087601f830112610f1d57600080fd5b813581811115610f2f57610f2f610ea2565b604051601f8201601f19908116603f01168101908382118183101715610f5757610f57610ea2565b816040528281528a6020848701011115610f7057600080fd5b82602086016020830137600060208483010152809550505050505092959194509250565b60008060408385031215610fa757600080fd5b610fb083610dc9565b9150610fbe60208401610dc9565b90509250929050565b600181811c90821680610fdb57607f821691505b602082108103610ffb57634e487b7160e01b600052602260045260246000fd5b50919050565b60208082526031908201527f4552433732313a207472616e736665722063616c6c6572206973206e6f74206f6040820152701ddb995c881b9bdc88185c1c1c9bdd9959607a1b606082015260800190565b60008351611064818460208801610d4d565b835190830190611078818360208801610d4d565b01949350505050565b634e487b7160e01b600052601160045260246000fd5b8181038181111561024b5761024b611081565b8082018082111561024b5761024b611081565b60208082526032908201527f4552433732313a207472616e7366657220746f206e6f6e20455243373231526560408201527131b2b4bb32b91034b6b83632b6b2b73a32b960711b606082015260800190565b60006001820161112157611121611081565b5060010190565b634e487b7160e01b600052601260045260246000fd5b60008261114d5761114d611128565b500490565b60008261116157611161611128565b500690565b634e487b7160e01b600052603260045260246000fd5b6001600160a01b03858116825284166020820152604081018390526080606082018190526000906111af90830184610d71565b9695505050505050565b6000602082840312156111cb57600080fd5b81516107c881610d1756fea2646970667358221220cf6a648dfe80dd5f17c7c353e8b60f6ae5b406abe65c4903508c157edc1a59d064736f6c63430008150033"
 
 // DeployERC721 deploys a new Ethereum contract, binding an instance of ERC721 to it.
 func DeployERC721(auth *bind.TransactOpts, backend bind.ContractBackend, name_ string, symbol_ string) (common.Address, *types.Transaction, *ERC721, error) {
@@ -3932,7 +3932,7 @@ func (_Pairing *PairingTransactorRaw) Transact(opts *bind.TransactOpts, method s
 const StringsABI = "[]"
 
 // StringsBin is the compiled bytecode used for deploying new contracts.
-var StringsBin = "0x60566037600b82828239805160001a607314602a57634e487b7160e01b600052600060045260246000fd5b30600052607381538281f3fe73000000000000000000000000000000000000000030146080604052600080fdfea26469706673582212209eede71638cfb849633e9d0300913762e4dc36e9b8893d42c3860cad5be4d07364736f6c63430008060033"
+var StringsBin = "0x60566037600b82828239805160001a607314602a57634e487b7160e01b600052600060045260246000fd5b30600052607381538281f3fe73000000000000000000000000000000000000000030146080604052600080fdfea26469706673582212200dea130ddb0c22290703d95668bade5d958654704aceacc58079aaa2a12f93fb64736f6c63430008150033"
 
 // DeployStrings deploys a new Ethereum contract, binding an instance of Strings to it.
 func DeployStrings(auth *bind.TransactOpts, backend bind.ContractBackend) (common.Address, *types.Transaction, *Strings, error) {
@@ -4289,43 +4289,68 @@ func (_Verifier *VerifierCallerSession) VerifyProof(a [2]*big.Int, b [2][2]*big.
 }
 
 // ZKOnacciABI is the input ABI used to generate the binding from.
-const ZKOnacciABI = "[{\"inputs\":[{\"internalType\":\"address\",\"name\":\"verifierAddr\",\"type\":\"address\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"approved\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"}],\"name\":\"Approval\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"bool\",\"name\":\"approved\",\"type\":\"bool\"}],\"name\":\"ApprovalForAll\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"}],\"name\":\"Transfer\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"}],\"name\":\"approve\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"}],\"name\":\"balanceOf\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"baseURI\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256[2]\",\"name\":\"proofA\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256[2][2]\",\"name\":\"proofB\",\"type\":\"uint256[2][2]\"},{\"internalType\":\"uint256[2]\",\"name\":\"proofC\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256\",\"name\":\"nextRoot\",\"type\":\"uint256\"}],\"name\":\"captureTheFlag\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"}],\"name\":\"getApproved\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"}],\"name\":\"isApprovedForAll\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"nTiers\",\"outputs\":[{\"internalType\":\"uint8\",\"name\":\"\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"name\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"}],\"name\":\"ownerOf\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"root\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"}],\"name\":\"safeTransferFrom\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"},{\"internalType\":\"bytes\",\"name\":\"_data\",\"type\":\"bytes\"}],\"name\":\"safeTransferFrom\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"},{\"internalType\":\"bool\",\"name\":\"approved\",\"type\":\"bool\"}],\"name\":\"setApprovalForAll\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes4\",\"name\":\"interfaceId\",\"type\":\"bytes4\"}],\"name\":\"supportsInterface\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"symbol\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"tokenCounter\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"tokenTiers\",\"outputs\":[{\"internalType\":\"uint16\",\"name\":\"\",\"type\":\"uint16\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"}],\"name\":\"tokenURI\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"tokenURIs\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"}],\"name\":\"transferFrom\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]"
+const ZKOnacciABI = "[{\"inputs\":[{\"internalType\":\"address\",\"name\":\"verifierAddr\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"genesisRoot\",\"type\":\"uint256\"},{\"internalType\":\"string\",\"name\":\"_baseURI\",\"type\":\"string\"},{\"internalType\":\"uint16[]\",\"name\":\"_tokenTiers\",\"type\":\"uint16[]\"},{\"internalType\":\"string[]\",\"name\":\"_tokenURIs\",\"type\":\"string[]\"},{\"internalType\":\"address\",\"name\":\"_owner\",\"type\":\"address\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"approved\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"}],\"name\":\"Approval\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"bool\",\"name\":\"approved\",\"type\":\"bool\"}],\"name\":\"ApprovalForAll\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"puzzleId\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"season\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"maxCaptures\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256[]\",\"name\":\"maxTierCaptures\",\"type\":\"uint256[]\"}],\"name\":\"CaptureLimitsSet\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"player\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"puzzleId\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"n\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint8\",\"name\":\"tier\",\"type\":\"uint8\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"oldRoot\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"newRoot\",\"type\":\"uint256\"}],\"name\":\"FlagCaptured\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"previousOwner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"OwnershipTransferred\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"sponsor\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"PrizeDeposited\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"player\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"puzzleId\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"RewardCredited\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"player\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"RewardWithdrawn\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"puzzleId\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"season\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"genesisRoot\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"verifier\",\"type\":\"address\"}],\"name\":\"SeasonStarted\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"puzzleId\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"season\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256[]\",\"name\":\"tierRewards\",\"type\":\"uint256[]\"}],\"name\":\"TierRewardsSet\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"}],\"name\":\"Transfer\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"PUZZLE_SHIFT\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"SEASON_SHIFT\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"ZKONACCI_ORDER\",\"outputs\":[{\"internalType\":\"uint8\",\"name\":\"\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"verifierAddr\",\"type\":\"address\"},{\"internalType\":\"uint8\",\"name\":\"_order\",\"type\":\"uint8\"},{\"internalType\":\"uint256\",\"name\":\"genesisRoot\",\"type\":\"uint256\"},{\"internalType\":\"string\",\"name\":\"_baseURI\",\"type\":\"string\"},{\"internalType\":\"uint16[]\",\"name\":\"_tokenTiers\",\"type\":\"uint16[]\"},{\"internalType\":\"string[]\",\"name\":\"_tokenURIs\",\"type\":\"string[]\"}],\"name\":\"addPuzzle\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"}],\"name\":\"approve\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"}],\"name\":\"balanceOf\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"puzzleId\",\"type\":\"uint256\"}],\"name\":\"baseURI\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"puzzleId\",\"type\":\"uint256\"}],\"name\":\"captureLimits\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"maxCaptures\",\"type\":\"uint256\"},{\"internalType\":\"uint256[]\",\"name\":\"maxTierCaptures\",\"type\":\"uint256[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"puzzleId\",\"type\":\"uint256\"},{\"internalType\":\"uint256[2]\",\"name\":\"proofA\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256[2][2]\",\"name\":\"proofB\",\"type\":\"uint256[2][2]\"},{\"internalType\":\"uint256[2]\",\"name\":\"proofC\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256\",\"name\":\"nextRoot\",\"type\":\"uint256\"}],\"name\":\"captureTheFlag\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"recipient\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"puzzleId\",\"type\":\"uint256\"},{\"internalType\":\"uint256[2]\",\"name\":\"proofA\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256[2][2]\",\"name\":\"proofB\",\"type\":\"uint256[2][2]\"},{\"internalType\":\"uint256[2]\",\"name\":\"proofC\",\"type\":\"uint256[2]\"},{\"internalType\":\"uint256\",\"name\":\"nextRoot\",\"type\":\"uint256\"}],\"name\":\"captureTheFlagFor\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"puzzleId\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"player\",\"type\":\"address\"}],\"name\":\"captures\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"puzzleId\",\"type\":\"uint256\"}],\"name\":\"currentSeason\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"deposit\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"}],\"name\":\"getApproved\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"}],\"name\":\"isApprovedForAll\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"nPuzzles\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"puzzleId\",\"type\":\"uint256\"}],\"name\":\"nTiers\",\"outputs\":[{\"internalType\":\"uint8\",\"name\":\"\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"name\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"puzzleId\",\"type\":\"uint256\"}],\"name\":\"order\",\"outputs\":[{\"internalType\":\"uint8\",\"name\":\"\",\"type\":\"uint8\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"owner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"}],\"name\":\"ownerOf\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"prizePool\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"}],\"name\":\"puzzleOf\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"pure\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"rewards\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"puzzleId\",\"type\":\"uint256\"}],\"name\":\"root\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"}],\"name\":\"safeTransferFrom\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"},{\"internalType\":\"bytes\",\"name\":\"_data\",\"type\":\"bytes\"}],\"name\":\"safeTransferFrom\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"puzzleId\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"seasonId\",\"type\":\"uint256\"}],\"name\":\"season\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"genesisRoot\",\"type\":\"uint256\"},{\"internalType\":\"string\",\"name\":\"seasonBaseURI\",\"type\":\"string\"},{\"internalType\":\"uint16[]\",\"name\":\"seasonTokenTiers\",\"type\":\"uint16[]\"},{\"internalType\":\"string[]\",\"name\":\"seasonTokenURIs\",\"type\":\"string[]\"},{\"internalType\":\"address\",\"name\":\"verifierAddr\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"}],\"name\":\"seasonOf\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"pure\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"operator\",\"type\":\"address\"},{\"internalType\":\"bool\",\"name\":\"approved\",\"type\":\"bool\"}],\"name\":\"setApprovalForAll\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"puzzleId\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"_maxCaptures\",\"type\":\"uint256\"},{\"internalType\":\"uint256[]\",\"name\":\"_maxTierCaptures\",\"type\":\"uint256[]\"}],\"name\":\"setCaptureLimits\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"puzzleId\",\"type\":\"uint256\"},{\"internalType\":\"uint256[]\",\"name\":\"_tierRewards\",\"type\":\"uint256[]\"}],\"name\":\"setTierRewards\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"puzzleId\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"verifierAddr\",\"type\":\"address\"},{\"internalType\":\"uint8\",\"name\":\"_order\",\"type\":\"uint8\"},{\"internalType\":\"uint256\",\"name\":\"genesisRoot\",\"type\":\"uint256\"},{\"internalType\":\"string\",\"name\":\"_baseURI\",\"type\":\"string\"},{\"internalType\":\"uint16[]\",\"name\":\"_tokenTiers\",\"type\":\"uint16[]\"},{\"internalType\":\"string[]\",\"name\":\"_tokenURIs\",\"type\":\"string[]\"}],\"name\":\"startSeason\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes4\",\"name\":\"interfaceId\",\"type\":\"bytes4\"}],\"name\":\"supportsInterface\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"symbol\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"puzzleId\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"player\",\"type\":\"address\"},{\"internalType\":\"uint8\",\"name\":\"tier\",\"type\":\"uint8\"}],\"name\":\"tierCaptures\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"puzzleId\",\"type\":\"uint256\"}],\"name\":\"tierRewards\",\"outputs\":[{\"internalType\":\"uint256[]\",\"name\":\"\",\"type\":\"uint256[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"puzzleId\",\"type\":\"uint256\"}],\"name\":\"tokenCounter\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"puzzleId\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"tier\",\"type\":\"uint256\"}],\"name\":\"tokenTiers\",\"outputs\":[{\"internalType\":\"uint16\",\"name\":\"\",\"type\":\"uint16\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"}],\"name\":\"tokenURI\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"puzzleId\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"tier\",\"type\":\"uint256\"}],\"name\":\"tokenURIs\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"from\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"to\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"tokenId\",\"type\":\"uint256\"}],\"name\":\"transferFrom\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"transferOwnership\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"puzzleId\",\"type\":\"uint256\"}],\"name\":\"verifier\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"withdraw\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"stateMutability\":\"payable\",\"type\":\"receive\"}]"
 
 // ZKOnacciFuncSigs maps the 4-byte function signature to its string representation.
 var ZKOnacciFuncSigs = map[string]string{
+	"7e0e8dfe": "PUZZLE_SHIFT()",
+	"7114d6cd": "SEASON_SHIFT()",
+	"0a6248c7": "ZKONACCI_ORDER()",
+	"03b0cf13": "addPuzzle(address,uint8,uint256,string,uint16[],string[])",
 	"095ea7b3": "approve(address,uint256)",
 	"70a08231": "balanceOf(address)",
-	"6c0360eb": "baseURI()",
-	"ed4d76e4": "captureTheFlag(uint256[2],uint256[2][2],uint256[2],uint256)",
+	"2ccde4f6": "baseURI(uint256)",
+	"94ed9dbc": "captureLimits(uint256)",
+	"456f2928": "captureTheFlag(uint256,uint256[2],uint256[2][2],uint256[2],uint256)",
+	"f33c7969": "captureTheFlagFor(address,uint256,uint256[2],uint256[2][2],uint256[2],uint256)",
+	"70340a47": "captures(uint256,address)",
+	"a54d0809": "currentSeason(uint256)",
+	"d0e30db0": "deposit()",
 	"081812fc": "getApproved(uint256)",
 	"e985e9c5": "isApprovedForAll(address,address)",
-	"50d5033d": "nTiers()",
+	"73531760": "nPuzzles()",
+	"2dec4cc1": "nTiers(uint256)",
 	"06fdde03": "name()",
+	"21603f43": "order(uint256)",
+	"8da5cb5b": "owner()",
 	"6352211e": "ownerOf(uint256)",
-	"ebf0c717": "root()",
+	"719ce73e": "prizePool()",
+	"7ab40036": "puzzleOf(uint256)",
+	"0700037d": "rewards(address)",
+	"e56bf027": "root(uint256)",
 	"42842e0e": "safeTransferFrom(address,address,uint256)",
 	"b88d4fde": "safeTransferFrom(address,address,uint256,bytes)",
+	"faea2091": "season(uint256,uint256)",
+	"a6084f47": "seasonOf(uint256)",
 	"a22cb465": "setApprovalForAll(address,bool)",
+	"bdca7c1b": "setCaptureLimits(uint256,uint256,uint256[])",
+	"7ac46b67": "setTierRewards(uint256,uint256[])",
+	"70a73a45": "startSeason(uint256,address,uint8,uint256,string,uint16[],string[])",
 	"01ffc9a7": "supportsInterface(bytes4)",
 	"95d89b41": "symbol()",
-	"d082e381": "tokenCounter()",
-	"818f2e42": "tokenTiers(uint256)",
+	"34e7a671": "tierCaptures(uint256,address,uint8)",
+	"556c448d": "tierRewards(uint256)",
+	"21bdb140": "tokenCounter(uint256)",
+	"ecec6211": "tokenTiers(uint256,uint256)",
 	"c87b56dd": "tokenURI(uint256)",
-	"6c8b703f": "tokenURIs(uint256)",
+	"c96afe89": "tokenURIs(uint256,uint256)",
 	"23b872dd": "transferFrom(address,address,uint256)",
+	"f2fde38b": "transferOwnership(address)",
+	"8d129178": "verifier(uint256)",
+	"3ccfd60b": "withdraw()",
 }
 
 // ZKOnacciBin is the compiled bytecode used for deploying new contracts.
-var ZKOnacciBin = "0x61010060405260026080908152600460a0819052600860c0819052601060e0526200002c9290916200019f565b506040805160e08101909152603b60808201818152829162001d9d60a084013981526020016040518060600160405280603b815260200162001e4e603b913981526020016040518060600160405280603b815260200162001e13603b913981526020016040518060600160405280603b815260200162001dd8603b91399052620000bb9060099060046200023b565b50348015620000c957600080fd5b5060405162001e8938038062001e89833981016040819052620000ec9162000385565b60408051808201825260088152677a6b4f6e6163636960c01b6020808301918252835180850190945260038452625a4b4f60e81b90840152815191929162000137916000916200028e565b5080516200014d9060019060208401906200028e565b50506000600755507f2ba10c11cc9b7533aabf84e38463d30f526dd69eb0d21783f469170ff53a9288600655600d80546001600160a01b0319166001600160a01b0392909216919091179055620003f4565b600183019183908215620002295791602002820160005b83821115620001f757835183826101000a81548161ffff021916908360ff1602179055509260200192600201602081600101049283019260010302620001b6565b8015620002275782816101000a81549061ffff0219169055600201602081600101049283019260010302620001f7565b505b50620002379291506200030b565b5090565b826004810192821562000280579160200282015b828111156200028057825180516200026f9184916020909101906200028e565b50916020019190600101906200024f565b506200023792915062000322565b8280546200029c90620003b7565b90600052602060002090601f016020900481019282620002c0576000855562000229565b82601f10620002db57805160ff191683800117855562000229565b8280016001018555821562000229579182015b8281111562000229578251825591602001919060010190620002ee565b5b808211156200023757600081556001016200030c565b808211156200023757600062000339828262000343565b5060010162000322565b5080546200035190620003b7565b6000825580601f1062000362575050565b601f0160209004906000526020600020908101906200038291906200030b565b50565b6000602082840312156200039857600080fd5b81516001600160a01b0381168114620003b057600080fd5b9392505050565b600181811c90821680620003cc57607f821691505b60208210811415620003ee57634e487b7160e01b600052602260045260246000fd5b50919050565b61199980620004046000396000f3fe608060405234801561001057600080fd5b506004361061012c5760003560e01c806370a08231116100ad578063c87b56dd11610071578063c87b56dd146102bd578063d082e381146102d0578063e985e9c5146102d9578063ebf0c71714610315578063ed4d76e41461031e57600080fd5b806370a0823114610248578063818f2e421461026957806395d89b411461028f578063a22cb46514610297578063b88d4fde146102aa57600080fd5b806342842e0e116100f457806342842e0e146101c157806350d5033d146101d45780636352211e146101ee5780636c0360eb146102015780636c8b703f1461023557600080fd5b806301ffc9a71461013157806306fdde0314610159578063081812fc1461016e578063095ea7b31461019957806323b872dd146101ae575b600080fd5b61014461013f3660046114e2565b610331565b60405190151581526020015b60405180910390f35b610161610383565b60405161015091906116f6565b61018161017c36600461151c565b610415565b6040516001600160a01b039091168152602001610150565b6101ac6101a73660046113fc565b6104af565b005b6101ac6101bc3660046112c9565b6105c5565b6101ac6101cf3660046112c9565b6105f6565b6101dc600481565b60405160ff9091168152602001610150565b6101816101fc36600461151c565b610611565b6101616040518060400160405280601581526020017468747470733a2f2f697066732e696f2f697066732f60581b81525081565b61016161024336600461151c565b610688565b61025b610256366004611274565b610728565b604051908152602001610150565b61027c61027736600461151c565b6107af565b60405161ffff9091168152602001610150565b6101616107dd565b6101ac6102a53660046113c5565b6107ec565b6101ac6102b8366004611305565b6108b1565b6101616102cb36600461151c565b6108e9565b61025b60075481565b6101446102e7366004611296565b6001600160a01b03918216600090815260056020908152604080832093909416825291909152205460ff1690565b61025b60065481565b61025b61032c366004611426565b610a3e565b60006001600160e01b031982166380ac58cd60e01b148061036257506001600160e01b03198216635b5e139f60e01b145b8061037d57506301ffc9a760e01b6001600160e01b03198316145b92915050565b60606000805461039290611884565b80601f01602080910402602001604051908101604052809291908181526020018280546103be90611884565b801561040b5780601f106103e05761010080835404028352916020019161040b565b820191906000526020600020905b8154815290600101906020018083116103ee57829003601f168201915b5050505050905090565b6000818152600260205260408120546001600160a01b03166104935760405162461bcd60e51b815260206004820152602c60248201527f4552433732313a20617070726f76656420717565727920666f72206e6f6e657860448201526b34b9ba32b73a103a37b5b2b760a11b60648201526084015b60405180910390fd5b506000908152600460205260409020546001600160a01b031690565b60006104ba82610611565b9050806001600160a01b0316836001600160a01b031614156105285760405162461bcd60e51b815260206004820152602160248201527f4552433732313a20617070726f76616c20746f2063757272656e74206f776e656044820152603960f91b606482015260840161048a565b336001600160a01b0382161480610544575061054481336102e7565b6105b65760405162461bcd60e51b815260206004820152603860248201527f4552433732313a20617070726f76652063616c6c6572206973206e6f74206f7760448201527f6e6572206e6f7220617070726f76656420666f7220616c6c0000000000000000606482015260840161048a565b6105c08383610bfd565b505050565b6105cf3382610c6b565b6105eb5760405162461bcd60e51b815260040161048a9061175b565b6105c0838383610d62565b6105c0838383604051806020016040528060008152506108b1565b6000818152600260205260408120546001600160a01b03168061037d5760405162461bcd60e51b815260206004820152602960248201527f4552433732313a206f776e657220717565727920666f72206e6f6e657869737460448201526832b73a103a37b5b2b760b91b606482015260840161048a565b6009816004811061069857600080fd5b0180549091506106a790611884565b80601f01602080910402602001604051908101604052809291908181526020018280546106d390611884565b80156107205780601f106106f557610100808354040283529160200191610720565b820191906000526020600020905b81548152906001019060200180831161070357829003601f168201915b505050505081565b60006001600160a01b0382166107935760405162461bcd60e51b815260206004820152602a60248201527f4552433732313a2062616c616e636520717565727920666f7220746865207a65604482015269726f206164647265737360b01b606482015260840161048a565b506001600160a01b031660009081526003602052604090205490565b600881600481106107bf57600080fd5b60109182820401919006600202915054906101000a900461ffff1681565b60606001805461039290611884565b6001600160a01b0382163314156108455760405162461bcd60e51b815260206004820152601960248201527f4552433732313a20617070726f766520746f2063616c6c657200000000000000604482015260640161048a565b3360008181526005602090815260408083206001600160a01b03871680855290835292819020805460ff191686151590811790915590519081529192917f17307eab39ab6107e8899845ad3d59bd9653f200f220920489ca2b5937696c31910160405180910390a35050565b6108bb3383610c6b565b6108d75760405162461bcd60e51b815260040161048a9061175b565b6108e384848484610f02565b50505050565b6000818152600260205260409020546060906001600160a01b03166109685760405162461bcd60e51b815260206004820152602f60248201527f4552433732314d657461646174613a2055524920717565727920666f72206e6f60448201526e3732bc34b9ba32b73a103a37b5b2b760891b606482015260840161048a565b60005b6109776001600461181e565b8160ff161080156109ba575060088160ff166004811061099957610999611910565b601091828204019190066002029054906101000a900461ffff1661ffff1683115b156109d157806109c9816118da565b91505061096b565b6040518060400160405280601581526020017468747470733a2f2f697066732e696f2f697066732f60581b81525060098260ff1660048110610a1557610a15611910565b01604051602001610a27929190611584565b604051602081830303815290604052915050919050565b60006008610a4e60016004611835565b60ff1660048110610a6157610a61611910565b601091828204019190066002029054906101000a900461ffff1661ffff166007541115610ae45760405162461bcd60e51b815260206004820152602b60248201527f5a4b4f6e616363693a3a63617074757265546865466c61673a20414c4c5f544f60448201526a12d15394d7d3525395115160aa1b606482015260840161048a565b600d5460408051606081018252338152600654602082015280820185905290516308a3cff560e11b81526001600160a01b03909216916311479fea91610b339189918991899190600401611672565b60206040518083038186803b158015610b4b57600080fd5b505afa158015610b5f573d6000803e3d6000fd5b505050506040513d601f19601f82011682018060405250810190610b8391906114c5565b1515600114610be75760405162461bcd60e51b815260206004820152602a60248201527f5a4b4f6e616363693a3a63617074757265546865466c61673a20494e56414c49604482015269222fad25afa82927a7a360b11b606482015260840161048a565b610bef610f35565b506006919091559392505050565b600081815260046020526040902080546001600160a01b0319166001600160a01b0384169081179091558190610c3282610611565b6001600160a01b03167f8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b92560405160405180910390a45050565b6000818152600260205260408120546001600160a01b0316610ce45760405162461bcd60e51b815260206004820152602c60248201527f4552433732313a206f70657261746f7220717565727920666f72206e6f6e657860448201526b34b9ba32b73a103a37b5b2b760a11b606482015260840161048a565b6000610cef83610611565b9050806001600160a01b0316846001600160a01b03161480610d2a5750836001600160a01b0316610d1f84610415565b6001600160a01b0316145b80610d5a57506001600160a01b0380821660009081526005602090815260408083209388168352929052205460ff165b949350505050565b826001600160a01b0316610d7582610611565b6001600160a01b031614610ddd5760405162461bcd60e51b815260206004820152602960248201527f4552433732313a207472616e73666572206f6620746f6b656e2074686174206960448201526839903737ba1037bbb760b91b606482015260840161048a565b6001600160a01b038216610e3f5760405162461bcd60e51b8152602060048201526024808201527f4552433732313a207472616e7366657220746f20746865207a65726f206164646044820152637265737360e01b606482015260840161048a565b610e4a600082610bfd565b6001600160a01b0383166000908152600360205260408120805460019290610e7390849061181e565b90915550506001600160a01b0382166000908152600360205260408120805460019290610ea1908490611806565b909155505060008181526002602052604080822080546001600160a01b0319166001600160a01b0386811691821790925591518493918716917fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef91a4505050565b610f0d848484610d62565b610f1984848484610f60565b6108e35760405162461bcd60e51b815260040161048a90611709565b600754600090610f45338261106d565b60078054906000610f55836118bf565b909155509092915050565b60006001600160a01b0384163b1561106257604051630a85bd0160e11b81526001600160a01b0385169063150b7a0290610fa4903390899088908890600401611635565b602060405180830381600087803b158015610fbe57600080fd5b505af1925050508015610fee575060408051601f3d908101601f19168201909252610feb918101906114ff565b60015b611048573d80801561101c576040519150601f19603f3d011682016040523d82523d6000602084013e611021565b606091505b5080516110405760405162461bcd60e51b815260040161048a90611709565b805181602001fd5b6001600160e01b031916630a85bd0160e11b149050610d5a565b506001949350505050565b61108782826040518060200160405280600081525061108b565b5050565b61109583836110be565b6110a26000848484610f60565b6105c05760405162461bcd60e51b815260040161048a90611709565b6001600160a01b0382166111145760405162461bcd60e51b815260206004820181905260248201527f4552433732313a206d696e7420746f20746865207a65726f2061646472657373604482015260640161048a565b6000818152600260205260409020546001600160a01b0316156111795760405162461bcd60e51b815260206004820152601c60248201527f4552433732313a20746f6b656e20616c7265616479206d696e74656400000000604482015260640161048a565b6001600160a01b03821660009081526003602052604081208054600192906111a2908490611806565b909155505060008181526002602052604080822080546001600160a01b0319166001600160a01b03861690811790915590518392907fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef908290a45050565b80356001600160a01b038116811461121757600080fd5b919050565b600082601f83011261122d57600080fd5b6112356117ac565b80838560408601111561124757600080fd5b60005b600281101561126957813584526020938401939091019060010161124a565b509095945050505050565b60006020828403121561128657600080fd5b61128f82611200565b9392505050565b600080604083850312156112a957600080fd5b6112b283611200565b91506112c060208401611200565b90509250929050565b6000806000606084860312156112de57600080fd5b6112e784611200565b92506112f560208501611200565b9150604084013590509250925092565b6000806000806080858703121561131b57600080fd5b61132485611200565b93506020611333818701611200565b935060408601359250606086013567ffffffffffffffff8082111561135757600080fd5b818801915088601f83011261136b57600080fd5b81358181111561137d5761137d611926565b61138f601f8201601f191685016117d5565b915080825289848285010111156113a557600080fd5b808484018584013760008482840101525080935050505092959194509250565b600080604083850312156113d857600080fd5b6113e183611200565b915060208301356113f18161193c565b809150509250929050565b6000806040838503121561140f57600080fd5b61141883611200565b946020939093013593505050565b600080600080610120858703121561143d57600080fd5b611447868661121c565b9350604086605f87011261145a57600080fd5b6114626117ac565b8082880160c089018a81111561147757600080fd5b60005b60028110156114a15761148d8c8461121c565b85526020909401939185019160010161147a565b508297506114af8b8261121c565b989b979a50979861010001359750505050505050565b6000602082840312156114d757600080fd5b815161128f8161193c565b6000602082840312156114f457600080fd5b813561128f8161194d565b60006020828403121561151157600080fd5b815161128f8161194d565b60006020828403121561152e57600080fd5b5035919050565b8060005b60028110156108e3578151845260209384019390910190600101611539565b60008151808452611570816020860160208601611858565b601f01601f19169290920160200192915050565b6000835160206115978285838901611858565b845491840191600090600181811c90808316806115b557607f831692505b8583108114156115d357634e487b7160e01b85526022600452602485fd5b8080156115e757600181146115f857611625565b60ff19851688528388019550611625565b60008b81526020902060005b8581101561161d5781548a820152908401908801611604565b505083880195505b50939a9950505050505050505050565b6001600160a01b038581168252841660208201526040810183905260806060820181905260009061166890830184611558565b9695505050505050565b61016081016116818287611535565b60408083018660005b60028110156116b15761169e838351611535565b918301916020919091019060010161168a565b505050506116c260c0830185611535565b61010082018360005b60038110156116ea5781518352602092830192909101906001016116cb565b50505095945050505050565b60208152600061128f6020830184611558565b60208082526032908201527f4552433732313a207472616e7366657220746f206e6f6e20455243373231526560408201527131b2b4bb32b91034b6b83632b6b2b73a32b960711b606082015260800190565b60208082526031908201527f4552433732313a207472616e736665722063616c6c6572206973206e6f74206f6040820152701ddb995c881b9bdc88185c1c1c9bdd9959607a1b606082015260800190565b6040805190810167ffffffffffffffff811182821017156117cf576117cf611926565b60405290565b604051601f8201601f1916810167ffffffffffffffff811182821017156117fe576117fe611926565b604052919050565b60008219821115611819576118196118fa565b500190565b600082821015611830576118306118fa565b500390565b600060ff821660ff84168082101561184f5761184f6118fa565b90039392505050565b60005b8381101561187357818101518382015260200161185b565b838111156108e35750506000910152565b600181811c9082168061189857607f821691505b602082108114156118b957634e487b7160e01b600052602260045260246000fd5b50919050565b60006000198214156118d3576118d36118fa565b5060010190565b600060ff821660ff8114156118f1576118f16118fa565b60010192915050565b634e487b7160e01b600052601160045260246000fd5b634e487b7160e01b600052603260045260246000fd5b634e487b7160e01b600052604160045260246000fd5b801515811461194a57600080fd5b50565b6001600160e01b03198116811461194a57600080fdfea264697066735822122056ba12a5a3ee36d8281a8f4db1df87e2529d73fd4e751358b17eb22cf151848b64736f6c634300080600336261666b726569676e776e6778337477656a3663646e323668796165743367673773637274706761666b716a6e6b717476333761327236716634756261666b72656966356b676f356332706f6f6c33733562766e6a6f37677378617374343579696f616768367466687866703561616536377574366d6261666b72656966336f75623735746d6732717968326d7a7376797165797861633778666a79356974726236726c653779786236776f35366a6a696261666b726569656c74726e743632636434707863766279676a6f36776864746e7a6c717a6b6d7032736e76686e3334646d793633616a35756e71"
+var ZKOnacciBin = "0x60806040523480156200001157600080fd5b5060405162004693380380620046938339810160408190526200003491620007ba565b604051806040016040528060088152602001677a6b4f6e6163636960c01b815250604051806040016040528060038152602001625a4b4f60e81b815250816000908162000082919062000979565b50600162000091828262000979565b5050506001600160a01b038116620000fc5760405162461bcd60e51b8152602060048201526024808201527f5a4b4f6e616363693a3a636f6e7374727563746f723a20494e56414c49445f4f6044820152632ba722a960e11b60648201526084015b60405180910390fd5b600680546001600160a01b0319166001600160a01b0383169081179091556040516000907f8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0908290a3620001568660028787878762000163565b5050505050505062000aa9565b60078054600190810180835560009283528291620001819162000a5b565b905062000194818989898989896200019f565b979650505050505050565b60008560ff1611620001f45760405162461bcd60e51b815260206004820152601760248201527f5a4b4f6e616363693a20494e56414c49445f4f524445520000000000000000006044820152606401620000f3565b60008251118015620002085750815160ff10155b620002565760405162461bcd60e51b815260206004820152601e60248201527f5a4b4f6e616363693a20494e56414c49445f54494552535f4c454e47544800006044820152606401620000f3565b8151815114620002b55760405162461bcd60e51b8152602060048201526024808201527f5a4b4f6e616363693a2054494552535f555249535f4c454e4754485f4d49534d604482015263082a886960e31b6064820152608401620000f3565b60015b8251811015620003745782620002d060018362000a5b565b81518110620002e357620002e362000a77565b602002602001015161ffff1683828151811062000304576200030462000a77565b602002602001015161ffff16116200035f5760405162461bcd60e51b815260206004820152601e60248201527f5a4b4f6e616363693a2054494552535f4e4f545f494e4352454153494e4700006044820152606401620000f3565b806200036b8162000a8d565b915050620002b8565b506000600788815481106200038d576200038d62000a77565b6000918252602080832060049092029091018781556001808201849055600382018054808301825590855292909320600a909202909101878155909250908101620003d9868262000979565b508351620003f1906002830190602087019062000484565b50825162000409906003830190602086019062000534565b506004810180546001600160a01b038a166001600160a81b03199091168117600160a01b60ff8b160217909155600283015460408051898152602081019390935290918b917f7f4fe728e97c8a56ce861ca6b2c59b85150f43e484b67170219eb9846d9a443f910160405180910390a3505050505050505050565b82805482825590600052602060002090600f01601090048101928215620005225791602002820160005b83821115620004f057835183826101000a81548161ffff021916908361ffff1602179055509260200192600201602081600101049283019260010302620004ae565b8015620005205782816101000a81549061ffff0219169055600201602081600101049283019260010302620004f0565b505b50620005309291506200058d565b5090565b8280548282559060005260206000209081019282156200057f579160200282015b828111156200057f57825182906200056e908262000979565b509160200191906001019062000555565b5062000530929150620005a4565b5b808211156200053057600081556001016200058e565b8082111562000530576000620005bb8282620005c5565b50600101620005a4565b508054620005d390620008ea565b6000825580601f10620005e4575050565b601f0160209004906000526020600020908101906200060491906200058d565b50565b80516001600160a01b03811681146200061f57600080fd5b919050565b634e487b7160e01b600052604160045260246000fd5b604051601f8201601f191681016001600160401b038111828210171562000665576200066562000624565b604052919050565b600082601f8301126200067f57600080fd5b81516001600160401b038111156200069b576200069b62000624565b6020620006b1601f8301601f191682016200063a565b8281528582848701011115620006c657600080fd5b60005b83811015620006e6578581018301518282018401528201620006c9565b506000928101909101919091529392505050565b60006001600160401b0382111562000716576200071662000624565b5060051b60200190565b600082601f8301126200073257600080fd5b815160206200074b6200074583620006fa565b6200063a565b82815260059290921b840181019181810190868411156200076b57600080fd5b8286015b84811015620007af5780516001600160401b03811115620007905760008081fd5b620007a08986838b01016200066d565b8452509183019183016200076f565b509695505050505050565b60008060008060008060c08789031215620007d457600080fd5b620007df8762000607565b60208881015160408a01519298509650906001600160401b03808211156200080657600080fd5b620008148b838c016200066d565b965060608a01519150808211156200082b57600080fd5b818a0191508a601f8301126200084057600080fd5b8151620008516200074582620006fa565b81815260059190911b8301840190848101908d8311156200087157600080fd5b938501935b82851015620008a357845161ffff81168114620008935760008081fd5b8252938501939085019062000876565b60808d01519098509450505080831115620008bd57600080fd5b5050620008cd89828a0162000720565b925050620008de60a0880162000607565b90509295509295509295565b600181811c90821680620008ff57607f821691505b6020821081036200092057634e487b7160e01b600052602260045260246000fd5b50919050565b601f8211156200097457600081815260208120601f850160051c810160208610156200094f5750805b601f850160051c820191505b8181101562000970578281556001016200095b565b5050505b505050565b81516001600160401b0381111562000995576200099562000624565b620009ad81620009a68454620008ea565b8462000926565b602080601f831160018114620009e55760008415620009cc5750858301515b600019600386901b1c1916600185901b17855562000970565b600085815260208120601f198616915b8281101562000a1657888601518255948401946001909101908401620009f5565b508582101562000a355787850151600019600388901b60f8161c191681555b5050505050600190811b01905550565b634e487b7160e01b600052601160045260246000fd5b8181038181111562000a715762000a7162000a45565b92915050565b634e487b7160e01b600052603260045260246000fd5b60006001820162000aa25762000aa262000a45565b5060010190565b613bda8062000ab96000396000f3fe6080604052600436106102765760003560e01c8063719ce73e1161014f578063a6084f47116100c1578063e56bf0271161007a578063e56bf0271461077e578063e985e9c51461079e578063ecec6211146107e7578063f2fde38b1461081a578063f33c79691461083a578063faea20911461085a57600080fd5b8063a6084f47146106d6578063b88d4fde146106f6578063bdca7c1b14610716578063c87b56dd14610736578063c96afe8914610756578063d0e30db01461077657600080fd5b80638d129178116101135780638d129178146106135780638da5cb5b1461063357806394ed9dbc1461065357806395d89b4114610681578063a22cb46514610696578063a54d0809146106b657600080fd5b8063719ce73e1461059257806373531760146105a85780637ab40036146105bd5780637ac46b67146105de5780637e0e8dfe146105fe57600080fd5b80632dec4cc1116101e8578063556c448d116101ac578063556c448d146104d05780636352211e146104fd57806370340a471461051d57806370a082311461053d57806370a73a451461055d5780637114d6cd1461057d57600080fd5b80632dec4cc11461043b57806334e7a6711461045b5780633ccfd60b1461047b57806342842e0e14610490578063456f2928146104b057600080fd5b8063095ea7b31161023a578063095ea7b3146103745780630a6248c71461039457806321603f43146103bb57806321bdb140146103db57806323b872dd146103fb5780632ccde4f61461041b57600080fd5b806301ffc9a71461028a57806303b0cf13146102bf57806306fdde03146102ed5780630700037d1461030f578063081812fc1461033c57600080fd5b366102855761028361088b565b005b600080fd5b34801561029657600080fd5b506102aa6102a5366004612dc0565b6108d9565b60405190151581526020015b60405180910390f35b3480156102cb57600080fd5b506102df6102da366004613012565b61092b565b6040519081526020016102b6565b3480156102f957600080fd5b506103026109e0565b6040516102b6919061310a565b34801561031b57600080fd5b506102df61032a36600461311d565b60096020526000908152604090205481565b34801561034857600080fd5b5061035c610357366004613138565b610a72565b6040516001600160a01b0390911681526020016102b6565b34801561038057600080fd5b5061028361038f366004613151565b610b07565b3480156103a057600080fd5b506103a9600281565b60405160ff90911681526020016102b6565b3480156103c757600080fd5b506103a96103d6366004613138565b610c1c565b3480156103e757600080fd5b506102df6103f6366004613138565b610c3b565b34801561040757600080fd5b5061028361041636600461317b565b610c50565b34801561042757600080fd5b50610302610436366004613138565b610c81565b34801561044757600080fd5b506103a9610456366004613138565b610d1f565b34801561046757600080fd5b506102df6104763660046131b7565b610d34565b34801561048757600080fd5b50610283610d73565b34801561049c57600080fd5b506102836104ab36600461317b565b610ebc565b3480156104bc57600080fd5b506102df6104cb366004613292565b610ed7565b3480156104dc57600080fd5b506104f06104eb366004613138565b610ee7565b6040516102b69190613327565b34801561050957600080fd5b5061035c610518366004613138565b610f4a565b34801561052957600080fd5b506102df61053836600461333a565b610fc1565b34801561054957600080fd5b506102df61055836600461311d565b610ff1565b34801561056957600080fd5b50610283610578366004613366565b611078565b34801561058957600080fd5b506102df601081565b34801561059e57600080fd5b506102df60085481565b3480156105b457600080fd5b506007546102df565b3480156105c957600080fd5b506102df6105d8366004613138565b60201c90565b3480156105ea57600080fd5b506102836105f936600461347e565b61126a565b34801561060a57600080fd5b506102df602081565b34801561061f57600080fd5b5061035c61062e366004613138565b61139d565b34801561063f57600080fd5b5060065461035c906001600160a01b031681565b34801561065f57600080fd5b5061067361066e366004613138565b6113bb565b6040516102b69291906134c5565b34801561068d57600080fd5b50610302611431565b3480156106a257600080fd5b506102836106b13660046134ec565b611440565b3480156106c257600080fd5b506102df6106d1366004613138565b611504565b3480156106e257600080fd5b506102df6106f1366004613138565b61150f565b34801561070257600080fd5b50610283610711366004613523565b61153a565b34801561072257600080fd5b5061028361073136600461359f565b611572565b34801561074257600080fd5b50610302610751366004613138565b6116b1565b34801561076257600080fd5b506103026107713660046135ef565b6117f6565b61028361088b565b34801561078a57600080fd5b506102df610799366004613138565b6118b0565b3480156107aa57600080fd5b506102aa6107b9366004613611565b6001600160a01b03918216600090815260056020908152604080832093909416825291909152205460ff1690565b3480156107f357600080fd5b506108076108023660046135ef565b6118c2565b60405161ffff90911681526020016102b6565b34801561082657600080fd5b5061028361083536600461311d565b61190d565b34801561084657600080fd5b506102df61085536600461363b565b6119fc565b34801561086657600080fd5b5061087a6108753660046135ef565b611a0c565b6040516102b69594939291906136a7565b346008600082825461089d919061377c565b909155505060405134815233907ff5ca7f2a0fd75407bcd086228127e5b2263fc3d7d75d505a1abaefbdc6fe98519060200160405180910390a2565b60006001600160e01b031982166380ac58cd60e01b148061090a57506001600160e01b03198216635b5e139f60e01b145b8061092557506301ffc9a760e01b6001600160e01b03198316145b92915050565b6006546000906001600160a01b031633146109615760405162461bcd60e51b81526004016109589061378f565b60405180910390fd5b6001600160a01b0387166109c55760405162461bcd60e51b815260206004820152602560248201527f5a4b4f6e616363693a3a61646450757a7a6c653a20494e56414c49445f56455260448201526424a324a2a960d91b6064820152608401610958565b6109d3878787878787611c5a565b90505b9695505050505050565b6060600080546109ef906137bc565b80601f0160208091040260200160405190810160405280929190818152602001828054610a1b906137bc565b8015610a685780601f10610a3d57610100808354040283529160200191610a68565b820191906000526020600020905b815481529060010190602001808311610a4b57829003601f168201915b5050505050905090565b6000818152600260205260408120546001600160a01b0316610aeb5760405162461bcd60e51b815260206004820152602c60248201527f4552433732313a20617070726f76656420717565727920666f72206e6f6e657860448201526b34b9ba32b73a103a37b5b2b760a11b6064820152608401610958565b506000908152600460205260409020546001600160a01b031690565b6000610b1282610f4a565b9050806001600160a01b0316836001600160a01b031603610b7f5760405162461bcd60e51b815260206004820152602160248201527f4552433732313a20617070726f76616c20746f2063757272656e74206f776e656044820152603960f91b6064820152608401610958565b336001600160a01b0382161480610b9b5750610b9b81336107b9565b610c0d5760405162461bcd60e51b815260206004820152603860248201527f4552433732313a20617070726f76652063616c6c6572206973206e6f74206f7760448201527f6e6572206e6f7220617070726f76656420666f7220616c6c00000000000000006064820152608401610958565b610c178383611c87565b505050565b6000610c2782611cf5565b60040154600160a01b900460ff1692915050565b6000610c4682611d32565b6001015492915050565b610c5a3382611dae565b610c765760405162461bcd60e51b8152600401610958906137f6565b610c17838383611ea5565b6060610c8c82611cf5565b6001018054610c9a906137bc565b80601f0160208091040260200160405190810160405280929190818152602001828054610cc6906137bc565b8015610d135780601f10610ce857610100808354040283529160200191610d13565b820191906000526020600020905b815481529060010190602001808311610cf657829003601f168201915b50505050509050919050565b6000610d2a82611cf5565b6002015492915050565b6000610d3f84611cf5565b6001600160a01b03841660009081526008919091016020908152604080832060ff8616845290915290205490509392505050565b3360009081526009602052604090205480610dd05760405162461bcd60e51b815260206004820152601e60248201527f5a4b4f6e616363693a3a77697468647261773a204e4f5f5245574152445300006044820152606401610958565b336000818152600960205260408082208290555190919083908381818185875af1925050503d8060008114610e21576040519150601f19603f3d011682016040523d82523d6000602084013e610e26565b606091505b5050905080610e835760405162461bcd60e51b815260206004820152602360248201527f5a4b4f6e616363693a3a77697468647261773a205452414e534645525f46414960448201526213115160ea1b6064820152608401610958565b60405182815233907f1d3eee4ca001cff39eec6ec7615aacf2f2bd61791273830728ba00ccbd6e13379060200160405180910390a25050565b610c178383836040518060200160405280600081525061153a565b60006109d6338787878787612045565b6060610ef282611cf5565b600901805480602002602001604051908101604052809291908181526020018280548015610d1357602002820191906000526020600020905b815481526020019060010190808311610f2b5750505050509050919050565b6000818152600260205260408120546001600160a01b0316806109255760405162461bcd60e51b815260206004820152602960248201527f4552433732313a206f776e657220717565727920666f72206e6f6e657869737460448201526832b73a103a37b5b2b760b91b6064820152608401610958565b6000610fcc83611cf5565b6001600160a01b03831660009081526007919091016020526040902054905092915050565b60006001600160a01b03821661105c5760405162461bcd60e51b815260206004820152602a60248201527f4552433732313a2062616c616e636520717565727920666f7220746865207a65604482015269726f206164647265737360b01b6064820152608401610958565b506001600160a01b031660009081526003602052604090205490565b6006546001600160a01b031633146110a25760405162461bcd60e51b81526004016109589061378f565b60006110ad88611d32565b90506000816003018260020154815481106110ca576110ca613847565b90600052602060002090600a0201905080600201600182600201805490506110f2919061385d565b8154811061110257611102613847565b90600052602060002090601091828204019190066002029054906101000a900461ffff1661ffff1682600101541161118f5760405162461bcd60e51b815260206004820152602a60248201527f5a4b4f6e616363693a3a7374617274536561736f6e3a20534541534f4e5f4e4f6044820152691517d192539254d2115160b21b6064820152608401610958565b61119b6010602061385d565b6001901b826002015460016111b0919061377c565b1061120d5760405162461bcd60e51b815260206004820152602760248201527f5a4b4f6e616363693a3a7374617274536561736f6e3a20544f4f5f4d414e595f604482015266534541534f4e5360c81b6064820152608401610958565b6001600160a01b0388166112395760048101546001600160a01b0381169850600160a01b900460ff1696505b60028201805490600061124b83613870565b919050555061125f89898989898989612262565b505050505050505050565b6006546001600160a01b031633146112945760405162461bcd60e51b81526004016109589061378f565b600061129f83611d32565b90506000816003018260020154815481106112bc576112bc613847565b90600052602060002090600a020190508251600014806112e0575060028101548351145b6113445760405162461bcd60e51b815260206004820152602f60248201527f5a4b4f6e616363693a3a73657454696572526577617264733a2054494552535f60448201526e0988a9c8ea890be9a92a69a82a8869608b1b6064820152608401610958565b82516113599060098301906020860190612bfa565b508160020154847f3b6e0fb4c24ebe672f590fbfc06bcf75cd911e6d0f1dc7705d45a742532a82e48560405161138f9190613327565b60405180910390a350505050565b60006113a882611cf5565b600401546001600160a01b031692915050565b6000606060006113ca84611cf5565b90508060050154816006018080548060200260200160405190810160405280929190818152602001828054801561142057602002820191906000526020600020905b81548152602001906001019080831161140c575b505050505090509250925050915091565b6060600180546109ef906137bc565b336001600160a01b038316036114985760405162461bcd60e51b815260206004820152601960248201527f4552433732313a20617070726f766520746f2063616c6c6572000000000000006044820152606401610958565b3360008181526005602090815260408083206001600160a01b03871680855290835292819020805460ff191686151590811790915590519081529192917f17307eab39ab6107e8899845ad3d59bd9653f200f220920489ca2b5937696c31910160405180910390a35050565b6000610d2a82611d32565b6000600161151f6010602061385d565b6001901b61152d919061385d565b601083901c169050919050565b6115443383611dae565b6115605760405162461bcd60e51b8152600401610958906137f6565b61156c84848484612529565b50505050565b6006546001600160a01b0316331461159c5760405162461bcd60e51b81526004016109589061378f565b60006115a784611d32565b90506000816003018260020154815481106115c4576115c4613847565b90600052602060002090600a020190508251600014806115e8575060028101548351145b61164e5760405162461bcd60e51b815260206004820152603160248201527f5a4b4f6e616363693a3a736574436170747572654c696d6974733a20544945526044820152700a6be988a9c8ea890be9a92a69a82a8869607b1b6064820152608401610958565b60058101849055825161166a9060068301906020860190612bfa565b508160020154857f26466e2446780ea6dab2cc99c53103d90d168c5d69bab00e5c82970c10cdd1f686866040516116a29291906134c5565b60405180910390a35050505050565b6000818152600260205260409020546060906001600160a01b03166117305760405162461bcd60e51b815260206004820152602f60248201527f4552433732314d657461646174613a2055524920717565727920666f72206e6f60448201526e3732bc34b9ba32b73a103a37b5b2b760891b6064820152608401610958565b6000600761173e8460201c90565b8154811061174e5761174e613847565b90600052602060002090600402016003016117688461150f565b8154811061177857611778613847565b600091825260208220600a90910201915061179760016201000061385d565b8416905081600101826003016117b0846002018461255c565b60ff16815481106117c3576117c3613847565b906000526020600020016040516020016117de9291906138fc565b60405160208183030381529060405292505050919050565b606061180183611cf5565b600301828154811061181557611815613847565b90600052602060002001805461182a906137bc565b80601f0160208091040260200160405190810160405280929190818152602001828054611856906137bc565b80156118a35780601f10611878576101008083540402835291602001916118a3565b820191906000526020600020905b81548152906001019060200180831161188657829003601f168201915b5050505050905092915050565b60006118bb82611d32565b5492915050565b60006118cd83611cf5565b60020182815481106118e1576118e1613847565b90600052602060002090601091828204019190066002029054906101000a900461ffff16905092915050565b6006546001600160a01b031633146119375760405162461bcd60e51b81526004016109589061378f565b6001600160a01b0381166119a05760405162461bcd60e51b815260206004820152602a60248201527f5a4b4f6e616363693a3a7472616e736665724f776e6572736869703a20494e5660448201526920a624a22fa7aba722a960b11b6064820152608401610958565b6006546040516001600160a01b038084169216907f8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e090600090a3600680546001600160a01b0319166001600160a01b0392909216919091179055565b60006109d3878787878787612045565b60006060806060600080611a1f88611d32565b6003018781548110611a3357611a33613847565b90600052602060002090600a0201905080600001548160010182600201836003018460040160009054906101000a90046001600160a01b0316838054611a78906137bc565b80601f0160208091040260200160405190810160405280929190818152602001828054611aa4906137bc565b8015611af15780601f10611ac657610100808354040283529160200191611af1565b820191906000526020600020905b815481529060010190602001808311611ad457829003601f168201915b5050505050935082805480602002602001604051908101604052809291908181526020018280548015611b6b57602002820191906000526020600020906000905b82829054906101000a900461ffff1661ffff1681526020019060020190602082600101049283019260010382029150808411611b325790505b5050505050925081805480602002602001604051908101604052809291908181526020016000905b82821015611c3f578382906000526020600020018054611bb2906137bc565b80601f0160208091040260200160405190810160405280929190818152602001828054611bde906137bc565b8015611c2b5780601f10611c0057610100808354040283529160200191611c2b565b820191906000526020600020905b815481529060010190602001808311611c0e57829003601f168201915b505050505081526020019060010190611b93565b50505050915095509550955095509550509295509295909350565b60078054600190810180835560009283528291611c769161385d565b90506109d381898989898989612262565b600081815260046020526040902080546001600160a01b0319166001600160a01b0384169081179091558190611cbc82610f4a565b6001600160a01b03167f8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b92560405160405180910390a45050565b600080611d0183611d32565b905080600301816002015481548110611d1c57611d1c613847565b90600052602060002090600a0201915050919050565b6007546000908210611d865760405162461bcd60e51b815260206004820152601860248201527f5a4b4f6e616363693a20494e56414c49445f50555a5a4c4500000000000000006044820152606401610958565b60078281548110611d9957611d99613847565b90600052602060002090600402019050919050565b6000818152600260205260408120546001600160a01b0316611e275760405162461bcd60e51b815260206004820152602c60248201527f4552433732313a206f70657261746f7220717565727920666f72206e6f6e657860448201526b34b9ba32b73a103a37b5b2b760a11b6064820152608401610958565b6000611e3283610f4a565b9050806001600160a01b0316846001600160a01b03161480611e6d5750836001600160a01b0316611e6284610a72565b6001600160a01b0316145b80611e9d57506001600160a01b0380821660009081526005602090815260408083209388168352929052205460ff165b949350505050565b826001600160a01b0316611eb882610f4a565b6001600160a01b031614611f205760405162461bcd60e51b815260206004820152602960248201527f4552433732313a207472616e73666572206f6620746f6b656e2074686174206960448201526839903737ba1037bbb760b91b6064820152608401610958565b6001600160a01b038216611f825760405162461bcd60e51b8152602060048201526024808201527f4552433732313a207472616e7366657220746f20746865207a65726f206164646044820152637265737360e01b6064820152608401610958565b611f8d600082611c87565b6001600160a01b0383166000908152600360205260408120805460019290611fb690849061385d565b90915550506001600160a01b0382166000908152600360205260408120805460019290611fe490849061377c565b909155505060008181526002602052604080822080546001600160a01b0319166001600160a01b0386811691821790925591518493918716917fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef91a4505050565b60008061205187611d32565b905060008160030182600201548154811061206e5761206e613847565b90600052602060002090600a020190508060020160018260020180549050612096919061385d565b815481106120a6576120a6613847565b90600052602060002090601091828204019190066002029054906101000a900461ffff1661ffff16826001015411156121355760405162461bcd60e51b815260206004820152602b60248201527f5a4b4f6e616363693a3a63617074757265546865466c61673a20414c4c5f544f60448201526a12d15394d7d3525395115160aa1b6064820152608401610958565b612150818a61214b84600201866001015461255c565b6125d2565b600480820154604080516060810182526001600160a01b038d811682528654602083015281830189905291516308a3cff560e11b815291909216926311479fea926121a3928c928c928c92909101613934565b602060405180830381865afa1580156121c0573d6000803e3d6000fd5b505050506040513d601f19601f820116820180604052508101906121e491906139b8565b15156001146122485760405162461bcd60e51b815260206004820152602a60248201527f5a4b4f6e616363693a3a63617074757265546865466c61673a20494e56414c49604482015269222fad25afa82927a7a360b11b6064820152608401610958565b6122558989848488612751565b9998505050505050505050565b60008560ff16116122b55760405162461bcd60e51b815260206004820152601760248201527f5a4b4f6e616363693a20494e56414c49445f4f524445520000000000000000006044820152606401610958565b600082511180156122c85750815160ff10155b6123145760405162461bcd60e51b815260206004820152601e60248201527f5a4b4f6e616363693a20494e56414c49445f54494552535f4c454e47544800006044820152606401610958565b81518151146123715760405162461bcd60e51b8152602060048201526024808201527f5a4b4f6e616363693a2054494552535f555249535f4c454e4754485f4d49534d604482015263082a886960e31b6064820152608401610958565b60015b8251811015612422578261238960018361385d565b8151811061239957612399613847565b602002602001015161ffff168382815181106123b7576123b7613847565b602002602001015161ffff16116124105760405162461bcd60e51b815260206004820152601e60248201527f5a4b4f6e616363693a2054494552535f4e4f545f494e4352454153494e4700006044820152606401610958565b8061241a81613870565b915050612374565b5060006007888154811061243857612438613847565b6000918252602080832060049092029091018781556001808201849055600382018054808301825590855292909320600a9092029091018781559092509081016124828682613a23565b5083516124989060028301906020870190612c45565b5082516124ae9060038301906020860190612ce9565b506004810180546001600160a01b038a166001600160a81b03199091168117600160a01b60ff8b160217909155600283015460408051898152602081019390935290918b917f7f4fe728e97c8a56ce861ca6b2c59b85150f43e484b67170219eb9846d9a443f910160405180910390a3505050505050505050565b612534848484611ea5565b61254084848484612884565b61156c5760405162461bcd60e51b815260040161095890613ae3565b6000805b835461256e9060019061385d565b8160ff161080156125b45750838160ff168154811061258f5761258f613847565b60009182526020909120601082040154600f9091166002026101000a900461ffff1683115b156125cb57806125c381613b35565b915050612560565b9392505050565b60058301541580612600575060058301546001600160a01b0383166000908152600785016020526040902054105b6126645760405162461bcd60e51b815260206004820152602f60248201527f5a4b4f6e616363693a3a63617074757265546865466c61673a2043415054555260448201526e1157d31253525517d4915050d21151608a1b6064820152608401610958565b600683015415806126975750826006018160ff168154811061268857612688613847565b90600052602060002001546000145b806126e85750826006018160ff16815481106126b5576126b5613847565b60009182526020808320909101546001600160a01b0385168352600886018252604080842060ff86168552909252912054105b610c175760405162461bcd60e51b815260206004820152603460248201527f5a4b4f6e616363693a3a63617074757265546865466c61673a20544945525f4360448201527310541515549157d31253525517d4915050d2115160621b6064820152608401610958565b82548184556001840154600091908261276d600287018361255c565b6002880154600488015491925060101b60208a901b17831790899082906001600160a01b038d16907fbe152ecaf7a007bb0a4533f1fb55748d8e09f66597bb3db940bc65bf6d9d498d906127cb90600160a01b900460ff168861377c565b6040805191825260ff881660208301528101899052606081018b905260800160405180910390a460018801805490600061280483613870565b90915550506001600160a01b038a166000908152600788016020526040812080549161282f83613870565b90915550506001600160a01b038a166000908152600888016020908152604080832060ff86168452909152812080549161286883613870565b919050555061287a8a8a838a86612985565b6122558a82612a67565b60006001600160a01b0384163b1561297a57604051630a85bd0160e11b81526001600160a01b0385169063150b7a02906128c8903390899088908890600401613b54565b6020604051808303816000875af1925050508015612903575060408051601f3d908101601f1916820190925261290091810190613b87565b60015b612960573d808015612931576040519150601f19603f3d011682016040523d82523d6000602084013e612936565b606091505b5080516000036129585760405162461bcd60e51b815260040161095890613ae3565b805181602001fd5b6001600160e01b031916630a85bd0160e11b149050611e9d565b506001949350505050565b600982015415612a60576000826009018260ff16815481106129a9576129a9613847565b906000526020600020015490506008548111156129c557506008545b806000036129d35750612a60565b80600860008282546129e5919061385d565b90915550506001600160a01b03861660009081526009602052604081208054839290612a1290849061377c565b925050819055508484876001600160a01b03167f1ea36cde5d89f72b939ae977ad3236deca9532580742d662fcce47ee24f51c2484604051612a5691815260200190565b60405180910390a4505b5050505050565b612a81828260405180602001604052806000815250612a85565b5050565b612a8f8383612ab8565b612a9c6000848484612884565b610c175760405162461bcd60e51b815260040161095890613ae3565b6001600160a01b038216612b0e5760405162461bcd60e51b815260206004820181905260248201527f4552433732313a206d696e7420746f20746865207a65726f20616464726573736044820152606401610958565b6000818152600260205260409020546001600160a01b031615612b735760405162461bcd60e51b815260206004820152601c60248201527f4552433732313a20746f6b656e20616c7265616479206d696e746564000000006044820152606401610958565b6001600160a01b0382166000908152600360205260408120805460019290612b9c90849061377c565b909155505060008181526002602052604080822080546001600160a01b0319166001600160a01b03861690811790915590518392907fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef908290a45050565b828054828255906000526020600020908101928215612c35579160200282015b82811115612c35578251825591602001919060010190612c1a565b50612c41929150612d3b565b5090565b82805482825590600052602060002090600f01601090048101928215612c355791602002820160005b83821115612cae57835183826101000a81548161ffff021916908361ffff1602179055509260200192600201602081600101049283019260010302612c6e565b8015612cdc5782816101000a81549061ffff0219169055600201602081600101049283019260010302612cae565b5050612c41929150612d3b565b828054828255906000526020600020908101928215612d2f579160200282015b82811115612d2f5782518290612d1f9082613a23565b5091602001919060010190612d09565b50612c41929150612d50565b5b80821115612c415760008155600101612d3c565b80821115612c41576000612d648282612d6d565b50600101612d50565b508054612d79906137bc565b6000825580601f10612d89575050565b601f016020900490600052602060002090810190612da79190612d3b565b50565b6001600160e01b031981168114612da757600080fd5b600060208284031215612dd257600080fd5b81356125cb81612daa565b80356001600160a01b0381168114612df457600080fd5b919050565b803560ff81168114612df457600080fd5b634e487b7160e01b600052604160045260246000fd5b6040805190810167ffffffffffffffff81118282101715612e4357612e43612e0a565b60405290565b604051601f8201601f1916810167ffffffffffffffff81118282101715612e7257612e72612e0a565b604052919050565b600067ffffffffffffffff831115612e9457612e94612e0a565b612ea7601f8401601f1916602001612e49565b9050828152838383011115612ebb57600080fd5b828260208301376000602084830101529392505050565b600082601f830112612ee357600080fd5b6125cb83833560208501612e7a565b600067ffffffffffffffff821115612f0c57612f0c612e0a565b5060051b60200190565b600082601f830112612f2757600080fd5b81356020612f3c612f3783612ef2565b612e49565b82815260059290921b84018101918181019086841115612f5b57600080fd5b8286015b84811015612f8757803561ffff81168114612f7a5760008081fd5b8352918301918301612f5f565b509695505050505050565b600082601f830112612fa357600080fd5b81356020612fb3612f3783612ef2565b82815260059290921b84018101918181019086841115612fd257600080fd5b8286015b84811015612f8757803567ffffffffffffffff811115612ff65760008081fd5b6130048986838b0101612ed2565b845250918301918301612fd6565b60008060008060008060c0878903121561302b57600080fd5b61303487612ddd565b955061304260208801612df9565b945060408701359350606087013567ffffffffffffffff8082111561306657600080fd5b6130728a838b01612ed2565b9450608089013591508082111561308857600080fd5b6130948a838b01612f16565b935060a08901359150808211156130aa57600080fd5b506130b789828a01612f92565b9150509295509295509295565b6000815180845260005b818110156130ea576020818501810151868301820152016130ce565b506000602082860101526020601f19601f83011685010191505092915050565b6020815260006125cb60208301846130c4565b60006020828403121561312f57600080fd5b6125cb82612ddd565b60006020828403121561314a57600080fd5b5035919050565b6000806040838503121561316457600080fd5b61316d83612ddd565b946020939093013593505050565b60008060006060848603121561319057600080fd5b61319984612ddd565b92506131a760208501612ddd565b9150604084013590509250925092565b6000806000606084860312156131cc57600080fd5b833592506131dc60208501612ddd565b91506131ea60408501612df9565b90509250925092565b600082601f83011261320457600080fd5b61320c612e20565b80604084018581111561321e57600080fd5b845b81811015613238578035845260209384019301613220565b509095945050505050565b600082601f83011261325457600080fd5b61325c612e20565b80608084018581111561326e57600080fd5b845b818110156132385761328287826131f3565b8452602090930192604001613270565b600080600080600061014086880312156132ab57600080fd5b853594506132bc87602088016131f3565b93506132cb8760608801613243565b92506132da8760e088016131f3565b94979396509194610120013592915050565b600081518084526020808501945080840160005b8381101561331c57815187529582019590820190600101613300565b509495945050505050565b6020815260006125cb60208301846132ec565b6000806040838503121561334d57600080fd5b8235915061335d60208401612ddd565b90509250929050565b600080600080600080600060e0888a03121561338157600080fd5b8735965061339160208901612ddd565b955061339f60408901612df9565b945060608801359350608088013567ffffffffffffffff808211156133c357600080fd5b6133cf8b838c01612ed2565b945060a08a01359150808211156133e557600080fd5b6133f18b838c01612f16565b935060c08a013591508082111561340757600080fd5b506134148a828b01612f92565b91505092959891949750929550565b600082601f83011261343457600080fd5b81356020613444612f3783612ef2565b82815260059290921b8401810191818101908684111561346357600080fd5b8286015b84811015612f875780358352918301918301613467565b6000806040838503121561349157600080fd5b82359150602083013567ffffffffffffffff8111156134af57600080fd5b6134bb85828601613423565b9150509250929050565b828152604060208201526000611e9d60408301846132ec565b8015158114612da757600080fd5b600080604083850312156134ff57600080fd5b61350883612ddd565b91506020830135613518816134de565b809150509250929050565b6000806000806080858703121561353957600080fd5b61354285612ddd565b935061355060208601612ddd565b925060408501359150606085013567ffffffffffffffff81111561357357600080fd5b8501601f8101871361358457600080fd5b61359387823560208401612e7a565b91505092959194509250565b6000806000606084860312156135b457600080fd5b8335925060208401359150604084013567ffffffffffffffff8111156135d957600080fd5b6135e586828701613423565b9150509250925092565b6000806040838503121561360257600080fd5b50508035926020909101359150565b6000806040838503121561362457600080fd5b61362d83612ddd565b915061335d60208401612ddd565b600080600080600080610160878903121561365557600080fd5b61365e87612ddd565b95506020870135945061367488604089016131f3565b93506136838860808901613243565b92506136938861010089016131f3565b915061014087013590509295509295509295565b8581526000602060a0818401526136c160a08401886130c4565b838103604085015286518082528288019183019060005b818110156136f857835161ffff16835292840192918401916001016136d8565b5050848103606086015286518082528382019250600581901b8201840184890160005b8381101561374957601f198584030186526137378383516130c4565b9587019592509086019060010161371b565b50506001600160a01b038816608088015294506109d69350505050565b634e487b7160e01b600052601160045260246000fd5b8082018082111561092557610925613766565b6020808252601390820152722d25a7b730b1b1b49d102727aa2fa7aba722a960691b604082015260600190565b600181811c908216806137d057607f821691505b6020821081036137f057634e487b7160e01b600052602260045260246000fd5b50919050565b60208082526031908201527f4552433732313a207472616e736665722063616c6c6572206973206e6f74206f6040820152701ddb995c881b9bdc88185c1c1c9bdd9959607a1b606082015260800190565b634e487b7160e01b600052603260045260246000fd5b8181038181111561092557610925613766565b60006001820161388257613882613766565b5060010190565b60008154613896816137bc565b600182811680156138ae57600181146138c3576138f2565b60ff19841687528215158302870194506138f2565b8560005260208060002060005b858110156138e95781548a8201529084019082016138d0565b50505082870194505b5050505092915050565b6000611e9d61390b8386613889565b84613889565b8060005b600281101561156c578151845260209384019390910190600101613915565b61016081016139438287613911565b60408083018660005b600281101561397357613960838351613911565b918301916020919091019060010161394c565b5050505061398460c0830185613911565b61010082018360005b60038110156139ac57815183526020928301929091019060010161398d565b50505095945050505050565b6000602082840312156139ca57600080fd5b81516125cb816134de565b601f821115610c1757600081815260208120601f850160051c810160208610156139fc5750805b601f850160051c820191505b81811015613a1b57828155600101613a08565b505050505050565b815167ffffffffffffffff811115613a3d57613a3d612e0a565b613a5181613a4b84546137bc565b846139d5565b602080601f831160018114613a865760008415613a6e5750858301515b600019600386901b1c1916600185901b178555613a1b565b600085815260208120601f198616915b82811015613ab557888601518255948401946001909101908401613a96565b5085821015613ad35787850151600019600388901b60f8161c191681555b5050505050600190811b01905550565b60208082526032908201527f4552433732313a207472616e7366657220746f206e6f6e20455243373231526560408201527131b2b4bb32b91034b6b83632b6b2b73a32b960711b606082015260800190565b600060ff821660ff8103613b4b57613b4b613766565b60010192915050565b6001600160a01b03858116825284166020820152604081018390526080606082018190526000906109d6908301846130c4565b600060208284031215613b9957600080fd5b81516125cb81612daa56fea2646970667358221220a78a50a2095870e5b76000c33b639b894eb84cd5bbd10c144812b32e7587ca1464736f6c63430008150033"
 
 // DeployZKOnacci deploys a new Ethereum contract, binding an instance of ZKOnacci to it.
-func DeployZKOnacci(auth *bind.TransactOpts, backend bind.ContractBackend, verifierAddr common.Address) (common.Address, *types.Transaction, *ZKOnacci, error) {
+func DeployZKOnacci(auth *bind.TransactOpts, backend bind.ContractBackend, verifierAddr common.Address, genesisRoot *big.Int, _baseURI string, _tokenTiers []uint16, _tokenURIs []string, _owner common.Address) (common.Address, *types.Transaction, *ZKOnacci, error) {
 	parsed, err := abi.JSON(strings.NewReader(ZKOnacciABI))
 	if err != nil {
 		return common.Address{}, nil, nil, err
 	}
 
-	address, tx, contract, err := bind.DeployContract(auth, parsed, common.FromHex(ZKOnacciBin), backend, verifierAddr)
+	address, tx, contract, err := bind.DeployContract(auth, parsed, common.FromHex(ZKOnacciBin), backend, verifierAddr, genesisRoot, _baseURI, _tokenTiers, _tokenURIs, _owner)
 	if err != nil {
 		return common.Address{}, nil, nil, err
 	}
@@ -4474,6 +4499,99 @@ func (_ZKOnacci *ZKOnacciTransactorRaw) Transact(opts *bind.TransactOpts, method
 	return _ZKOnacci.Contract.contract.Transact(opts, method, params...)
 }
 
+// PUZZLESHIFT is a free data retrieval call binding the contract method 0x7e0e8dfe.
+//
+// Solidity: function PUZZLE_SHIFT() view returns(uint256)
+func (_ZKOnacci *ZKOnacciCaller) PUZZLESHIFT(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _ZKOnacci.contract.Call(opts, &out, "PUZZLE_SHIFT")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// PUZZLESHIFT is a free data retrieval call binding the contract method 0x7e0e8dfe.
+//
+// Solidity: function PUZZLE_SHIFT() view returns(uint256)
+func (_ZKOnacci *ZKOnacciSession) PUZZLESHIFT() (*big.Int, error) {
+	return _ZKOnacci.Contract.PUZZLESHIFT(&_ZKOnacci.CallOpts)
+}
+
+// PUZZLESHIFT is a free data retrieval call binding the contract method 0x7e0e8dfe.
+//
+// Solidity: function PUZZLE_SHIFT() view returns(uint256)
+func (_ZKOnacci *ZKOnacciCallerSession) PUZZLESHIFT() (*big.Int, error) {
+	return _ZKOnacci.Contract.PUZZLESHIFT(&_ZKOnacci.CallOpts)
+}
+
+// SEASONSHIFT is a free data retrieval call binding the contract method 0x7114d6cd.
+//
+// Solidity: function SEASON_SHIFT() view returns(uint256)
+func (_ZKOnacci *ZKOnacciCaller) SEASONSHIFT(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _ZKOnacci.contract.Call(opts, &out, "SEASON_SHIFT")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// SEASONSHIFT is a free data retrieval call binding the contract method 0x7114d6cd.
+//
+// Solidity: function SEASON_SHIFT() view returns(uint256)
+func (_ZKOnacci *ZKOnacciSession) SEASONSHIFT() (*big.Int, error) {
+	return _ZKOnacci.Contract.SEASONSHIFT(&_ZKOnacci.CallOpts)
+}
+
+// SEASONSHIFT is a free data retrieval call binding the contract method 0x7114d6cd.
+//
+// Solidity: function SEASON_SHIFT() view returns(uint256)
+func (_ZKOnacci *ZKOnacciCallerSession) SEASONSHIFT() (*big.Int, error) {
+	return _ZKOnacci.Contract.SEASONSHIFT(&_ZKOnacci.CallOpts)
+}
+
+// ZKONACCIORDER is a free data retrieval call binding the contract method 0x0a6248c7.
+//
+// Solidity: function ZKONACCI_ORDER() view returns(uint8)
+func (_ZKOnacci *ZKOnacciCaller) ZKONACCIORDER(opts *bind.CallOpts) (uint8, error) {
+	var out []interface{}
+	err := _ZKOnacci.contract.Call(opts, &out, "ZKONACCI_ORDER")
+
+	if err != nil {
+		return *new(uint8), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint8)).(*uint8)
+
+	return out0, err
+
+}
+
+// ZKONACCIORDER is a free data retrieval call binding the contract method 0x0a6248c7.
+//
+// Solidity: function ZKONACCI_ORDER() view returns(uint8)
+func (_ZKOnacci *ZKOnacciSession) ZKONACCIORDER() (uint8, error) {
+	return _ZKOnacci.Contract.ZKONACCIORDER(&_ZKOnacci.CallOpts)
+}
+
+// ZKONACCIORDER is a free data retrieval call binding the contract method 0x0a6248c7.
+//
+// Solidity: function ZKONACCI_ORDER() view returns(uint8)
+func (_ZKOnacci *ZKOnacciCallerSession) ZKONACCIORDER() (uint8, error) {
+	return _ZKOnacci.Contract.ZKONACCIORDER(&_ZKOnacci.CallOpts)
+}
+
 // BalanceOf is a free data retrieval call binding the contract method 0x70a08231.
 //
 // Solidity: function balanceOf(address owner) view returns(uint256)
@@ -4505,12 +4623,12 @@ func (_ZKOnacci *ZKOnacciCallerSession) BalanceOf(owner common.Address) (*big.In
 	return _ZKOnacci.Contract.BalanceOf(&_ZKOnacci.CallOpts, owner)
 }
 
-// BaseURI is a free data retrieval call binding the contract method 0x6c0360eb.
+// BaseURI is a free data retrieval call binding the contract method 0x2ccde4f6.
 //
-// Solidity: function baseURI() view returns(string)
-func (_ZKOnacci *ZKOnacciCaller) BaseURI(opts *bind.CallOpts) (string, error) {
+// Solidity: function baseURI(uint256 puzzleId) view returns(string)
+func (_ZKOnacci *ZKOnacciCaller) BaseURI(opts *bind.CallOpts, puzzleId *big.Int) (string, error) {
 	var out []interface{}
-	err := _ZKOnacci.contract.Call(opts, &out, "baseURI")
+	err := _ZKOnacci.contract.Call(opts, &out, "baseURI", puzzleId)
 
 	if err != nil {
 		return *new(string), err
@@ -4522,18 +4640,125 @@ func (_ZKOnacci *ZKOnacciCaller) BaseURI(opts *bind.CallOpts) (string, error) {
 
 }
 
-// BaseURI is a free data retrieval call binding the contract method 0x6c0360eb.
+// BaseURI is a free data retrieval call binding the contract method 0x2ccde4f6.
+//
+// Solidity: function baseURI(uint256 puzzleId) view returns(string)
+func (_ZKOnacci *ZKOnacciSession) BaseURI(puzzleId *big.Int) (string, error) {
+	return _ZKOnacci.Contract.BaseURI(&_ZKOnacci.CallOpts, puzzleId)
+}
+
+// BaseURI is a free data retrieval call binding the contract method 0x2ccde4f6.
+//
+// Solidity: function baseURI(uint256 puzzleId) view returns(string)
+func (_ZKOnacci *ZKOnacciCallerSession) BaseURI(puzzleId *big.Int) (string, error) {
+	return _ZKOnacci.Contract.BaseURI(&_ZKOnacci.CallOpts, puzzleId)
+}
+
+// CaptureLimits is a free data retrieval call binding the contract method 0x94ed9dbc.
+//
+// Solidity: function captureLimits(uint256 puzzleId) view returns(uint256 maxCaptures, uint256[] maxTierCaptures)
+func (_ZKOnacci *ZKOnacciCaller) CaptureLimits(opts *bind.CallOpts, puzzleId *big.Int) (struct {
+	MaxCaptures     *big.Int
+	MaxTierCaptures []*big.Int
+}, error) {
+	var out []interface{}
+	err := _ZKOnacci.contract.Call(opts, &out, "captureLimits", puzzleId)
+
+	outstruct := new(struct {
+		MaxCaptures     *big.Int
+		MaxTierCaptures []*big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.MaxCaptures = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	outstruct.MaxTierCaptures = *abi.ConvertType(out[1], new([]*big.Int)).(*[]*big.Int)
+
+	return *outstruct, err
+
+}
+
+// CaptureLimits is a free data retrieval call binding the contract method 0x94ed9dbc.
+//
+// Solidity: function captureLimits(uint256 puzzleId) view returns(uint256 maxCaptures, uint256[] maxTierCaptures)
+func (_ZKOnacci *ZKOnacciSession) CaptureLimits(puzzleId *big.Int) (struct {
+	MaxCaptures     *big.Int
+	MaxTierCaptures []*big.Int
+}, error) {
+	return _ZKOnacci.Contract.CaptureLimits(&_ZKOnacci.CallOpts, puzzleId)
+}
+
+// CaptureLimits is a free data retrieval call binding the contract method 0x94ed9dbc.
+//
+// Solidity: function captureLimits(uint256 puzzleId) view returns(uint256 maxCaptures, uint256[] maxTierCaptures)
+func (_ZKOnacci *ZKOnacciCallerSession) CaptureLimits(puzzleId *big.Int) (struct {
+	MaxCaptures     *big.Int
+	MaxTierCaptures []*big.Int
+}, error) {
+	return _ZKOnacci.Contract.CaptureLimits(&_ZKOnacci.CallOpts, puzzleId)
+}
+
+// Captures is a free data retrieval call binding the contract method 0x70340a47.
+//
+// Solidity: function captures(uint256 puzzleId, address player) view returns(uint256)
+func (_ZKOnacci *ZKOnacciCaller) Captures(opts *bind.CallOpts, puzzleId *big.Int, player common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _ZKOnacci.contract.Call(opts, &out, "captures", puzzleId, player)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// Captures is a free data retrieval call binding the contract method 0x70340a47.
+//
+// Solidity: function captures(uint256 puzzleId, address player) view returns(uint256)
+func (_ZKOnacci *ZKOnacciSession) Captures(puzzleId *big.Int, player common.Address) (*big.Int, error) {
+	return _ZKOnacci.Contract.Captures(&_ZKOnacci.CallOpts, puzzleId, player)
+}
+
+// Captures is a free data retrieval call binding the contract method 0x70340a47.
+//
+// Solidity: function captures(uint256 puzzleId, address player) view returns(uint256)
+func (_ZKOnacci *ZKOnacciCallerSession) Captures(puzzleId *big.Int, player common.Address) (*big.Int, error) {
+	return _ZKOnacci.Contract.Captures(&_ZKOnacci.CallOpts, puzzleId, player)
+}
+
+// CurrentSeason is a free data retrieval call binding the contract method 0xa54d0809.
+//
+// Solidity: function currentSeason(uint256 puzzleId) view returns(uint256)
+func (_ZKOnacci *ZKOnacciCaller) CurrentSeason(opts *bind.CallOpts, puzzleId *big.Int) (*big.Int, error) {
+	var out []interface{}
+	err := _ZKOnacci.contract.Call(opts, &out, "currentSeason", puzzleId)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// CurrentSeason is a free data retrieval call binding the contract method 0xa54d0809.
 //
-// Solidity: function baseURI() view returns(string)
-func (_ZKOnacci *ZKOnacciSession) BaseURI() (string, error) {
-	return _ZKOnacci.Contract.BaseURI(&_ZKOnacci.CallOpts)
+// Solidity: function currentSeason(uint256 puzzleId) view returns(uint256)
+func (_ZKOnacci *ZKOnacciSession) CurrentSeason(puzzleId *big.Int) (*big.Int, error) {
+	return _ZKOnacci.Contract.CurrentSeason(&_ZKOnacci.CallOpts, puzzleId)
 }
 
-// BaseURI is a free data retrieval call binding the contract method 0x6c0360eb.
+// CurrentSeason is a free data retrieval call binding the contract method 0xa54d0809.
 //
-// Solidity: function baseURI() view returns(string)
-func (_ZKOnacci *ZKOnacciCallerSession) BaseURI() (string, error) {
-	return _ZKOnacci.Contract.BaseURI(&_ZKOnacci.CallOpts)
+// Solidity: function currentSeason(uint256 puzzleId) view returns(uint256)
+func (_ZKOnacci *ZKOnacciCallerSession) CurrentSeason(puzzleId *big.Int) (*big.Int, error) {
+	return _ZKOnacci.Contract.CurrentSeason(&_ZKOnacci.CallOpts, puzzleId)
 }
 
 // GetApproved is a free data retrieval call binding the contract method 0x081812fc.
@@ -4598,12 +4823,43 @@ func (_ZKOnacci *ZKOnacciCallerSession) IsApprovedForAll(owner common.Address, o
 	return _ZKOnacci.Contract.IsApprovedForAll(&_ZKOnacci.CallOpts, owner, operator)
 }
 
-// NTiers is a free data retrieval call binding the contract method 0x50d5033d.
+// NPuzzles is a free data retrieval call binding the contract method 0x73531760.
+//
+// Solidity: function nPuzzles() view returns(uint256)
+func (_ZKOnacci *ZKOnacciCaller) NPuzzles(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _ZKOnacci.contract.Call(opts, &out, "nPuzzles")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// NPuzzles is a free data retrieval call binding the contract method 0x73531760.
+//
+// Solidity: function nPuzzles() view returns(uint256)
+func (_ZKOnacci *ZKOnacciSession) NPuzzles() (*big.Int, error) {
+	return _ZKOnacci.Contract.NPuzzles(&_ZKOnacci.CallOpts)
+}
+
+// NPuzzles is a free data retrieval call binding the contract method 0x73531760.
+//
+// Solidity: function nPuzzles() view returns(uint256)
+func (_ZKOnacci *ZKOnacciCallerSession) NPuzzles() (*big.Int, error) {
+	return _ZKOnacci.Contract.NPuzzles(&_ZKOnacci.CallOpts)
+}
+
+// NTiers is a free data retrieval call binding the contract method 0x2dec4cc1.
 //
-// Solidity: function nTiers() view returns(uint8)
-func (_ZKOnacci *ZKOnacciCaller) NTiers(opts *bind.CallOpts) (uint8, error) {
+// Solidity: function nTiers(uint256 puzzleId) view returns(uint8)
+func (_ZKOnacci *ZKOnacciCaller) NTiers(opts *bind.CallOpts, puzzleId *big.Int) (uint8, error) {
 	var out []interface{}
-	err := _ZKOnacci.contract.Call(opts, &out, "nTiers")
+	err := _ZKOnacci.contract.Call(opts, &out, "nTiers", puzzleId)
 
 	if err != nil {
 		return *new(uint8), err
@@ -4615,18 +4871,18 @@ func (_ZKOnacci *ZKOnacciCaller) NTiers(opts *bind.CallOpts) (uint8, error) {
 
 }
 
-// NTiers is a free data retrieval call binding the contract method 0x50d5033d.
+// NTiers is a free data retrieval call binding the contract method 0x2dec4cc1.
 //
-// Solidity: function nTiers() view returns(uint8)
-func (_ZKOnacci *ZKOnacciSession) NTiers() (uint8, error) {
-	return _ZKOnacci.Contract.NTiers(&_ZKOnacci.CallOpts)
+// Solidity: function nTiers(uint256 puzzleId) view returns(uint8)
+func (_ZKOnacci *ZKOnacciSession) NTiers(puzzleId *big.Int) (uint8, error) {
+	return _ZKOnacci.Contract.NTiers(&_ZKOnacci.CallOpts, puzzleId)
 }
 
-// NTiers is a free data retrieval call binding the contract method 0x50d5033d.
+// NTiers is a free data retrieval call binding the contract method 0x2dec4cc1.
 //
-// Solidity: function nTiers() view returns(uint8)
-func (_ZKOnacci *ZKOnacciCallerSession) NTiers() (uint8, error) {
-	return _ZKOnacci.Contract.NTiers(&_ZKOnacci.CallOpts)
+// Solidity: function nTiers(uint256 puzzleId) view returns(uint8)
+func (_ZKOnacci *ZKOnacciCallerSession) NTiers(puzzleId *big.Int) (uint8, error) {
+	return _ZKOnacci.Contract.NTiers(&_ZKOnacci.CallOpts, puzzleId)
 }
 
 // Name is a free data retrieval call binding the contract method 0x06fdde03.
@@ -4660,6 +4916,68 @@ func (_ZKOnacci *ZKOnacciCallerSession) Name() (string, error) {
 	return _ZKOnacci.Contract.Name(&_ZKOnacci.CallOpts)
 }
 
+// Order is a free data retrieval call binding the contract method 0x21603f43.
+//
+// Solidity: function order(uint256 puzzleId) view returns(uint8)
+func (_ZKOnacci *ZKOnacciCaller) Order(opts *bind.CallOpts, puzzleId *big.Int) (uint8, error) {
+	var out []interface{}
+	err := _ZKOnacci.contract.Call(opts, &out, "order", puzzleId)
+
+	if err != nil {
+		return *new(uint8), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint8)).(*uint8)
+
+	return out0, err
+
+}
+
+// Order is a free data retrieval call binding the contract method 0x21603f43.
+//
+// Solidity: function order(uint256 puzzleId) view returns(uint8)
+func (_ZKOnacci *ZKOnacciSession) Order(puzzleId *big.Int) (uint8, error) {
+	return _ZKOnacci.Contract.Order(&_ZKOnacci.CallOpts, puzzleId)
+}
+
+// Order is a free data retrieval call binding the contract method 0x21603f43.
+//
+// Solidity: function order(uint256 puzzleId) view returns(uint8)
+func (_ZKOnacci *ZKOnacciCallerSession) Order(puzzleId *big.Int) (uint8, error) {
+	return _ZKOnacci.Contract.Order(&_ZKOnacci.CallOpts, puzzleId)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_ZKOnacci *ZKOnacciCaller) Owner(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _ZKOnacci.contract.Call(opts, &out, "owner")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_ZKOnacci *ZKOnacciSession) Owner() (common.Address, error) {
+	return _ZKOnacci.Contract.Owner(&_ZKOnacci.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_ZKOnacci *ZKOnacciCallerSession) Owner() (common.Address, error) {
+	return _ZKOnacci.Contract.Owner(&_ZKOnacci.CallOpts)
+}
+
 // OwnerOf is a free data retrieval call binding the contract method 0x6352211e.
 //
 // Solidity: function ownerOf(uint256 tokenId) view returns(address)
@@ -4691,12 +5009,12 @@ func (_ZKOnacci *ZKOnacciCallerSession) OwnerOf(tokenId *big.Int) (common.Addres
 	return _ZKOnacci.Contract.OwnerOf(&_ZKOnacci.CallOpts, tokenId)
 }
 
-// Root is a free data retrieval call binding the contract method 0xebf0c717.
+// PrizePool is a free data retrieval call binding the contract method 0x719ce73e.
 //
-// Solidity: function root() view returns(uint256)
-func (_ZKOnacci *ZKOnacciCaller) Root(opts *bind.CallOpts) (*big.Int, error) {
+// Solidity: function prizePool() view returns(uint256)
+func (_ZKOnacci *ZKOnacciCaller) PrizePool(opts *bind.CallOpts) (*big.Int, error) {
 	var out []interface{}
-	err := _ZKOnacci.contract.Call(opts, &out, "root")
+	err := _ZKOnacci.contract.Call(opts, &out, "prizePool")
 
 	if err != nil {
 		return *new(*big.Int), err
@@ -4708,88 +5026,88 @@ func (_ZKOnacci *ZKOnacciCaller) Root(opts *bind.CallOpts) (*big.Int, error) {
 
 }
 
-// Root is a free data retrieval call binding the contract method 0xebf0c717.
+// PrizePool is a free data retrieval call binding the contract method 0x719ce73e.
 //
-// Solidity: function root() view returns(uint256)
-func (_ZKOnacci *ZKOnacciSession) Root() (*big.Int, error) {
-	return _ZKOnacci.Contract.Root(&_ZKOnacci.CallOpts)
+// Solidity: function prizePool() view returns(uint256)
+func (_ZKOnacci *ZKOnacciSession) PrizePool() (*big.Int, error) {
+	return _ZKOnacci.Contract.PrizePool(&_ZKOnacci.CallOpts)
 }
 
-// Root is a free data retrieval call binding the contract method 0xebf0c717.
+// PrizePool is a free data retrieval call binding the contract method 0x719ce73e.
 //
-// Solidity: function root() view returns(uint256)
-func (_ZKOnacci *ZKOnacciCallerSession) Root() (*big.Int, error) {
-	return _ZKOnacci.Contract.Root(&_ZKOnacci.CallOpts)
+// Solidity: function prizePool() view returns(uint256)
+func (_ZKOnacci *ZKOnacciCallerSession) PrizePool() (*big.Int, error) {
+	return _ZKOnacci.Contract.PrizePool(&_ZKOnacci.CallOpts)
 }
 
-// SupportsInterface is a free data retrieval call binding the contract method 0x01ffc9a7.
+// PuzzleOf is a free data retrieval call binding the contract method 0x7ab40036.
 //
-// Solidity: function supportsInterface(bytes4 interfaceId) view returns(bool)
-func (_ZKOnacci *ZKOnacciCaller) SupportsInterface(opts *bind.CallOpts, interfaceId [4]byte) (bool, error) {
+// Solidity: function puzzleOf(uint256 tokenId) pure returns(uint256)
+func (_ZKOnacci *ZKOnacciCaller) PuzzleOf(opts *bind.CallOpts, tokenId *big.Int) (*big.Int, error) {
 	var out []interface{}
-	err := _ZKOnacci.contract.Call(opts, &out, "supportsInterface", interfaceId)
+	err := _ZKOnacci.contract.Call(opts, &out, "puzzleOf", tokenId)
 
 	if err != nil {
-		return *new(bool), err
+		return *new(*big.Int), err
 	}
 
-	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
 
 	return out0, err
 
 }
 
-// SupportsInterface is a free data retrieval call binding the contract method 0x01ffc9a7.
+// PuzzleOf is a free data retrieval call binding the contract method 0x7ab40036.
 //
-// Solidity: function supportsInterface(bytes4 interfaceId) view returns(bool)
-func (_ZKOnacci *ZKOnacciSession) SupportsInterface(interfaceId [4]byte) (bool, error) {
-	return _ZKOnacci.Contract.SupportsInterface(&_ZKOnacci.CallOpts, interfaceId)
+// Solidity: function puzzleOf(uint256 tokenId) pure returns(uint256)
+func (_ZKOnacci *ZKOnacciSession) PuzzleOf(tokenId *big.Int) (*big.Int, error) {
+	return _ZKOnacci.Contract.PuzzleOf(&_ZKOnacci.CallOpts, tokenId)
 }
 
-// SupportsInterface is a free data retrieval call binding the contract method 0x01ffc9a7.
+// PuzzleOf is a free data retrieval call binding the contract method 0x7ab40036.
 //
-// Solidity: function supportsInterface(bytes4 interfaceId) view returns(bool)
-func (_ZKOnacci *ZKOnacciCallerSession) SupportsInterface(interfaceId [4]byte) (bool, error) {
-	return _ZKOnacci.Contract.SupportsInterface(&_ZKOnacci.CallOpts, interfaceId)
+// Solidity: function puzzleOf(uint256 tokenId) pure returns(uint256)
+func (_ZKOnacci *ZKOnacciCallerSession) PuzzleOf(tokenId *big.Int) (*big.Int, error) {
+	return _ZKOnacci.Contract.PuzzleOf(&_ZKOnacci.CallOpts, tokenId)
 }
 
-// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
+// Rewards is a free data retrieval call binding the contract method 0x0700037d.
 //
-// Solidity: function symbol() view returns(string)
-func (_ZKOnacci *ZKOnacciCaller) Symbol(opts *bind.CallOpts) (string, error) {
+// Solidity: function rewards(address ) view returns(uint256)
+func (_ZKOnacci *ZKOnacciCaller) Rewards(opts *bind.CallOpts, arg0 common.Address) (*big.Int, error) {
 	var out []interface{}
-	err := _ZKOnacci.contract.Call(opts, &out, "symbol")
+	err := _ZKOnacci.contract.Call(opts, &out, "rewards", arg0)
 
 	if err != nil {
-		return *new(string), err
+		return *new(*big.Int), err
 	}
 
-	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
 
 	return out0, err
 
 }
 
-// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
+// Rewards is a free data retrieval call binding the contract method 0x0700037d.
 //
-// Solidity: function symbol() view returns(string)
-func (_ZKOnacci *ZKOnacciSession) Symbol() (string, error) {
-	return _ZKOnacci.Contract.Symbol(&_ZKOnacci.CallOpts)
+// Solidity: function rewards(address ) view returns(uint256)
+func (_ZKOnacci *ZKOnacciSession) Rewards(arg0 common.Address) (*big.Int, error) {
+	return _ZKOnacci.Contract.Rewards(&_ZKOnacci.CallOpts, arg0)
 }
 
-// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
+// Rewards is a free data retrieval call binding the contract method 0x0700037d.
 //
-// Solidity: function symbol() view returns(string)
-func (_ZKOnacci *ZKOnacciCallerSession) Symbol() (string, error) {
-	return _ZKOnacci.Contract.Symbol(&_ZKOnacci.CallOpts)
+// Solidity: function rewards(address ) view returns(uint256)
+func (_ZKOnacci *ZKOnacciCallerSession) Rewards(arg0 common.Address) (*big.Int, error) {
+	return _ZKOnacci.Contract.Rewards(&_ZKOnacci.CallOpts, arg0)
 }
 
-// TokenCounter is a free data retrieval call binding the contract method 0xd082e381.
+// Root is a free data retrieval call binding the contract method 0xe56bf027.
 //
-// Solidity: function tokenCounter() view returns(uint256)
-func (_ZKOnacci *ZKOnacciCaller) TokenCounter(opts *bind.CallOpts) (*big.Int, error) {
+// Solidity: function root(uint256 puzzleId) view returns(uint256)
+func (_ZKOnacci *ZKOnacciCaller) Root(opts *bind.CallOpts, puzzleId *big.Int) (*big.Int, error) {
 	var out []interface{}
-	err := _ZKOnacci.contract.Call(opts, &out, "tokenCounter")
+	err := _ZKOnacci.contract.Call(opts, &out, "root", puzzleId)
 
 	if err != nil {
 		return *new(*big.Int), err
@@ -4801,123 +5119,421 @@ func (_ZKOnacci *ZKOnacciCaller) TokenCounter(opts *bind.CallOpts) (*big.Int, er
 
 }
 
-// TokenCounter is a free data retrieval call binding the contract method 0xd082e381.
+// Root is a free data retrieval call binding the contract method 0xe56bf027.
 //
-// Solidity: function tokenCounter() view returns(uint256)
-func (_ZKOnacci *ZKOnacciSession) TokenCounter() (*big.Int, error) {
-	return _ZKOnacci.Contract.TokenCounter(&_ZKOnacci.CallOpts)
+// Solidity: function root(uint256 puzzleId) view returns(uint256)
+func (_ZKOnacci *ZKOnacciSession) Root(puzzleId *big.Int) (*big.Int, error) {
+	return _ZKOnacci.Contract.Root(&_ZKOnacci.CallOpts, puzzleId)
 }
 
-// TokenCounter is a free data retrieval call binding the contract method 0xd082e381.
+// Root is a free data retrieval call binding the contract method 0xe56bf027.
 //
-// Solidity: function tokenCounter() view returns(uint256)
-func (_ZKOnacci *ZKOnacciCallerSession) TokenCounter() (*big.Int, error) {
-	return _ZKOnacci.Contract.TokenCounter(&_ZKOnacci.CallOpts)
+// Solidity: function root(uint256 puzzleId) view returns(uint256)
+func (_ZKOnacci *ZKOnacciCallerSession) Root(puzzleId *big.Int) (*big.Int, error) {
+	return _ZKOnacci.Contract.Root(&_ZKOnacci.CallOpts, puzzleId)
 }
 
-// TokenTiers is a free data retrieval call binding the contract method 0x818f2e42.
+// Season is a free data retrieval call binding the contract method 0xfaea2091.
 //
-// Solidity: function tokenTiers(uint256 ) view returns(uint16)
-func (_ZKOnacci *ZKOnacciCaller) TokenTiers(opts *bind.CallOpts, arg0 *big.Int) (uint16, error) {
+// Solidity: function season(uint256 puzzleId, uint256 seasonId) view returns(uint256 genesisRoot, string seasonBaseURI, uint16[] seasonTokenTiers, string[] seasonTokenURIs, address verifierAddr)
+func (_ZKOnacci *ZKOnacciCaller) Season(opts *bind.CallOpts, puzzleId *big.Int, seasonId *big.Int) (struct {
+	GenesisRoot      *big.Int
+	SeasonBaseURI    string
+	SeasonTokenTiers []uint16
+	SeasonTokenURIs  []string
+	VerifierAddr     common.Address
+}, error) {
 	var out []interface{}
-	err := _ZKOnacci.contract.Call(opts, &out, "tokenTiers", arg0)
+	err := _ZKOnacci.contract.Call(opts, &out, "season", puzzleId, seasonId)
 
+	outstruct := new(struct {
+		GenesisRoot      *big.Int
+		SeasonBaseURI    string
+		SeasonTokenTiers []uint16
+		SeasonTokenURIs  []string
+		VerifierAddr     common.Address
+	})
 	if err != nil {
-		return *new(uint16), err
+		return *outstruct, err
 	}
 
-	out0 := *abi.ConvertType(out[0], new(uint16)).(*uint16)
+	outstruct.GenesisRoot = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	outstruct.SeasonBaseURI = *abi.ConvertType(out[1], new(string)).(*string)
+	outstruct.SeasonTokenTiers = *abi.ConvertType(out[2], new([]uint16)).(*[]uint16)
+	outstruct.SeasonTokenURIs = *abi.ConvertType(out[3], new([]string)).(*[]string)
+	outstruct.VerifierAddr = *abi.ConvertType(out[4], new(common.Address)).(*common.Address)
 
-	return out0, err
+	return *outstruct, err
 
 }
 
-// TokenTiers is a free data retrieval call binding the contract method 0x818f2e42.
+// Season is a free data retrieval call binding the contract method 0xfaea2091.
 //
-// Solidity: function tokenTiers(uint256 ) view returns(uint16)
-func (_ZKOnacci *ZKOnacciSession) TokenTiers(arg0 *big.Int) (uint16, error) {
-	return _ZKOnacci.Contract.TokenTiers(&_ZKOnacci.CallOpts, arg0)
+// Solidity: function season(uint256 puzzleId, uint256 seasonId) view returns(uint256 genesisRoot, string seasonBaseURI, uint16[] seasonTokenTiers, string[] seasonTokenURIs, address verifierAddr)
+func (_ZKOnacci *ZKOnacciSession) Season(puzzleId *big.Int, seasonId *big.Int) (struct {
+	GenesisRoot      *big.Int
+	SeasonBaseURI    string
+	SeasonTokenTiers []uint16
+	SeasonTokenURIs  []string
+	VerifierAddr     common.Address
+}, error) {
+	return _ZKOnacci.Contract.Season(&_ZKOnacci.CallOpts, puzzleId, seasonId)
 }
 
-// TokenTiers is a free data retrieval call binding the contract method 0x818f2e42.
+// Season is a free data retrieval call binding the contract method 0xfaea2091.
 //
-// Solidity: function tokenTiers(uint256 ) view returns(uint16)
-func (_ZKOnacci *ZKOnacciCallerSession) TokenTiers(arg0 *big.Int) (uint16, error) {
-	return _ZKOnacci.Contract.TokenTiers(&_ZKOnacci.CallOpts, arg0)
+// Solidity: function season(uint256 puzzleId, uint256 seasonId) view returns(uint256 genesisRoot, string seasonBaseURI, uint16[] seasonTokenTiers, string[] seasonTokenURIs, address verifierAddr)
+func (_ZKOnacci *ZKOnacciCallerSession) Season(puzzleId *big.Int, seasonId *big.Int) (struct {
+	GenesisRoot      *big.Int
+	SeasonBaseURI    string
+	SeasonTokenTiers []uint16
+	SeasonTokenURIs  []string
+	VerifierAddr     common.Address
+}, error) {
+	return _ZKOnacci.Contract.Season(&_ZKOnacci.CallOpts, puzzleId, seasonId)
 }
 
-// TokenURI is a free data retrieval call binding the contract method 0xc87b56dd.
+// SeasonOf is a free data retrieval call binding the contract method 0xa6084f47.
 //
-// Solidity: function tokenURI(uint256 tokenId) view returns(string)
-func (_ZKOnacci *ZKOnacciCaller) TokenURI(opts *bind.CallOpts, tokenId *big.Int) (string, error) {
+// Solidity: function seasonOf(uint256 tokenId) pure returns(uint256)
+func (_ZKOnacci *ZKOnacciCaller) SeasonOf(opts *bind.CallOpts, tokenId *big.Int) (*big.Int, error) {
 	var out []interface{}
-	err := _ZKOnacci.contract.Call(opts, &out, "tokenURI", tokenId)
+	err := _ZKOnacci.contract.Call(opts, &out, "seasonOf", tokenId)
 
 	if err != nil {
-		return *new(string), err
+		return *new(*big.Int), err
 	}
 
-	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
 
 	return out0, err
 
 }
 
-// TokenURI is a free data retrieval call binding the contract method 0xc87b56dd.
+// SeasonOf is a free data retrieval call binding the contract method 0xa6084f47.
 //
-// Solidity: function tokenURI(uint256 tokenId) view returns(string)
-func (_ZKOnacci *ZKOnacciSession) TokenURI(tokenId *big.Int) (string, error) {
-	return _ZKOnacci.Contract.TokenURI(&_ZKOnacci.CallOpts, tokenId)
+// Solidity: function seasonOf(uint256 tokenId) pure returns(uint256)
+func (_ZKOnacci *ZKOnacciSession) SeasonOf(tokenId *big.Int) (*big.Int, error) {
+	return _ZKOnacci.Contract.SeasonOf(&_ZKOnacci.CallOpts, tokenId)
 }
 
-// TokenURI is a free data retrieval call binding the contract method 0xc87b56dd.
+// SeasonOf is a free data retrieval call binding the contract method 0xa6084f47.
 //
-// Solidity: function tokenURI(uint256 tokenId) view returns(string)
-func (_ZKOnacci *ZKOnacciCallerSession) TokenURI(tokenId *big.Int) (string, error) {
-	return _ZKOnacci.Contract.TokenURI(&_ZKOnacci.CallOpts, tokenId)
+// Solidity: function seasonOf(uint256 tokenId) pure returns(uint256)
+func (_ZKOnacci *ZKOnacciCallerSession) SeasonOf(tokenId *big.Int) (*big.Int, error) {
+	return _ZKOnacci.Contract.SeasonOf(&_ZKOnacci.CallOpts, tokenId)
 }
 
-// TokenURIs is a free data retrieval call binding the contract method 0x6c8b703f.
+// SupportsInterface is a free data retrieval call binding the contract method 0x01ffc9a7.
 //
-// Solidity: function tokenURIs(uint256 ) view returns(string)
-func (_ZKOnacci *ZKOnacciCaller) TokenURIs(opts *bind.CallOpts, arg0 *big.Int) (string, error) {
+// Solidity: function supportsInterface(bytes4 interfaceId) view returns(bool)
+func (_ZKOnacci *ZKOnacciCaller) SupportsInterface(opts *bind.CallOpts, interfaceId [4]byte) (bool, error) {
 	var out []interface{}
-	err := _ZKOnacci.contract.Call(opts, &out, "tokenURIs", arg0)
+	err := _ZKOnacci.contract.Call(opts, &out, "supportsInterface", interfaceId)
 
 	if err != nil {
-		return *new(string), err
+		return *new(bool), err
 	}
 
-	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
 
 	return out0, err
 
 }
 
-// TokenURIs is a free data retrieval call binding the contract method 0x6c8b703f.
+// SupportsInterface is a free data retrieval call binding the contract method 0x01ffc9a7.
 //
-// Solidity: function tokenURIs(uint256 ) view returns(string)
-func (_ZKOnacci *ZKOnacciSession) TokenURIs(arg0 *big.Int) (string, error) {
-	return _ZKOnacci.Contract.TokenURIs(&_ZKOnacci.CallOpts, arg0)
+// Solidity: function supportsInterface(bytes4 interfaceId) view returns(bool)
+func (_ZKOnacci *ZKOnacciSession) SupportsInterface(interfaceId [4]byte) (bool, error) {
+	return _ZKOnacci.Contract.SupportsInterface(&_ZKOnacci.CallOpts, interfaceId)
 }
 
-// TokenURIs is a free data retrieval call binding the contract method 0x6c8b703f.
+// SupportsInterface is a free data retrieval call binding the contract method 0x01ffc9a7.
 //
-// Solidity: function tokenURIs(uint256 ) view returns(string)
-func (_ZKOnacci *ZKOnacciCallerSession) TokenURIs(arg0 *big.Int) (string, error) {
-	return _ZKOnacci.Contract.TokenURIs(&_ZKOnacci.CallOpts, arg0)
+// Solidity: function supportsInterface(bytes4 interfaceId) view returns(bool)
+func (_ZKOnacci *ZKOnacciCallerSession) SupportsInterface(interfaceId [4]byte) (bool, error) {
+	return _ZKOnacci.Contract.SupportsInterface(&_ZKOnacci.CallOpts, interfaceId)
 }
 
-// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
 //
-// Solidity: function approve(address to, uint256 tokenId) returns()
-func (_ZKOnacci *ZKOnacciTransactor) Approve(opts *bind.TransactOpts, to common.Address, tokenId *big.Int) (*types.Transaction, error) {
-	return _ZKOnacci.contract.Transact(opts, "approve", to, tokenId)
+// Solidity: function symbol() view returns(string)
+func (_ZKOnacci *ZKOnacciCaller) Symbol(opts *bind.CallOpts) (string, error) {
+	var out []interface{}
+	err := _ZKOnacci.contract.Call(opts, &out, "symbol")
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
 }
 
-// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
 //
-// Solidity: function approve(address to, uint256 tokenId) returns()
+// Solidity: function symbol() view returns(string)
+func (_ZKOnacci *ZKOnacciSession) Symbol() (string, error) {
+	return _ZKOnacci.Contract.Symbol(&_ZKOnacci.CallOpts)
+}
+
+// Symbol is a free data retrieval call binding the contract method 0x95d89b41.
+//
+// Solidity: function symbol() view returns(string)
+func (_ZKOnacci *ZKOnacciCallerSession) Symbol() (string, error) {
+	return _ZKOnacci.Contract.Symbol(&_ZKOnacci.CallOpts)
+}
+
+// TierCaptures is a free data retrieval call binding the contract method 0x34e7a671.
+//
+// Solidity: function tierCaptures(uint256 puzzleId, address player, uint8 tier) view returns(uint256)
+func (_ZKOnacci *ZKOnacciCaller) TierCaptures(opts *bind.CallOpts, puzzleId *big.Int, player common.Address, tier uint8) (*big.Int, error) {
+	var out []interface{}
+	err := _ZKOnacci.contract.Call(opts, &out, "tierCaptures", puzzleId, player, tier)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TierCaptures is a free data retrieval call binding the contract method 0x34e7a671.
+//
+// Solidity: function tierCaptures(uint256 puzzleId, address player, uint8 tier) view returns(uint256)
+func (_ZKOnacci *ZKOnacciSession) TierCaptures(puzzleId *big.Int, player common.Address, tier uint8) (*big.Int, error) {
+	return _ZKOnacci.Contract.TierCaptures(&_ZKOnacci.CallOpts, puzzleId, player, tier)
+}
+
+// TierCaptures is a free data retrieval call binding the contract method 0x34e7a671.
+//
+// Solidity: function tierCaptures(uint256 puzzleId, address player, uint8 tier) view returns(uint256)
+func (_ZKOnacci *ZKOnacciCallerSession) TierCaptures(puzzleId *big.Int, player common.Address, tier uint8) (*big.Int, error) {
+	return _ZKOnacci.Contract.TierCaptures(&_ZKOnacci.CallOpts, puzzleId, player, tier)
+}
+
+// TierRewards is a free data retrieval call binding the contract method 0x556c448d.
+//
+// Solidity: function tierRewards(uint256 puzzleId) view returns(uint256[])
+func (_ZKOnacci *ZKOnacciCaller) TierRewards(opts *bind.CallOpts, puzzleId *big.Int) ([]*big.Int, error) {
+	var out []interface{}
+	err := _ZKOnacci.contract.Call(opts, &out, "tierRewards", puzzleId)
+
+	if err != nil {
+		return *new([]*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]*big.Int)).(*[]*big.Int)
+
+	return out0, err
+
+}
+
+// TierRewards is a free data retrieval call binding the contract method 0x556c448d.
+//
+// Solidity: function tierRewards(uint256 puzzleId) view returns(uint256[])
+func (_ZKOnacci *ZKOnacciSession) TierRewards(puzzleId *big.Int) ([]*big.Int, error) {
+	return _ZKOnacci.Contract.TierRewards(&_ZKOnacci.CallOpts, puzzleId)
+}
+
+// TierRewards is a free data retrieval call binding the contract method 0x556c448d.
+//
+// Solidity: function tierRewards(uint256 puzzleId) view returns(uint256[])
+func (_ZKOnacci *ZKOnacciCallerSession) TierRewards(puzzleId *big.Int) ([]*big.Int, error) {
+	return _ZKOnacci.Contract.TierRewards(&_ZKOnacci.CallOpts, puzzleId)
+}
+
+// TokenCounter is a free data retrieval call binding the contract method 0x21bdb140.
+//
+// Solidity: function tokenCounter(uint256 puzzleId) view returns(uint256)
+func (_ZKOnacci *ZKOnacciCaller) TokenCounter(opts *bind.CallOpts, puzzleId *big.Int) (*big.Int, error) {
+	var out []interface{}
+	err := _ZKOnacci.contract.Call(opts, &out, "tokenCounter", puzzleId)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TokenCounter is a free data retrieval call binding the contract method 0x21bdb140.
+//
+// Solidity: function tokenCounter(uint256 puzzleId) view returns(uint256)
+func (_ZKOnacci *ZKOnacciSession) TokenCounter(puzzleId *big.Int) (*big.Int, error) {
+	return _ZKOnacci.Contract.TokenCounter(&_ZKOnacci.CallOpts, puzzleId)
+}
+
+// TokenCounter is a free data retrieval call binding the contract method 0x21bdb140.
+//
+// Solidity: function tokenCounter(uint256 puzzleId) view returns(uint256)
+func (_ZKOnacci *ZKOnacciCallerSession) TokenCounter(puzzleId *big.Int) (*big.Int, error) {
+	return _ZKOnacci.Contract.TokenCounter(&_ZKOnacci.CallOpts, puzzleId)
+}
+
+// TokenTiers is a free data retrieval call binding the contract method 0xecec6211.
+//
+// Solidity: function tokenTiers(uint256 puzzleId, uint256 tier) view returns(uint16)
+func (_ZKOnacci *ZKOnacciCaller) TokenTiers(opts *bind.CallOpts, puzzleId *big.Int, tier *big.Int) (uint16, error) {
+	var out []interface{}
+	err := _ZKOnacci.contract.Call(opts, &out, "tokenTiers", puzzleId, tier)
+
+	if err != nil {
+		return *new(uint16), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint16)).(*uint16)
+
+	return out0, err
+
+}
+
+// TokenTiers is a free data retrieval call binding the contract method 0xecec6211.
+//
+// Solidity: function tokenTiers(uint256 puzzleId, uint256 tier) view returns(uint16)
+func (_ZKOnacci *ZKOnacciSession) TokenTiers(puzzleId *big.Int, tier *big.Int) (uint16, error) {
+	return _ZKOnacci.Contract.TokenTiers(&_ZKOnacci.CallOpts, puzzleId, tier)
+}
+
+// TokenTiers is a free data retrieval call binding the contract method 0xecec6211.
+//
+// Solidity: function tokenTiers(uint256 puzzleId, uint256 tier) view returns(uint16)
+func (_ZKOnacci *ZKOnacciCallerSession) TokenTiers(puzzleId *big.Int, tier *big.Int) (uint16, error) {
+	return _ZKOnacci.Contract.TokenTiers(&_ZKOnacci.CallOpts, puzzleId, tier)
+}
+
+// TokenURI is a free data retrieval call binding the contract method 0xc87b56dd.
+//
+// Solidity: function tokenURI(uint256 tokenId) view returns(string)
+func (_ZKOnacci *ZKOnacciCaller) TokenURI(opts *bind.CallOpts, tokenId *big.Int) (string, error) {
+	var out []interface{}
+	err := _ZKOnacci.contract.Call(opts, &out, "tokenURI", tokenId)
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// TokenURI is a free data retrieval call binding the contract method 0xc87b56dd.
+//
+// Solidity: function tokenURI(uint256 tokenId) view returns(string)
+func (_ZKOnacci *ZKOnacciSession) TokenURI(tokenId *big.Int) (string, error) {
+	return _ZKOnacci.Contract.TokenURI(&_ZKOnacci.CallOpts, tokenId)
+}
+
+// TokenURI is a free data retrieval call binding the contract method 0xc87b56dd.
+//
+// Solidity: function tokenURI(uint256 tokenId) view returns(string)
+func (_ZKOnacci *ZKOnacciCallerSession) TokenURI(tokenId *big.Int) (string, error) {
+	return _ZKOnacci.Contract.TokenURI(&_ZKOnacci.CallOpts, tokenId)
+}
+
+// TokenURIs is a free data retrieval call binding the contract method 0xc96afe89.
+//
+// Solidity: function tokenURIs(uint256 puzzleId, uint256 tier) view returns(string)
+func (_ZKOnacci *ZKOnacciCaller) TokenURIs(opts *bind.CallOpts, puzzleId *big.Int, tier *big.Int) (string, error) {
+	var out []interface{}
+	err := _ZKOnacci.contract.Call(opts, &out, "tokenURIs", puzzleId, tier)
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// TokenURIs is a free data retrieval call binding the contract method 0xc96afe89.
+//
+// Solidity: function tokenURIs(uint256 puzzleId, uint256 tier) view returns(string)
+func (_ZKOnacci *ZKOnacciSession) TokenURIs(puzzleId *big.Int, tier *big.Int) (string, error) {
+	return _ZKOnacci.Contract.TokenURIs(&_ZKOnacci.CallOpts, puzzleId, tier)
+}
+
+// TokenURIs is a free data retrieval call binding the contract method 0xc96afe89.
+//
+// Solidity: function tokenURIs(uint256 puzzleId, uint256 tier) view returns(string)
+func (_ZKOnacci *ZKOnacciCallerSession) TokenURIs(puzzleId *big.Int, tier *big.Int) (string, error) {
+	return _ZKOnacci.Contract.TokenURIs(&_ZKOnacci.CallOpts, puzzleId, tier)
+}
+
+// Verifier is a free data retrieval call binding the contract method 0x8d129178.
+//
+// Solidity: function verifier(uint256 puzzleId) view returns(address)
+func (_ZKOnacci *ZKOnacciCaller) Verifier(opts *bind.CallOpts, puzzleId *big.Int) (common.Address, error) {
+	var out []interface{}
+	err := _ZKOnacci.contract.Call(opts, &out, "verifier", puzzleId)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Verifier is a free data retrieval call binding the contract method 0x8d129178.
+//
+// Solidity: function verifier(uint256 puzzleId) view returns(address)
+func (_ZKOnacci *ZKOnacciSession) Verifier(puzzleId *big.Int) (common.Address, error) {
+	return _ZKOnacci.Contract.Verifier(&_ZKOnacci.CallOpts, puzzleId)
+}
+
+// Verifier is a free data retrieval call binding the contract method 0x8d129178.
+//
+// Solidity: function verifier(uint256 puzzleId) view returns(address)
+func (_ZKOnacci *ZKOnacciCallerSession) Verifier(puzzleId *big.Int) (common.Address, error) {
+	return _ZKOnacci.Contract.Verifier(&_ZKOnacci.CallOpts, puzzleId)
+}
+
+// AddPuzzle is a paid mutator transaction binding the contract method 0x03b0cf13.
+//
+// Solidity: function addPuzzle(address verifierAddr, uint8 _order, uint256 genesisRoot, string _baseURI, uint16[] _tokenTiers, string[] _tokenURIs) returns(uint256)
+func (_ZKOnacci *ZKOnacciTransactor) AddPuzzle(opts *bind.TransactOpts, verifierAddr common.Address, _order uint8, genesisRoot *big.Int, _baseURI string, _tokenTiers []uint16, _tokenURIs []string) (*types.Transaction, error) {
+	return _ZKOnacci.contract.Transact(opts, "addPuzzle", verifierAddr, _order, genesisRoot, _baseURI, _tokenTiers, _tokenURIs)
+}
+
+// AddPuzzle is a paid mutator transaction binding the contract method 0x03b0cf13.
+//
+// Solidity: function addPuzzle(address verifierAddr, uint8 _order, uint256 genesisRoot, string _baseURI, uint16[] _tokenTiers, string[] _tokenURIs) returns(uint256)
+func (_ZKOnacci *ZKOnacciSession) AddPuzzle(verifierAddr common.Address, _order uint8, genesisRoot *big.Int, _baseURI string, _tokenTiers []uint16, _tokenURIs []string) (*types.Transaction, error) {
+	return _ZKOnacci.Contract.AddPuzzle(&_ZKOnacci.TransactOpts, verifierAddr, _order, genesisRoot, _baseURI, _tokenTiers, _tokenURIs)
+}
+
+// AddPuzzle is a paid mutator transaction binding the contract method 0x03b0cf13.
+//
+// Solidity: function addPuzzle(address verifierAddr, uint8 _order, uint256 genesisRoot, string _baseURI, uint16[] _tokenTiers, string[] _tokenURIs) returns(uint256)
+func (_ZKOnacci *ZKOnacciTransactorSession) AddPuzzle(verifierAddr common.Address, _order uint8, genesisRoot *big.Int, _baseURI string, _tokenTiers []uint16, _tokenURIs []string) (*types.Transaction, error) {
+	return _ZKOnacci.Contract.AddPuzzle(&_ZKOnacci.TransactOpts, verifierAddr, _order, genesisRoot, _baseURI, _tokenTiers, _tokenURIs)
+}
+
+// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address to, uint256 tokenId) returns()
+func (_ZKOnacci *ZKOnacciTransactor) Approve(opts *bind.TransactOpts, to common.Address, tokenId *big.Int) (*types.Transaction, error) {
+	return _ZKOnacci.contract.Transact(opts, "approve", to, tokenId)
+}
+
+// Approve is a paid mutator transaction binding the contract method 0x095ea7b3.
+//
+// Solidity: function approve(address to, uint256 tokenId) returns()
 func (_ZKOnacci *ZKOnacciSession) Approve(to common.Address, tokenId *big.Int) (*types.Transaction, error) {
 	return _ZKOnacci.Contract.Approve(&_ZKOnacci.TransactOpts, to, tokenId)
 }
@@ -4929,25 +5545,67 @@ func (_ZKOnacci *ZKOnacciTransactorSession) Approve(to common.Address, tokenId *
 	return _ZKOnacci.Contract.Approve(&_ZKOnacci.TransactOpts, to, tokenId)
 }
 
-// CaptureTheFlag is a paid mutator transaction binding the contract method 0xed4d76e4.
+// CaptureTheFlag is a paid mutator transaction binding the contract method 0x456f2928.
+//
+// Solidity: function captureTheFlag(uint256 puzzleId, uint256[2] proofA, uint256[2][2] proofB, uint256[2] proofC, uint256 nextRoot) returns(uint256)
+func (_ZKOnacci *ZKOnacciTransactor) CaptureTheFlag(opts *bind.TransactOpts, puzzleId *big.Int, proofA [2]*big.Int, proofB [2][2]*big.Int, proofC [2]*big.Int, nextRoot *big.Int) (*types.Transaction, error) {
+	return _ZKOnacci.contract.Transact(opts, "captureTheFlag", puzzleId, proofA, proofB, proofC, nextRoot)
+}
+
+// CaptureTheFlag is a paid mutator transaction binding the contract method 0x456f2928.
+//
+// Solidity: function captureTheFlag(uint256 puzzleId, uint256[2] proofA, uint256[2][2] proofB, uint256[2] proofC, uint256 nextRoot) returns(uint256)
+func (_ZKOnacci *ZKOnacciSession) CaptureTheFlag(puzzleId *big.Int, proofA [2]*big.Int, proofB [2][2]*big.Int, proofC [2]*big.Int, nextRoot *big.Int) (*types.Transaction, error) {
+	return _ZKOnacci.Contract.CaptureTheFlag(&_ZKOnacci.TransactOpts, puzzleId, proofA, proofB, proofC, nextRoot)
+}
+
+// CaptureTheFlag is a paid mutator transaction binding the contract method 0x456f2928.
+//
+// Solidity: function captureTheFlag(uint256 puzzleId, uint256[2] proofA, uint256[2][2] proofB, uint256[2] proofC, uint256 nextRoot) returns(uint256)
+func (_ZKOnacci *ZKOnacciTransactorSession) CaptureTheFlag(puzzleId *big.Int, proofA [2]*big.Int, proofB [2][2]*big.Int, proofC [2]*big.Int, nextRoot *big.Int) (*types.Transaction, error) {
+	return _ZKOnacci.Contract.CaptureTheFlag(&_ZKOnacci.TransactOpts, puzzleId, proofA, proofB, proofC, nextRoot)
+}
+
+// CaptureTheFlagFor is a paid mutator transaction binding the contract method 0xf33c7969.
+//
+// Solidity: function captureTheFlagFor(address recipient, uint256 puzzleId, uint256[2] proofA, uint256[2][2] proofB, uint256[2] proofC, uint256 nextRoot) returns(uint256)
+func (_ZKOnacci *ZKOnacciTransactor) CaptureTheFlagFor(opts *bind.TransactOpts, recipient common.Address, puzzleId *big.Int, proofA [2]*big.Int, proofB [2][2]*big.Int, proofC [2]*big.Int, nextRoot *big.Int) (*types.Transaction, error) {
+	return _ZKOnacci.contract.Transact(opts, "captureTheFlagFor", recipient, puzzleId, proofA, proofB, proofC, nextRoot)
+}
+
+// CaptureTheFlagFor is a paid mutator transaction binding the contract method 0xf33c7969.
+//
+// Solidity: function captureTheFlagFor(address recipient, uint256 puzzleId, uint256[2] proofA, uint256[2][2] proofB, uint256[2] proofC, uint256 nextRoot) returns(uint256)
+func (_ZKOnacci *ZKOnacciSession) CaptureTheFlagFor(recipient common.Address, puzzleId *big.Int, proofA [2]*big.Int, proofB [2][2]*big.Int, proofC [2]*big.Int, nextRoot *big.Int) (*types.Transaction, error) {
+	return _ZKOnacci.Contract.CaptureTheFlagFor(&_ZKOnacci.TransactOpts, recipient, puzzleId, proofA, proofB, proofC, nextRoot)
+}
+
+// CaptureTheFlagFor is a paid mutator transaction binding the contract method 0xf33c7969.
+//
+// Solidity: function captureTheFlagFor(address recipient, uint256 puzzleId, uint256[2] proofA, uint256[2][2] proofB, uint256[2] proofC, uint256 nextRoot) returns(uint256)
+func (_ZKOnacci *ZKOnacciTransactorSession) CaptureTheFlagFor(recipient common.Address, puzzleId *big.Int, proofA [2]*big.Int, proofB [2][2]*big.Int, proofC [2]*big.Int, nextRoot *big.Int) (*types.Transaction, error) {
+	return _ZKOnacci.Contract.CaptureTheFlagFor(&_ZKOnacci.TransactOpts, recipient, puzzleId, proofA, proofB, proofC, nextRoot)
+}
+
+// Deposit is a paid mutator transaction binding the contract method 0xd0e30db0.
 //
-// Solidity: function captureTheFlag(uint256[2] proofA, uint256[2][2] proofB, uint256[2] proofC, uint256 nextRoot) returns(uint256)
-func (_ZKOnacci *ZKOnacciTransactor) CaptureTheFlag(opts *bind.TransactOpts, proofA [2]*big.Int, proofB [2][2]*big.Int, proofC [2]*big.Int, nextRoot *big.Int) (*types.Transaction, error) {
-	return _ZKOnacci.contract.Transact(opts, "captureTheFlag", proofA, proofB, proofC, nextRoot)
+// Solidity: function deposit() payable returns()
+func (_ZKOnacci *ZKOnacciTransactor) Deposit(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ZKOnacci.contract.Transact(opts, "deposit")
 }
 
-// CaptureTheFlag is a paid mutator transaction binding the contract method 0xed4d76e4.
+// Deposit is a paid mutator transaction binding the contract method 0xd0e30db0.
 //
-// Solidity: function captureTheFlag(uint256[2] proofA, uint256[2][2] proofB, uint256[2] proofC, uint256 nextRoot) returns(uint256)
-func (_ZKOnacci *ZKOnacciSession) CaptureTheFlag(proofA [2]*big.Int, proofB [2][2]*big.Int, proofC [2]*big.Int, nextRoot *big.Int) (*types.Transaction, error) {
-	return _ZKOnacci.Contract.CaptureTheFlag(&_ZKOnacci.TransactOpts, proofA, proofB, proofC, nextRoot)
+// Solidity: function deposit() payable returns()
+func (_ZKOnacci *ZKOnacciSession) Deposit() (*types.Transaction, error) {
+	return _ZKOnacci.Contract.Deposit(&_ZKOnacci.TransactOpts)
 }
 
-// CaptureTheFlag is a paid mutator transaction binding the contract method 0xed4d76e4.
+// Deposit is a paid mutator transaction binding the contract method 0xd0e30db0.
 //
-// Solidity: function captureTheFlag(uint256[2] proofA, uint256[2][2] proofB, uint256[2] proofC, uint256 nextRoot) returns(uint256)
-func (_ZKOnacci *ZKOnacciTransactorSession) CaptureTheFlag(proofA [2]*big.Int, proofB [2][2]*big.Int, proofC [2]*big.Int, nextRoot *big.Int) (*types.Transaction, error) {
-	return _ZKOnacci.Contract.CaptureTheFlag(&_ZKOnacci.TransactOpts, proofA, proofB, proofC, nextRoot)
+// Solidity: function deposit() payable returns()
+func (_ZKOnacci *ZKOnacciTransactorSession) Deposit() (*types.Transaction, error) {
+	return _ZKOnacci.Contract.Deposit(&_ZKOnacci.TransactOpts)
 }
 
 // SafeTransferFrom is a paid mutator transaction binding the contract method 0x42842e0e.
@@ -5013,6 +5671,69 @@ func (_ZKOnacci *ZKOnacciTransactorSession) SetApprovalForAll(operator common.Ad
 	return _ZKOnacci.Contract.SetApprovalForAll(&_ZKOnacci.TransactOpts, operator, approved)
 }
 
+// SetCaptureLimits is a paid mutator transaction binding the contract method 0xbdca7c1b.
+//
+// Solidity: function setCaptureLimits(uint256 puzzleId, uint256 _maxCaptures, uint256[] _maxTierCaptures) returns()
+func (_ZKOnacci *ZKOnacciTransactor) SetCaptureLimits(opts *bind.TransactOpts, puzzleId *big.Int, _maxCaptures *big.Int, _maxTierCaptures []*big.Int) (*types.Transaction, error) {
+	return _ZKOnacci.contract.Transact(opts, "setCaptureLimits", puzzleId, _maxCaptures, _maxTierCaptures)
+}
+
+// SetCaptureLimits is a paid mutator transaction binding the contract method 0xbdca7c1b.
+//
+// Solidity: function setCaptureLimits(uint256 puzzleId, uint256 _maxCaptures, uint256[] _maxTierCaptures) returns()
+func (_ZKOnacci *ZKOnacciSession) SetCaptureLimits(puzzleId *big.Int, _maxCaptures *big.Int, _maxTierCaptures []*big.Int) (*types.Transaction, error) {
+	return _ZKOnacci.Contract.SetCaptureLimits(&_ZKOnacci.TransactOpts, puzzleId, _maxCaptures, _maxTierCaptures)
+}
+
+// SetCaptureLimits is a paid mutator transaction binding the contract method 0xbdca7c1b.
+//
+// Solidity: function setCaptureLimits(uint256 puzzleId, uint256 _maxCaptures, uint256[] _maxTierCaptures) returns()
+func (_ZKOnacci *ZKOnacciTransactorSession) SetCaptureLimits(puzzleId *big.Int, _maxCaptures *big.Int, _maxTierCaptures []*big.Int) (*types.Transaction, error) {
+	return _ZKOnacci.Contract.SetCaptureLimits(&_ZKOnacci.TransactOpts, puzzleId, _maxCaptures, _maxTierCaptures)
+}
+
+// SetTierRewards is a paid mutator transaction binding the contract method 0x7ac46b67.
+//
+// Solidity: function setTierRewards(uint256 puzzleId, uint256[] _tierRewards) returns()
+func (_ZKOnacci *ZKOnacciTransactor) SetTierRewards(opts *bind.TransactOpts, puzzleId *big.Int, _tierRewards []*big.Int) (*types.Transaction, error) {
+	return _ZKOnacci.contract.Transact(opts, "setTierRewards", puzzleId, _tierRewards)
+}
+
+// SetTierRewards is a paid mutator transaction binding the contract method 0x7ac46b67.
+//
+// Solidity: function setTierRewards(uint256 puzzleId, uint256[] _tierRewards) returns()
+func (_ZKOnacci *ZKOnacciSession) SetTierRewards(puzzleId *big.Int, _tierRewards []*big.Int) (*types.Transaction, error) {
+	return _ZKOnacci.Contract.SetTierRewards(&_ZKOnacci.TransactOpts, puzzleId, _tierRewards)
+}
+
+// SetTierRewards is a paid mutator transaction binding the contract method 0x7ac46b67.
+//
+// Solidity: function setTierRewards(uint256 puzzleId, uint256[] _tierRewards) returns()
+func (_ZKOnacci *ZKOnacciTransactorSession) SetTierRewards(puzzleId *big.Int, _tierRewards []*big.Int) (*types.Transaction, error) {
+	return _ZKOnacci.Contract.SetTierRewards(&_ZKOnacci.TransactOpts, puzzleId, _tierRewards)
+}
+
+// StartSeason is a paid mutator transaction binding the contract method 0x70a73a45.
+//
+// Solidity: function startSeason(uint256 puzzleId, address verifierAddr, uint8 _order, uint256 genesisRoot, string _baseURI, uint16[] _tokenTiers, string[] _tokenURIs) returns()
+func (_ZKOnacci *ZKOnacciTransactor) StartSeason(opts *bind.TransactOpts, puzzleId *big.Int, verifierAddr common.Address, _order uint8, genesisRoot *big.Int, _baseURI string, _tokenTiers []uint16, _tokenURIs []string) (*types.Transaction, error) {
+	return _ZKOnacci.contract.Transact(opts, "startSeason", puzzleId, verifierAddr, _order, genesisRoot, _baseURI, _tokenTiers, _tokenURIs)
+}
+
+// StartSeason is a paid mutator transaction binding the contract method 0x70a73a45.
+//
+// Solidity: function startSeason(uint256 puzzleId, address verifierAddr, uint8 _order, uint256 genesisRoot, string _baseURI, uint16[] _tokenTiers, string[] _tokenURIs) returns()
+func (_ZKOnacci *ZKOnacciSession) StartSeason(puzzleId *big.Int, verifierAddr common.Address, _order uint8, genesisRoot *big.Int, _baseURI string, _tokenTiers []uint16, _tokenURIs []string) (*types.Transaction, error) {
+	return _ZKOnacci.Contract.StartSeason(&_ZKOnacci.TransactOpts, puzzleId, verifierAddr, _order, genesisRoot, _baseURI, _tokenTiers, _tokenURIs)
+}
+
+// StartSeason is a paid mutator transaction binding the contract method 0x70a73a45.
+//
+// Solidity: function startSeason(uint256 puzzleId, address verifierAddr, uint8 _order, uint256 genesisRoot, string _baseURI, uint16[] _tokenTiers, string[] _tokenURIs) returns()
+func (_ZKOnacci *ZKOnacciTransactorSession) StartSeason(puzzleId *big.Int, verifierAddr common.Address, _order uint8, genesisRoot *big.Int, _baseURI string, _tokenTiers []uint16, _tokenURIs []string) (*types.Transaction, error) {
+	return _ZKOnacci.Contract.StartSeason(&_ZKOnacci.TransactOpts, puzzleId, verifierAddr, _order, genesisRoot, _baseURI, _tokenTiers, _tokenURIs)
+}
+
 // TransferFrom is a paid mutator transaction binding the contract method 0x23b872dd.
 //
 // Solidity: function transferFrom(address from, address to, uint256 tokenId) returns()
@@ -5034,6 +5755,69 @@ func (_ZKOnacci *ZKOnacciTransactorSession) TransferFrom(from common.Address, to
 	return _ZKOnacci.Contract.TransferFrom(&_ZKOnacci.TransactOpts, from, to, tokenId)
 }
 
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_ZKOnacci *ZKOnacciTransactor) TransferOwnership(opts *bind.TransactOpts, newOwner common.Address) (*types.Transaction, error) {
+	return _ZKOnacci.contract.Transact(opts, "transferOwnership", newOwner)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_ZKOnacci *ZKOnacciSession) TransferOwnership(newOwner common.Address) (*types.Transaction, error) {
+	return _ZKOnacci.Contract.TransferOwnership(&_ZKOnacci.TransactOpts, newOwner)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_ZKOnacci *ZKOnacciTransactorSession) TransferOwnership(newOwner common.Address) (*types.Transaction, error) {
+	return _ZKOnacci.Contract.TransferOwnership(&_ZKOnacci.TransactOpts, newOwner)
+}
+
+// Withdraw is a paid mutator transaction binding the contract method 0x3ccfd60b.
+//
+// Solidity: function withdraw() returns()
+func (_ZKOnacci *ZKOnacciTransactor) Withdraw(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ZKOnacci.contract.Transact(opts, "withdraw")
+}
+
+// Withdraw is a paid mutator transaction binding the contract method 0x3ccfd60b.
+//
+// Solidity: function withdraw() returns()
+func (_ZKOnacci *ZKOnacciSession) Withdraw() (*types.Transaction, error) {
+	return _ZKOnacci.Contract.Withdraw(&_ZKOnacci.TransactOpts)
+}
+
+// Withdraw is a paid mutator transaction binding the contract method 0x3ccfd60b.
+//
+// Solidity: function withdraw() returns()
+func (_ZKOnacci *ZKOnacciTransactorSession) Withdraw() (*types.Transaction, error) {
+	return _ZKOnacci.Contract.Withdraw(&_ZKOnacci.TransactOpts)
+}
+
+// Receive is a paid mutator transaction binding the contract receive function.
+//
+// Solidity: receive() payable returns()
+func (_ZKOnacci *ZKOnacciTransactor) Receive(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _ZKOnacci.contract.RawTransact(opts, nil) // calldata is disallowed for receive function
+}
+
+// Receive is a paid mutator transaction binding the contract receive function.
+//
+// Solidity: receive() payable returns()
+func (_ZKOnacci *ZKOnacciSession) Receive() (*types.Transaction, error) {
+	return _ZKOnacci.Contract.Receive(&_ZKOnacci.TransactOpts)
+}
+
+// Receive is a paid mutator transaction binding the contract receive function.
+//
+// Solidity: receive() payable returns()
+func (_ZKOnacci *ZKOnacciTransactorSession) Receive() (*types.Transaction, error) {
+	return _ZKOnacci.Contract.Receive(&_ZKOnacci.TransactOpts)
+}
+
 // ZKOnacciApprovalIterator is returned from FilterApproval and is used to iterate over the raw logs and unpacked data for Approval events raised by the ZKOnacci contract.
 type ZKOnacciApprovalIterator struct {
 	Event *ZKOnacciApproval // Event containing the contract specifics and raw log
@@ -5350,6 +6134,1242 @@ func (_ZKOnacci *ZKOnacciFilterer) ParseApprovalForAll(log types.Log) (*ZKOnacci
 	return event, nil
 }
 
+// ZKOnacciCaptureLimitsSetIterator is returned from FilterCaptureLimitsSet and is used to iterate over the raw logs and unpacked data for CaptureLimitsSet events raised by the ZKOnacci contract.
+type ZKOnacciCaptureLimitsSetIterator struct {
+	Event *ZKOnacciCaptureLimitsSet // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ZKOnacciCaptureLimitsSetIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ZKOnacciCaptureLimitsSet)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ZKOnacciCaptureLimitsSet)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ZKOnacciCaptureLimitsSetIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ZKOnacciCaptureLimitsSetIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ZKOnacciCaptureLimitsSet represents a CaptureLimitsSet event raised by the ZKOnacci contract.
+type ZKOnacciCaptureLimitsSet struct {
+	PuzzleId        *big.Int
+	Season          *big.Int
+	MaxCaptures     *big.Int
+	MaxTierCaptures []*big.Int
+	Raw             types.Log // Blockchain specific contextual infos
+}
+
+// FilterCaptureLimitsSet is a free log retrieval operation binding the contract event 0x26466e2446780ea6dab2cc99c53103d90d168c5d69bab00e5c82970c10cdd1f6.
+//
+// Solidity: event CaptureLimitsSet(uint256 indexed puzzleId, uint256 indexed season, uint256 maxCaptures, uint256[] maxTierCaptures)
+func (_ZKOnacci *ZKOnacciFilterer) FilterCaptureLimitsSet(opts *bind.FilterOpts, puzzleId []*big.Int, season []*big.Int) (*ZKOnacciCaptureLimitsSetIterator, error) {
+
+	var puzzleIdRule []interface{}
+	for _, puzzleIdItem := range puzzleId {
+		puzzleIdRule = append(puzzleIdRule, puzzleIdItem)
+	}
+	var seasonRule []interface{}
+	for _, seasonItem := range season {
+		seasonRule = append(seasonRule, seasonItem)
+	}
+
+	logs, sub, err := _ZKOnacci.contract.FilterLogs(opts, "CaptureLimitsSet", puzzleIdRule, seasonRule)
+	if err != nil {
+		return nil, err
+	}
+	return &ZKOnacciCaptureLimitsSetIterator{contract: _ZKOnacci.contract, event: "CaptureLimitsSet", logs: logs, sub: sub}, nil
+}
+
+// WatchCaptureLimitsSet is a free log subscription operation binding the contract event 0x26466e2446780ea6dab2cc99c53103d90d168c5d69bab00e5c82970c10cdd1f6.
+//
+// Solidity: event CaptureLimitsSet(uint256 indexed puzzleId, uint256 indexed season, uint256 maxCaptures, uint256[] maxTierCaptures)
+func (_ZKOnacci *ZKOnacciFilterer) WatchCaptureLimitsSet(opts *bind.WatchOpts, sink chan<- *ZKOnacciCaptureLimitsSet, puzzleId []*big.Int, season []*big.Int) (event.Subscription, error) {
+
+	var puzzleIdRule []interface{}
+	for _, puzzleIdItem := range puzzleId {
+		puzzleIdRule = append(puzzleIdRule, puzzleIdItem)
+	}
+	var seasonRule []interface{}
+	for _, seasonItem := range season {
+		seasonRule = append(seasonRule, seasonItem)
+	}
+
+	logs, sub, err := _ZKOnacci.contract.WatchLogs(opts, "CaptureLimitsSet", puzzleIdRule, seasonRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ZKOnacciCaptureLimitsSet)
+				if err := _ZKOnacci.contract.UnpackLog(event, "CaptureLimitsSet", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseCaptureLimitsSet is a log parse operation binding the contract event 0x26466e2446780ea6dab2cc99c53103d90d168c5d69bab00e5c82970c10cdd1f6.
+//
+// Solidity: event CaptureLimitsSet(uint256 indexed puzzleId, uint256 indexed season, uint256 maxCaptures, uint256[] maxTierCaptures)
+func (_ZKOnacci *ZKOnacciFilterer) ParseCaptureLimitsSet(log types.Log) (*ZKOnacciCaptureLimitsSet, error) {
+	event := new(ZKOnacciCaptureLimitsSet)
+	if err := _ZKOnacci.contract.UnpackLog(event, "CaptureLimitsSet", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ZKOnacciFlagCapturedIterator is returned from FilterFlagCaptured and is used to iterate over the raw logs and unpacked data for FlagCaptured events raised by the ZKOnacci contract.
+type ZKOnacciFlagCapturedIterator struct {
+	Event *ZKOnacciFlagCaptured // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ZKOnacciFlagCapturedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ZKOnacciFlagCaptured)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ZKOnacciFlagCaptured)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ZKOnacciFlagCapturedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ZKOnacciFlagCapturedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ZKOnacciFlagCaptured represents a FlagCaptured event raised by the ZKOnacci contract.
+type ZKOnacciFlagCaptured struct {
+	Player   common.Address
+	TokenId  *big.Int
+	PuzzleId *big.Int
+	N        *big.Int
+	Tier     uint8
+	OldRoot  *big.Int
+	NewRoot  *big.Int
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterFlagCaptured is a free log retrieval operation binding the contract event 0xbe152ecaf7a007bb0a4533f1fb55748d8e09f66597bb3db940bc65bf6d9d498d.
+//
+// Solidity: event FlagCaptured(address indexed player, uint256 indexed tokenId, uint256 indexed puzzleId, uint256 n, uint8 tier, uint256 oldRoot, uint256 newRoot)
+func (_ZKOnacci *ZKOnacciFilterer) FilterFlagCaptured(opts *bind.FilterOpts, player []common.Address, tokenId []*big.Int, puzzleId []*big.Int) (*ZKOnacciFlagCapturedIterator, error) {
+
+	var playerRule []interface{}
+	for _, playerItem := range player {
+		playerRule = append(playerRule, playerItem)
+	}
+	var tokenIdRule []interface{}
+	for _, tokenIdItem := range tokenId {
+		tokenIdRule = append(tokenIdRule, tokenIdItem)
+	}
+	var puzzleIdRule []interface{}
+	for _, puzzleIdItem := range puzzleId {
+		puzzleIdRule = append(puzzleIdRule, puzzleIdItem)
+	}
+
+	logs, sub, err := _ZKOnacci.contract.FilterLogs(opts, "FlagCaptured", playerRule, tokenIdRule, puzzleIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &ZKOnacciFlagCapturedIterator{contract: _ZKOnacci.contract, event: "FlagCaptured", logs: logs, sub: sub}, nil
+}
+
+// WatchFlagCaptured is a free log subscription operation binding the contract event 0xbe152ecaf7a007bb0a4533f1fb55748d8e09f66597bb3db940bc65bf6d9d498d.
+//
+// Solidity: event FlagCaptured(address indexed player, uint256 indexed tokenId, uint256 indexed puzzleId, uint256 n, uint8 tier, uint256 oldRoot, uint256 newRoot)
+func (_ZKOnacci *ZKOnacciFilterer) WatchFlagCaptured(opts *bind.WatchOpts, sink chan<- *ZKOnacciFlagCaptured, player []common.Address, tokenId []*big.Int, puzzleId []*big.Int) (event.Subscription, error) {
+
+	var playerRule []interface{}
+	for _, playerItem := range player {
+		playerRule = append(playerRule, playerItem)
+	}
+	var tokenIdRule []interface{}
+	for _, tokenIdItem := range tokenId {
+		tokenIdRule = append(tokenIdRule, tokenIdItem)
+	}
+	var puzzleIdRule []interface{}
+	for _, puzzleIdItem := range puzzleId {
+		puzzleIdRule = append(puzzleIdRule, puzzleIdItem)
+	}
+
+	logs, sub, err := _ZKOnacci.contract.WatchLogs(opts, "FlagCaptured", playerRule, tokenIdRule, puzzleIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ZKOnacciFlagCaptured)
+				if err := _ZKOnacci.contract.UnpackLog(event, "FlagCaptured", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseFlagCaptured is a log parse operation binding the contract event 0xbe152ecaf7a007bb0a4533f1fb55748d8e09f66597bb3db940bc65bf6d9d498d.
+//
+// Solidity: event FlagCaptured(address indexed player, uint256 indexed tokenId, uint256 indexed puzzleId, uint256 n, uint8 tier, uint256 oldRoot, uint256 newRoot)
+func (_ZKOnacci *ZKOnacciFilterer) ParseFlagCaptured(log types.Log) (*ZKOnacciFlagCaptured, error) {
+	event := new(ZKOnacciFlagCaptured)
+	if err := _ZKOnacci.contract.UnpackLog(event, "FlagCaptured", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ZKOnacciOwnershipTransferredIterator is returned from FilterOwnershipTransferred and is used to iterate over the raw logs and unpacked data for OwnershipTransferred events raised by the ZKOnacci contract.
+type ZKOnacciOwnershipTransferredIterator struct {
+	Event *ZKOnacciOwnershipTransferred // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ZKOnacciOwnershipTransferredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ZKOnacciOwnershipTransferred)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ZKOnacciOwnershipTransferred)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ZKOnacciOwnershipTransferredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ZKOnacciOwnershipTransferredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ZKOnacciOwnershipTransferred represents a OwnershipTransferred event raised by the ZKOnacci contract.
+type ZKOnacciOwnershipTransferred struct {
+	PreviousOwner common.Address
+	NewOwner      common.Address
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterOwnershipTransferred is a free log retrieval operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_ZKOnacci *ZKOnacciFilterer) FilterOwnershipTransferred(opts *bind.FilterOpts, previousOwner []common.Address, newOwner []common.Address) (*ZKOnacciOwnershipTransferredIterator, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _ZKOnacci.contract.FilterLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &ZKOnacciOwnershipTransferredIterator{contract: _ZKOnacci.contract, event: "OwnershipTransferred", logs: logs, sub: sub}, nil
+}
+
+// WatchOwnershipTransferred is a free log subscription operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_ZKOnacci *ZKOnacciFilterer) WatchOwnershipTransferred(opts *bind.WatchOpts, sink chan<- *ZKOnacciOwnershipTransferred, previousOwner []common.Address, newOwner []common.Address) (event.Subscription, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _ZKOnacci.contract.WatchLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ZKOnacciOwnershipTransferred)
+				if err := _ZKOnacci.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseOwnershipTransferred is a log parse operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_ZKOnacci *ZKOnacciFilterer) ParseOwnershipTransferred(log types.Log) (*ZKOnacciOwnershipTransferred, error) {
+	event := new(ZKOnacciOwnershipTransferred)
+	if err := _ZKOnacci.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ZKOnacciPrizeDepositedIterator is returned from FilterPrizeDeposited and is used to iterate over the raw logs and unpacked data for PrizeDeposited events raised by the ZKOnacci contract.
+type ZKOnacciPrizeDepositedIterator struct {
+	Event *ZKOnacciPrizeDeposited // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ZKOnacciPrizeDepositedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ZKOnacciPrizeDeposited)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ZKOnacciPrizeDeposited)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ZKOnacciPrizeDepositedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ZKOnacciPrizeDepositedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ZKOnacciPrizeDeposited represents a PrizeDeposited event raised by the ZKOnacci contract.
+type ZKOnacciPrizeDeposited struct {
+	Sponsor common.Address
+	Amount  *big.Int
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterPrizeDeposited is a free log retrieval operation binding the contract event 0xf5ca7f2a0fd75407bcd086228127e5b2263fc3d7d75d505a1abaefbdc6fe9851.
+//
+// Solidity: event PrizeDeposited(address indexed sponsor, uint256 amount)
+func (_ZKOnacci *ZKOnacciFilterer) FilterPrizeDeposited(opts *bind.FilterOpts, sponsor []common.Address) (*ZKOnacciPrizeDepositedIterator, error) {
+
+	var sponsorRule []interface{}
+	for _, sponsorItem := range sponsor {
+		sponsorRule = append(sponsorRule, sponsorItem)
+	}
+
+	logs, sub, err := _ZKOnacci.contract.FilterLogs(opts, "PrizeDeposited", sponsorRule)
+	if err != nil {
+		return nil, err
+	}
+	return &ZKOnacciPrizeDepositedIterator{contract: _ZKOnacci.contract, event: "PrizeDeposited", logs: logs, sub: sub}, nil
+}
+
+// WatchPrizeDeposited is a free log subscription operation binding the contract event 0xf5ca7f2a0fd75407bcd086228127e5b2263fc3d7d75d505a1abaefbdc6fe9851.
+//
+// Solidity: event PrizeDeposited(address indexed sponsor, uint256 amount)
+func (_ZKOnacci *ZKOnacciFilterer) WatchPrizeDeposited(opts *bind.WatchOpts, sink chan<- *ZKOnacciPrizeDeposited, sponsor []common.Address) (event.Subscription, error) {
+
+	var sponsorRule []interface{}
+	for _, sponsorItem := range sponsor {
+		sponsorRule = append(sponsorRule, sponsorItem)
+	}
+
+	logs, sub, err := _ZKOnacci.contract.WatchLogs(opts, "PrizeDeposited", sponsorRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ZKOnacciPrizeDeposited)
+				if err := _ZKOnacci.contract.UnpackLog(event, "PrizeDeposited", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParsePrizeDeposited is a log parse operation binding the contract event 0xf5ca7f2a0fd75407bcd086228127e5b2263fc3d7d75d505a1abaefbdc6fe9851.
+//
+// Solidity: event PrizeDeposited(address indexed sponsor, uint256 amount)
+func (_ZKOnacci *ZKOnacciFilterer) ParsePrizeDeposited(log types.Log) (*ZKOnacciPrizeDeposited, error) {
+	event := new(ZKOnacciPrizeDeposited)
+	if err := _ZKOnacci.contract.UnpackLog(event, "PrizeDeposited", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ZKOnacciRewardCreditedIterator is returned from FilterRewardCredited and is used to iterate over the raw logs and unpacked data for RewardCredited events raised by the ZKOnacci contract.
+type ZKOnacciRewardCreditedIterator struct {
+	Event *ZKOnacciRewardCredited // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ZKOnacciRewardCreditedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ZKOnacciRewardCredited)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ZKOnacciRewardCredited)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ZKOnacciRewardCreditedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ZKOnacciRewardCreditedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ZKOnacciRewardCredited represents a RewardCredited event raised by the ZKOnacci contract.
+type ZKOnacciRewardCredited struct {
+	Player   common.Address
+	TokenId  *big.Int
+	PuzzleId *big.Int
+	Amount   *big.Int
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterRewardCredited is a free log retrieval operation binding the contract event 0x1ea36cde5d89f72b939ae977ad3236deca9532580742d662fcce47ee24f51c24.
+//
+// Solidity: event RewardCredited(address indexed player, uint256 indexed tokenId, uint256 indexed puzzleId, uint256 amount)
+func (_ZKOnacci *ZKOnacciFilterer) FilterRewardCredited(opts *bind.FilterOpts, player []common.Address, tokenId []*big.Int, puzzleId []*big.Int) (*ZKOnacciRewardCreditedIterator, error) {
+
+	var playerRule []interface{}
+	for _, playerItem := range player {
+		playerRule = append(playerRule, playerItem)
+	}
+	var tokenIdRule []interface{}
+	for _, tokenIdItem := range tokenId {
+		tokenIdRule = append(tokenIdRule, tokenIdItem)
+	}
+	var puzzleIdRule []interface{}
+	for _, puzzleIdItem := range puzzleId {
+		puzzleIdRule = append(puzzleIdRule, puzzleIdItem)
+	}
+
+	logs, sub, err := _ZKOnacci.contract.FilterLogs(opts, "RewardCredited", playerRule, tokenIdRule, puzzleIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &ZKOnacciRewardCreditedIterator{contract: _ZKOnacci.contract, event: "RewardCredited", logs: logs, sub: sub}, nil
+}
+
+// WatchRewardCredited is a free log subscription operation binding the contract event 0x1ea36cde5d89f72b939ae977ad3236deca9532580742d662fcce47ee24f51c24.
+//
+// Solidity: event RewardCredited(address indexed player, uint256 indexed tokenId, uint256 indexed puzzleId, uint256 amount)
+func (_ZKOnacci *ZKOnacciFilterer) WatchRewardCredited(opts *bind.WatchOpts, sink chan<- *ZKOnacciRewardCredited, player []common.Address, tokenId []*big.Int, puzzleId []*big.Int) (event.Subscription, error) {
+
+	var playerRule []interface{}
+	for _, playerItem := range player {
+		playerRule = append(playerRule, playerItem)
+	}
+	var tokenIdRule []interface{}
+	for _, tokenIdItem := range tokenId {
+		tokenIdRule = append(tokenIdRule, tokenIdItem)
+	}
+	var puzzleIdRule []interface{}
+	for _, puzzleIdItem := range puzzleId {
+		puzzleIdRule = append(puzzleIdRule, puzzleIdItem)
+	}
+
+	logs, sub, err := _ZKOnacci.contract.WatchLogs(opts, "RewardCredited", playerRule, tokenIdRule, puzzleIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ZKOnacciRewardCredited)
+				if err := _ZKOnacci.contract.UnpackLog(event, "RewardCredited", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseRewardCredited is a log parse operation binding the contract event 0x1ea36cde5d89f72b939ae977ad3236deca9532580742d662fcce47ee24f51c24.
+//
+// Solidity: event RewardCredited(address indexed player, uint256 indexed tokenId, uint256 indexed puzzleId, uint256 amount)
+func (_ZKOnacci *ZKOnacciFilterer) ParseRewardCredited(log types.Log) (*ZKOnacciRewardCredited, error) {
+	event := new(ZKOnacciRewardCredited)
+	if err := _ZKOnacci.contract.UnpackLog(event, "RewardCredited", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ZKOnacciRewardWithdrawnIterator is returned from FilterRewardWithdrawn and is used to iterate over the raw logs and unpacked data for RewardWithdrawn events raised by the ZKOnacci contract.
+type ZKOnacciRewardWithdrawnIterator struct {
+	Event *ZKOnacciRewardWithdrawn // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ZKOnacciRewardWithdrawnIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ZKOnacciRewardWithdrawn)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ZKOnacciRewardWithdrawn)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ZKOnacciRewardWithdrawnIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ZKOnacciRewardWithdrawnIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ZKOnacciRewardWithdrawn represents a RewardWithdrawn event raised by the ZKOnacci contract.
+type ZKOnacciRewardWithdrawn struct {
+	Player common.Address
+	Amount *big.Int
+	Raw    types.Log // Blockchain specific contextual infos
+}
+
+// FilterRewardWithdrawn is a free log retrieval operation binding the contract event 0x1d3eee4ca001cff39eec6ec7615aacf2f2bd61791273830728ba00ccbd6e1337.
+//
+// Solidity: event RewardWithdrawn(address indexed player, uint256 amount)
+func (_ZKOnacci *ZKOnacciFilterer) FilterRewardWithdrawn(opts *bind.FilterOpts, player []common.Address) (*ZKOnacciRewardWithdrawnIterator, error) {
+
+	var playerRule []interface{}
+	for _, playerItem := range player {
+		playerRule = append(playerRule, playerItem)
+	}
+
+	logs, sub, err := _ZKOnacci.contract.FilterLogs(opts, "RewardWithdrawn", playerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &ZKOnacciRewardWithdrawnIterator{contract: _ZKOnacci.contract, event: "RewardWithdrawn", logs: logs, sub: sub}, nil
+}
+
+// WatchRewardWithdrawn is a free log subscription operation binding the contract event 0x1d3eee4ca001cff39eec6ec7615aacf2f2bd61791273830728ba00ccbd6e1337.
+//
+// Solidity: event RewardWithdrawn(address indexed player, uint256 amount)
+func (_ZKOnacci *ZKOnacciFilterer) WatchRewardWithdrawn(opts *bind.WatchOpts, sink chan<- *ZKOnacciRewardWithdrawn, player []common.Address) (event.Subscription, error) {
+
+	var playerRule []interface{}
+	for _, playerItem := range player {
+		playerRule = append(playerRule, playerItem)
+	}
+
+	logs, sub, err := _ZKOnacci.contract.WatchLogs(opts, "RewardWithdrawn", playerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ZKOnacciRewardWithdrawn)
+				if err := _ZKOnacci.contract.UnpackLog(event, "RewardWithdrawn", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseRewardWithdrawn is a log parse operation binding the contract event 0x1d3eee4ca001cff39eec6ec7615aacf2f2bd61791273830728ba00ccbd6e1337.
+//
+// Solidity: event RewardWithdrawn(address indexed player, uint256 amount)
+func (_ZKOnacci *ZKOnacciFilterer) ParseRewardWithdrawn(log types.Log) (*ZKOnacciRewardWithdrawn, error) {
+	event := new(ZKOnacciRewardWithdrawn)
+	if err := _ZKOnacci.contract.UnpackLog(event, "RewardWithdrawn", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ZKOnacciSeasonStartedIterator is returned from FilterSeasonStarted and is used to iterate over the raw logs and unpacked data for SeasonStarted events raised by the ZKOnacci contract.
+type ZKOnacciSeasonStartedIterator struct {
+	Event *ZKOnacciSeasonStarted // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ZKOnacciSeasonStartedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ZKOnacciSeasonStarted)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ZKOnacciSeasonStarted)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ZKOnacciSeasonStartedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ZKOnacciSeasonStartedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ZKOnacciSeasonStarted represents a SeasonStarted event raised by the ZKOnacci contract.
+type ZKOnacciSeasonStarted struct {
+	PuzzleId    *big.Int
+	Season      *big.Int
+	GenesisRoot *big.Int
+	Verifier    common.Address
+	Raw         types.Log // Blockchain specific contextual infos
+}
+
+// FilterSeasonStarted is a free log retrieval operation binding the contract event 0x7f4fe728e97c8a56ce861ca6b2c59b85150f43e484b67170219eb9846d9a443f.
+//
+// Solidity: event SeasonStarted(uint256 indexed puzzleId, uint256 indexed season, uint256 genesisRoot, address verifier)
+func (_ZKOnacci *ZKOnacciFilterer) FilterSeasonStarted(opts *bind.FilterOpts, puzzleId []*big.Int, season []*big.Int) (*ZKOnacciSeasonStartedIterator, error) {
+
+	var puzzleIdRule []interface{}
+	for _, puzzleIdItem := range puzzleId {
+		puzzleIdRule = append(puzzleIdRule, puzzleIdItem)
+	}
+	var seasonRule []interface{}
+	for _, seasonItem := range season {
+		seasonRule = append(seasonRule, seasonItem)
+	}
+
+	logs, sub, err := _ZKOnacci.contract.FilterLogs(opts, "SeasonStarted", puzzleIdRule, seasonRule)
+	if err != nil {
+		return nil, err
+	}
+	return &ZKOnacciSeasonStartedIterator{contract: _ZKOnacci.contract, event: "SeasonStarted", logs: logs, sub: sub}, nil
+}
+
+// WatchSeasonStarted is a free log subscription operation binding the contract event 0x7f4fe728e97c8a56ce861ca6b2c59b85150f43e484b67170219eb9846d9a443f.
+//
+// Solidity: event SeasonStarted(uint256 indexed puzzleId, uint256 indexed season, uint256 genesisRoot, address verifier)
+func (_ZKOnacci *ZKOnacciFilterer) WatchSeasonStarted(opts *bind.WatchOpts, sink chan<- *ZKOnacciSeasonStarted, puzzleId []*big.Int, season []*big.Int) (event.Subscription, error) {
+
+	var puzzleIdRule []interface{}
+	for _, puzzleIdItem := range puzzleId {
+		puzzleIdRule = append(puzzleIdRule, puzzleIdItem)
+	}
+	var seasonRule []interface{}
+	for _, seasonItem := range season {
+		seasonRule = append(seasonRule, seasonItem)
+	}
+
+	logs, sub, err := _ZKOnacci.contract.WatchLogs(opts, "SeasonStarted", puzzleIdRule, seasonRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ZKOnacciSeasonStarted)
+				if err := _ZKOnacci.contract.UnpackLog(event, "SeasonStarted", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseSeasonStarted is a log parse operation binding the contract event 0x7f4fe728e97c8a56ce861ca6b2c59b85150f43e484b67170219eb9846d9a443f.
+//
+// Solidity: event SeasonStarted(uint256 indexed puzzleId, uint256 indexed season, uint256 genesisRoot, address verifier)
+func (_ZKOnacci *ZKOnacciFilterer) ParseSeasonStarted(log types.Log) (*ZKOnacciSeasonStarted, error) {
+	event := new(ZKOnacciSeasonStarted)
+	if err := _ZKOnacci.contract.UnpackLog(event, "SeasonStarted", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ZKOnacciTierRewardsSetIterator is returned from FilterTierRewardsSet and is used to iterate over the raw logs and unpacked data for TierRewardsSet events raised by the ZKOnacci contract.
+type ZKOnacciTierRewardsSetIterator struct {
+	Event *ZKOnacciTierRewardsSet // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ZKOnacciTierRewardsSetIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ZKOnacciTierRewardsSet)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ZKOnacciTierRewardsSet)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ZKOnacciTierRewardsSetIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ZKOnacciTierRewardsSetIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ZKOnacciTierRewardsSet represents a TierRewardsSet event raised by the ZKOnacci contract.
+type ZKOnacciTierRewardsSet struct {
+	PuzzleId    *big.Int
+	Season      *big.Int
+	TierRewards []*big.Int
+	Raw         types.Log // Blockchain specific contextual infos
+}
+
+// FilterTierRewardsSet is a free log retrieval operation binding the contract event 0x3b6e0fb4c24ebe672f590fbfc06bcf75cd911e6d0f1dc7705d45a742532a82e4.
+//
+// Solidity: event TierRewardsSet(uint256 indexed puzzleId, uint256 indexed season, uint256[] tierRewards)
+func (_ZKOnacci *ZKOnacciFilterer) FilterTierRewardsSet(opts *bind.FilterOpts, puzzleId []*big.Int, season []*big.Int) (*ZKOnacciTierRewardsSetIterator, error) {
+
+	var puzzleIdRule []interface{}
+	for _, puzzleIdItem := range puzzleId {
+		puzzleIdRule = append(puzzleIdRule, puzzleIdItem)
+	}
+	var seasonRule []interface{}
+	for _, seasonItem := range season {
+		seasonRule = append(seasonRule, seasonItem)
+	}
+
+	logs, sub, err := _ZKOnacci.contract.FilterLogs(opts, "TierRewardsSet", puzzleIdRule, seasonRule)
+	if err != nil {
+		return nil, err
+	}
+	return &ZKOnacciTierRewardsSetIterator{contract: _ZKOnacci.contract, event: "TierRewardsSet", logs: logs, sub: sub}, nil
+}
+
+// WatchTierRewardsSet is a free log subscription operation binding the contract event 0x3b6e0fb4c24ebe672f590fbfc06bcf75cd911e6d0f1dc7705d45a742532a82e4.
+//
+// Solidity: event TierRewardsSet(uint256 indexed puzzleId, uint256 indexed season, uint256[] tierRewards)
+func (_ZKOnacci *ZKOnacciFilterer) WatchTierRewardsSet(opts *bind.WatchOpts, sink chan<- *ZKOnacciTierRewardsSet, puzzleId []*big.Int, season []*big.Int) (event.Subscription, error) {
+
+	var puzzleIdRule []interface{}
+	for _, puzzleIdItem := range puzzleId {
+		puzzleIdRule = append(puzzleIdRule, puzzleIdItem)
+	}
+	var seasonRule []interface{}
+	for _, seasonItem := range season {
+		seasonRule = append(seasonRule, seasonItem)
+	}
+
+	logs, sub, err := _ZKOnacci.contract.WatchLogs(opts, "TierRewardsSet", puzzleIdRule, seasonRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ZKOnacciTierRewardsSet)
+				if err := _ZKOnacci.contract.UnpackLog(event, "TierRewardsSet", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseTierRewardsSet is a log parse operation binding the contract event 0x3b6e0fb4c24ebe672f590fbfc06bcf75cd911e6d0f1dc7705d45a742532a82e4.
+//
+// Solidity: event TierRewardsSet(uint256 indexed puzzleId, uint256 indexed season, uint256[] tierRewards)
+func (_ZKOnacci *ZKOnacciFilterer) ParseTierRewardsSet(log types.Log) (*ZKOnacciTierRewardsSet, error) {
+	event := new(ZKOnacciTierRewardsSet)
+	if err := _ZKOnacci.contract.UnpackLog(event, "TierRewardsSet", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
 // ZKOnacciTransferIterator is returned from FilterTransfer and is used to iterate over the raw logs and unpacked data for Transfer events raised by the ZKOnacci contract.
 type ZKOnacciTransferIterator struct {
 	Event *ZKOnacciTransfer // Event containing the contract specifics and raw log
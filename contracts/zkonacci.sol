@@ -4,72 +4,451 @@ import * as _verifier from "./verifier.sol";
 import "../node_modules/@openzeppelin/contracts/token/ERC721/ERC721.sol";
 
 contract ZKOnacci is ERC721 {
-    // Circuit
-    uint256 public root;
-    // NFT metadata
-    string constant public baseURI = "https://ipfs.io/ipfs/";
-    uint256 public tokenCounter;
-    uint8 constant public nTiers = 4;
-    uint16[nTiers] public tokenTiers = [2, 4, 8, 16];
-    string[nTiers] public tokenURIs = [
-        "bafkreignwngx3twej6cdn26hyaet3gg7scrtpgafkqjnkqtv37a2r6qf4u", // 3 copies (0,1,2)
-        "bafkreieltrnt62cd4pxcvbygjo6whdtnzlqzkmp2snvhn34dmy63aj5unq", // 2 copies (3,4)
-        "bafkreif3oub75tmg2qyh2mzsvyqeyxac7xfjy5itrb6rle7yxb6wo56jji", // 4 copies (5,6,7,8)
-        "bafkreif5kgo5c2pool3s5bvnjo7gsxast45yioagh6tfhxfp5aae67ut6m"  // 8 copies (9,10,...,16)
-    ];
-    _verifier.Verifier private verifier;
-
-    constructor(address verifierAddr) public ERC721 ("zkOnacci", "ZKO"){
-        // TODO: consider starting with the 3 firdt numbers [0,1,1]
-        // Set the first two numbers of the sequence [0, 1]
-        tokenCounter = 0;
-        root = 19733998167332688543494136895553318319796515049857122158390636597337826955912;
-        verifier = _verifier.Verifier(verifierAddr);
+    // The contract is a registry of puzzles, each of them with its own verifier, tree and tiers. The puzzle 0 is
+    // set up by the constructor and the owner can add more. Each season is a new round of a puzzle, with its own
+    // genesis root, tiers and (optionally) verifier.
+    // Token IDs encode the puzzle and the season:
+    // (puzzle << PUZZLE_SHIFT) | (season << SEASON_SHIFT) | index of the token within the season
+    uint256 public constant SEASON_SHIFT = 16;
+    uint256 public constant PUZZLE_SHIFT = 32;
+    // Order of the sequence of the zkOnacci circuit (fibonacci), the one of the puzzle 0 set up by the constructor
+    uint8 public constant ZKONACCI_ORDER = 2;
+
+    struct Season {
+        uint256 genesisRoot;
+        // NFT metadata
+        string baseURI;
+        // Last token index of each tier, strictly increasing
+        uint16[] tokenTiers;
+        // URI of each tier, appended to baseURI
+        string[] tokenURIs;
+        address verifier;
+        // Amount of numbers of the sequence proven by the verifier that are in the genesis tree
+        uint8 order;
+        // Max captures of a single address on the season, overall and on each tier (0 = no limit)
+        uint256 maxCaptures;
+        uint256[] maxTierCaptures;
+        mapping(address => uint256) captures;
+        mapping(address => mapping(uint8 => uint256)) tierCaptures;
+        // Reward (in wei) credited from the prize pool for a token of each tier
+        uint256[] tierRewards;
+    }
+
+    struct Puzzle {
+        // Circuit
+        uint256 root;
+        // Tokens minted on the current season
+        uint256 tokenCounter;
+        uint256 currentSeason;
+        Season[] seasons;
+    }
+
+    address public owner;
+    Puzzle[] private puzzles;
+    // Funds deposited by the sponsors that haven't been credited to any player yet
+    uint256 public prizePool;
+    // Rewards credited to each player and not withdrawn yet
+    mapping(address => uint256) public rewards;
+
+    // Emitted on every capture: n is the position of the sequence that has been proven,
+    // and oldRoot / newRoot the roots of the tree of the puzzle before and after adding it
+    event FlagCaptured(
+        address indexed player,
+        uint256 indexed tokenId,
+        uint256 indexed puzzleId,
+        uint256 n,
+        uint8 tier,
+        uint256 oldRoot,
+        uint256 newRoot
+    );
+    event SeasonStarted(uint256 indexed puzzleId, uint256 indexed season, uint256 genesisRoot, address verifier);
+    event CaptureLimitsSet(uint256 indexed puzzleId, uint256 indexed season, uint256 maxCaptures, uint256[] maxTierCaptures);
+    event TierRewardsSet(uint256 indexed puzzleId, uint256 indexed season, uint256[] tierRewards);
+    event PrizeDeposited(address indexed sponsor, uint256 amount);
+    // amount can be lower than the reward of the tier if the prize pool runs out of funds
+    event RewardCredited(address indexed player, uint256 indexed tokenId, uint256 indexed puzzleId, uint256 amount);
+    event RewardWithdrawn(address indexed player, uint256 amount);
+    event OwnershipTransferred(address indexed previousOwner, address indexed newOwner);
+
+    modifier onlyOwner() {
+        require(msg.sender == owner, "ZKOnacci: NOT_OWNER");
+        _;
+    }
+
+    // The owner is a param (instead of the sender) so the contract can be deployed through a factory
+    constructor(
+            address verifierAddr,
+            uint256 genesisRoot,
+            string memory _baseURI,
+            uint16[] memory _tokenTiers,
+            string[] memory _tokenURIs,
+            address _owner
+    ) public ERC721 ("zkOnacci", "ZKO"){
+        require(_owner != address(0), "ZKOnacci::constructor: INVALID_OWNER");
+        owner = _owner;
+        emit OwnershipTransferred(address(0), _owner);
+        _addPuzzle(verifierAddr, ZKONACCI_ORDER, genesisRoot, _baseURI, _tokenTiers, _tokenURIs);
+    }
+
+    // Registers a new puzzle, starting its first season. order is the amount of numbers of the sequence proven by
+    // the verifier that are in the genesis tree. Returns the ID of the puzzle
+    function addPuzzle(
+            address verifierAddr,
+            uint8 _order,
+            uint256 genesisRoot,
+            string memory _baseURI,
+            uint16[] memory _tokenTiers,
+            string[] memory _tokenURIs
+    ) public onlyOwner returns (uint256) {
+        require(verifierAddr != address(0), "ZKOnacci::addPuzzle: INVALID_VERIFIER");
+        return _addPuzzle(verifierAddr, _order, genesisRoot, _baseURI, _tokenTiers, _tokenURIs);
+    }
+
+    function _addPuzzle(
+            address verifierAddr,
+            uint8 _order,
+            uint256 genesisRoot,
+            string memory _baseURI,
+            uint16[] memory _tokenTiers,
+            string[] memory _tokenURIs
+    ) private returns (uint256) {
+        puzzles.push();
+        uint256 puzzleId = puzzles.length - 1;
+        _startSeason(puzzleId, verifierAddr, _order, genesisRoot, _baseURI, _tokenTiers, _tokenURIs);
+        return puzzleId;
+    }
+
+    // Starts a new season of a puzzle once all the tokens of its current season have been minted. The verifier and
+    // its order are kept if verifierAddr is zero. The tokens of the previous seasons keep their metadata
+    function startSeason(
+            uint256 puzzleId,
+            address verifierAddr,
+            uint8 _order,
+            uint256 genesisRoot,
+            string memory _baseURI,
+            uint16[] memory _tokenTiers,
+            string[] memory _tokenURIs
+    ) public onlyOwner {
+        Puzzle storage p = _puzzle(puzzleId);
+        Season storage current = p.seasons[p.currentSeason];
+        require(
+            p.tokenCounter > current.tokenTiers[current.tokenTiers.length - 1],
+            "ZKOnacci::startSeason: SEASON_NOT_FINISHED"
+        );
+        require(p.currentSeason + 1 < (1 << (PUZZLE_SHIFT - SEASON_SHIFT)), "ZKOnacci::startSeason: TOO_MANY_SEASONS");
+        if (verifierAddr == address(0)) {
+            verifierAddr = current.verifier;
+            _order = current.order;
+        }
+        p.currentSeason++;
+        _startSeason(puzzleId, verifierAddr, _order, genesisRoot, _baseURI, _tokenTiers, _tokenURIs);
+    }
+
+    function _startSeason(
+            uint256 puzzleId,
+            address verifierAddr,
+            uint8 _order,
+            uint256 genesisRoot,
+            string memory _baseURI,
+            uint16[] memory _tokenTiers,
+            string[] memory _tokenURIs
+    ) private {
+        require(_order > 0, "ZKOnacci: INVALID_ORDER");
+        require(
+            _tokenTiers.length > 0 && _tokenTiers.length <= type(uint8).max,
+            "ZKOnacci: INVALID_TIERS_LENGTH"
+        );
+        require(
+            _tokenURIs.length == _tokenTiers.length,
+            "ZKOnacci: TIERS_URIS_LENGTH_MISMATCH"
+        );
+        for (uint256 i = 1; i < _tokenTiers.length; i++) {
+            require(
+                _tokenTiers[i] > _tokenTiers[i-1],
+                "ZKOnacci: TIERS_NOT_INCREASING"
+            );
+        }
+        Puzzle storage p = puzzles[puzzleId];
+        // Root of the tree with the first numbers of the sequence (e.g. [0, 1])
+        p.root = genesisRoot;
+        p.tokenCounter = 0;
+        Season storage s = p.seasons.push();
+        s.genesisRoot = genesisRoot;
+        s.baseURI = _baseURI;
+        s.tokenTiers = _tokenTiers;
+        s.tokenURIs = _tokenURIs;
+        s.verifier = verifierAddr;
+        s.order = _order;
+        emit SeasonStarted(puzzleId, p.currentSeason, genesisRoot, verifierAddr);
+    }
+
+    // Limits the captures of each address on the current season of a puzzle, overall and on each tier
+    // (0 = no limit). maxTierCaptures is either empty (no tier limits) or has a limit for every tier
+    function setCaptureLimits(
+            uint256 puzzleId,
+            uint256 _maxCaptures,
+            uint256[] memory _maxTierCaptures
+    ) public onlyOwner {
+        Puzzle storage p = _puzzle(puzzleId);
+        Season storage s = p.seasons[p.currentSeason];
+        require(
+            _maxTierCaptures.length == 0 || _maxTierCaptures.length == s.tokenTiers.length,
+            "ZKOnacci::setCaptureLimits: TIERS_LENGTH_MISMATCH"
+        );
+        s.maxCaptures = _maxCaptures;
+        s.maxTierCaptures = _maxTierCaptures;
+        emit CaptureLimitsSet(puzzleId, p.currentSeason, _maxCaptures, _maxTierCaptures);
+    }
+
+    // Sets the reward of each tier of the current season of a puzzle, either one per tier or none (no rewards)
+    function setTierRewards(uint256 puzzleId, uint256[] memory _tierRewards) public onlyOwner {
+        Puzzle storage p = _puzzle(puzzleId);
+        Season storage s = p.seasons[p.currentSeason];
+        require(
+            _tierRewards.length == 0 || _tierRewards.length == s.tokenTiers.length,
+            "ZKOnacci::setTierRewards: TIERS_LENGTH_MISMATCH"
+        );
+        s.tierRewards = _tierRewards;
+        emit TierRewardsSet(puzzleId, p.currentSeason, _tierRewards);
+    }
+
+    // Adds the sent funds to the prize pool
+    function deposit() public payable {
+        prizePool += msg.value;
+        emit PrizeDeposited(msg.sender, msg.value);
+    }
+
+    receive() external payable {
+        deposit();
+    }
+
+    // Sends the credited rewards to the sender. The credit is cleared before the transfer, so reentering can't withdraw twice
+    function withdraw() public {
+        uint256 amount = rewards[msg.sender];
+        require(amount > 0, "ZKOnacci::withdraw: NO_REWARDS");
+        rewards[msg.sender] = 0;
+        (bool sent, ) = msg.sender.call{value: amount}("");
+        require(sent, "ZKOnacci::withdraw: TRANSFER_FAILED");
+        emit RewardWithdrawn(msg.sender, amount);
+    }
+
+    function transferOwnership(address newOwner) public onlyOwner {
+        require(newOwner != address(0), "ZKOnacci::transferOwnership: INVALID_OWNER");
+        emit OwnershipTransferred(owner, newOwner);
+        owner = newOwner;
+    }
+
+    function _puzzle(uint256 puzzleId) private view returns (Puzzle storage) {
+        require(puzzleId < puzzles.length, "ZKOnacci: INVALID_PUZZLE");
+        return puzzles[puzzleId];
+    }
+
+    function _currentSeason(uint256 puzzleId) private view returns (Season storage) {
+        Puzzle storage p = _puzzle(puzzleId);
+        return p.seasons[p.currentSeason];
+    }
+
+    function nPuzzles() public view returns (uint256) {
+        return puzzles.length;
+    }
+
+    // State of a puzzle
+    function root(uint256 puzzleId) public view returns (uint256) {
+        return _puzzle(puzzleId).root;
+    }
+
+    function tokenCounter(uint256 puzzleId) public view returns (uint256) {
+        return _puzzle(puzzleId).tokenCounter;
+    }
+
+    function currentSeason(uint256 puzzleId) public view returns (uint256) {
+        return _puzzle(puzzleId).currentSeason;
+    }
+
+    // Settings of the current season of a puzzle
+    function verifier(uint256 puzzleId) public view returns (address) {
+        return _currentSeason(puzzleId).verifier;
+    }
+
+    function order(uint256 puzzleId) public view returns (uint8) {
+        return _currentSeason(puzzleId).order;
+    }
+
+    function baseURI(uint256 puzzleId) public view returns (string memory) {
+        return _currentSeason(puzzleId).baseURI;
+    }
+
+    function nTiers(uint256 puzzleId) public view returns (uint8) {
+        return uint8(_currentSeason(puzzleId).tokenTiers.length);
+    }
+
+    function tokenTiers(uint256 puzzleId, uint256 tier) public view returns (uint16) {
+        return _currentSeason(puzzleId).tokenTiers[tier];
+    }
+
+    function tokenURIs(uint256 puzzleId, uint256 tier) public view returns (string memory) {
+        return _currentSeason(puzzleId).tokenURIs[tier];
+    }
+
+    function captureLimits(uint256 puzzleId) public view returns (uint256 maxCaptures, uint256[] memory maxTierCaptures) {
+        Season storage s = _currentSeason(puzzleId);
+        return (s.maxCaptures, s.maxTierCaptures);
+    }
+
+    function tierRewards(uint256 puzzleId) public view returns (uint256[] memory) {
+        return _currentSeason(puzzleId).tierRewards;
+    }
+
+    // Captures of a player on the current season of a puzzle
+    function captures(uint256 puzzleId, address player) public view returns (uint256) {
+        return _currentSeason(puzzleId).captures[player];
+    }
+
+    function tierCaptures(uint256 puzzleId, address player, uint8 tier) public view returns (uint256) {
+        return _currentSeason(puzzleId).tierCaptures[player][tier];
+    }
+
+    // Settings of a season of a puzzle, including the finished ones
+    function season(uint256 puzzleId, uint256 seasonId) public view returns (
+            uint256 genesisRoot,
+            string memory seasonBaseURI,
+            uint16[] memory seasonTokenTiers,
+            string[] memory seasonTokenURIs,
+            address verifierAddr
+    ) {
+        Season storage s = _puzzle(puzzleId).seasons[seasonId];
+        return (s.genesisRoot, s.baseURI, s.tokenTiers, s.tokenURIs, s.verifier);
+    }
+
+    function puzzleOf(uint256 tokenId) public pure returns (uint256) {
+        return tokenId >> PUZZLE_SHIFT;
+    }
+
+    function seasonOf(uint256 tokenId) public pure returns (uint256) {
+        return (tokenId >> SEASON_SHIFT) & ((1 << (PUZZLE_SHIFT - SEASON_SHIFT)) - 1);
     }
 
     function captureTheFlag (
+            uint256 puzzleId,
             uint[2] memory proofA,
             uint[2][2] memory proofB,
             uint[2] memory proofC,
             uint256 nextRoot
     ) public returns (uint256) {
-        // Check if all tokens have been minted
+        return _captureTheFlag(msg.sender, puzzleId, proofA, proofB, proofC, nextRoot);
+    }
+
+    // Capture the flag on behalf of recipient (e.g. a relayer paying the gas for a player).
+    // The proof must be generated with recipient as senderInput, so the tx sender can't redirect the NFT
+    function captureTheFlagFor (
+            address recipient,
+            uint256 puzzleId,
+            uint[2] memory proofA,
+            uint[2][2] memory proofB,
+            uint[2] memory proofC,
+            uint256 nextRoot
+    ) public returns (uint256) {
+        return _captureTheFlag(recipient, puzzleId, proofA, proofB, proofC, nextRoot);
+    }
+
+    function _captureTheFlag (
+            address recipient,
+            uint256 puzzleId,
+            uint[2] memory proofA,
+            uint[2][2] memory proofB,
+            uint[2] memory proofC,
+            uint256 nextRoot
+    ) private returns (uint256) {
+        Puzzle storage p = _puzzle(puzzleId);
+        // Check if all tokens of the season have been minted
+        Season storage s = p.seasons[p.currentSeason];
         require(
-            tokenCounter <= tokenTiers[nTiers-1],
+            p.tokenCounter <= s.tokenTiers[s.tokenTiers.length - 1],
             "ZKOnacci::captureTheFlag: ALL_TOKENS_MINTED"
         );
+        // The limits apply to the recipient, which gets the NFT
+        checkCaptureLimits(s, recipient, tierOf(s.tokenTiers, p.tokenCounter));
         // Verify proof
         require(
-            verifier.verifyProof(
+            _verifier.Verifier(s.verifier).verifyProof(
                 proofA, proofB, proofC,
                 [
-                    uint256(uint160(msg.sender)),
-                    root,
+                    uint256(uint160(recipient)),
+                    p.root,
                     nextRoot
                 ]
             ) == true,
             "ZKOnacci::captureTheFlag: INVALID_ZK_PROOF"
         );
-        // Mint NFT
-        mintNFT();
-        root = nextRoot;
+        return _mintFlag(recipient, puzzleId, p, s, nextRoot);
     }
 
-    function mintNFT() private returns (uint256) {
-        uint256 newItemId = tokenCounter;
-        _safeMint(msg.sender, newItemId);
-        tokenCounter++;
-        return newItemId;
+    function checkCaptureLimits(Season storage s, address player, uint8 tier) private view {
+        require(
+            s.maxCaptures == 0 || s.captures[player] < s.maxCaptures,
+            "ZKOnacci::captureTheFlag: CAPTURE_LIMIT_REACHED"
+        );
+        require(
+            s.maxTierCaptures.length == 0 || s.maxTierCaptures[tier] == 0 ||
+                s.tierCaptures[player][tier] < s.maxTierCaptures[tier],
+            "ZKOnacci::captureTheFlag: TIER_CAPTURE_LIMIT_REACHED"
+        );
     }
 
-    function tokenURI(uint256 tokenId) public view virtual override returns (string memory) {
-        require(_exists(tokenId), "ERC721Metadata: URI query for nonexistent token");
+    function _mintFlag(
+            address recipient,
+            uint256 puzzleId,
+            Puzzle storage p,
+            Season storage s,
+            uint256 nextRoot
+    ) private returns (uint256) {
+        // Update the root and the captures before minting, as _safeMint calls the recipient
+        uint256 oldRoot = p.root;
+        p.root = nextRoot;
+        uint256 index = p.tokenCounter;
+        uint8 tier = tierOf(s.tokenTiers, index);
+        // The first order numbers of the sequence are in the genesis tree, so the token i of the season proves n = i + order
+        uint256 tokenId = (puzzleId << PUZZLE_SHIFT) | (p.currentSeason << SEASON_SHIFT) | index;
+        emit FlagCaptured(recipient, tokenId, puzzleId, index + s.order, tier, oldRoot, nextRoot);
+        // Mint NFT
+        p.tokenCounter++;
+        s.captures[recipient]++;
+        s.tierCaptures[recipient][tier]++;
+        creditReward(recipient, puzzleId, tokenId, s, tier);
+        _safeMint(recipient, tokenId);
+        return tokenId;
+    }
 
-        // NFTs have different URIs according to how many of them had been minted when they where created.
+    // Credits the reward of the tier to the player, as far as the prize pool allows
+    function creditReward(address player, uint256 puzzleId, uint256 tokenId, Season storage s, uint8 tier) private {
+        if (s.tierRewards.length == 0) {
+            return;
+        }
+        uint256 amount = s.tierRewards[tier];
+        if (amount > prizePool) {
+            amount = prizePool;
+        }
+        if (amount == 0) {
+            return;
+        }
+        prizePool -= amount;
+        rewards[player] += amount;
+        emit RewardCredited(player, tokenId, puzzleId, amount);
+    }
+
+    // NFTs have different tiers according to how many of them had been minted on their season when they where created.
+    function tierOf(uint16[] storage seasonTokenTiers, uint256 index) private view returns (uint8) {
         uint8 tokenTierIndex = 0;
-        while (tokenTierIndex < tokenTiers.length - 1 && tokenId > tokenTiers[tokenTierIndex]) {
+        while (tokenTierIndex < seasonTokenTiers.length - 1 && index > seasonTokenTiers[tokenTierIndex]) {
             tokenTierIndex++;
         }
-        return string(abi.encodePacked(baseURI, tokenURIs[tokenTierIndex]));
+        return tokenTierIndex;
+    }
+
+    function tokenURI(uint256 tokenId) public view virtual override returns (string memory) {
+        require(_exists(tokenId), "ERC721Metadata: URI query for nonexistent token");
+        Season storage s = puzzles[puzzleOf(tokenId)].seasons[seasonOf(tokenId)];
+        uint256 index = tokenId & ((1 << SEASON_SHIFT) - 1);
+        return string(abi.encodePacked(s.baseURI, s.tokenURIs[tierOf(s.tokenTiers, index)]));
     }
-}
\ No newline at end of file
+}
@@ -28,7 +28,41 @@ type testingEnv struct {
 	provingKey *types.Pk
 }
 
-func newTestingEnv() (testingEnv, error) {
+// tierConfig holds the NFT params of the constructor
+type tierConfig struct {
+	name       string
+	baseURI    string
+	tokenTiers []uint16
+	tokenURIs  []string
+}
+
+var tierConfigs = []tierConfig{
+	{
+		name:       "default",
+		baseURI:    "https://ipfs.io/ipfs/",
+		tokenTiers: []uint16{2, 4, 8, 16},
+		tokenURIs: []string{
+			"bafkreignwngx3twej6cdn26hyaet3gg7scrtpgafkqjnkqtv37a2r6qf4u",
+			"bafkreieltrnt62cd4pxcvbygjo6whdtnzlqzkmp2snvhn34dmy63aj5unq",
+			"bafkreif3oub75tmg2qyh2mzsvyqeyxac7xfjy5itrb6rle7yxb6wo56jji",
+			"bafkreif5kgo5c2pool3s5bvnjo7gsxast45yioagh6tfhxfp5aae67ut6m",
+		},
+	},
+	{
+		name:       "single tier",
+		baseURI:    "ipfs://",
+		tokenTiers: []uint16{0},
+		tokenURIs:  []string{"single"},
+	},
+	{
+		name:       "uneven tiers",
+		baseURI:    "https://example.com/",
+		tokenTiers: []uint16{1, 2, 6},
+		tokenURIs:  []string{"first", "second", "third"},
+	},
+}
+
+func newTestingEnv(genesisRoot *big.Int, tiers tierConfig) (testingEnv, error) {
 	balance := big.NewInt(0)
 	balance.SetString("10000000000000000000000000", 10) // 10 ETH in wei
 	privateKey, err := crypto.GenerateKey()
@@ -62,6 +96,11 @@ func newTestingEnv() (testingEnv, error) {
 		auth,
 		client,
 		verifierAddr,
+		genesisRoot,
+		tiers.baseURI,
+		tiers.tokenTiers,
+		tiers.tokenURIs,
+		auth.From,
 	)
 	if err != nil {
 		return testingEnv{}, err
@@ -79,32 +118,41 @@ func newTestingEnv() (testingEnv, error) {
 const nLevels = 6
 
 func TestMintNFT(t *testing.T) {
+	for _, tiers := range tierConfigs {
+		tiers := tiers
+		t.Run(tiers.name, func(t *testing.T) {
+			testMintNFT(t, tiers)
+		})
+	}
+}
+
+func testMintNFT(t *testing.T, tiers tierConfig) {
+	// Calculate initial state
+	merkleTree := genesisTree(t)
 	// Set up testing environment
-	testEnv, err := newTestingEnv()
+	testEnv, err := newTestingEnv(merkleTree.Root().BigInt(), tiers)
 	require.NoError(t, err)
 	callOpts := &bind.CallOpts{}
+	puzzle := big.NewInt(0)
 	// Get tokenURIs by tier
-	nTiers, err := testEnv.zkOnacci.NTiers(callOpts)
+	nTiers, err := testEnv.zkOnacci.NTiers(callOpts, puzzle)
 	require.NoError(t, err)
-	baseURI, err := testEnv.zkOnacci.BaseURI(callOpts)
+	baseURI, err := testEnv.zkOnacci.BaseURI(callOpts, puzzle)
 	require.NoError(t, err)
 	tokenTiers := []uint16{}
 	tokenURIs := []string{}
 	for i := 0; i < int(nTiers); i++ {
 		// Tier
-		iTier, err := testEnv.zkOnacci.TokenTiers(callOpts, big.NewInt(int64(i)))
+		iTier, err := testEnv.zkOnacci.TokenTiers(callOpts, puzzle, big.NewInt(int64(i)))
 		require.NoError(t, err)
 		tokenTiers = append(tokenTiers, iTier)
 		// URI
-		iURI, err := testEnv.zkOnacci.TokenURIs(callOpts, big.NewInt(int64(i)))
+		iURI, err := testEnv.zkOnacci.TokenURIs(callOpts, puzzle, big.NewInt(int64(i)))
 		require.NoError(t, err)
 		tokenURIs = append(tokenURIs, baseURI+iURI)
 	}
-	// Calculate initial state
-	merkleTree, err := merkletree.NewMerkleTree(memory.NewMemoryStorage(), nLevels)
-	require.NoError(t, err)
-	require.NoError(t, merkleTree.Add(big.NewInt(0), big.NewInt(0)))
-	require.NoError(t, merkleTree.Add(big.NewInt(1), big.NewInt(1)))
+	assert.Equal(t, tiers.tokenTiers, tokenTiers)
+	assert.Equal(t, len(tiers.tokenURIs), len(tokenURIs))
 	// Mint all tokens +1 (to test that the supply is limited as expected)
 	var n uint16 = 2
 	maxTier := tokenTiers[len(tokenTiers)-1]
@@ -112,37 +160,15 @@ func TestMintNFT(t *testing.T) {
 	FnMinTwo := 0
 	for n < maxTier+2 {
 		fmt.Printf("Minting NFT #%d, nMinusOne = %d, nMinusTwo = %d, nFib = %d\n", n, FnMinOne, FnMinTwo, FnMinOne+FnMinTwo)
-		// Generate proof
-		// Existence proofs for Fn-1 and Fn-2 BEFORE processing Fn
 		oldRoot := merkleTree.Root()
-		mtpNMinOne, err := merkleTree.GenerateCircomVerifierProof(big.NewInt(int64(n-1)), nil)
-		require.NoError(t, err)
-		mtpNMinTwo, err := merkleTree.GenerateCircomVerifierProof(big.NewInt(int64(n-2)), nil)
-		require.NoError(t, err)
-		// Add Fn and get processing proof
-		mtpN, err := merkleTree.AddAndGetCircomProof(big.NewInt(int64(n)), big.NewInt(int64(FnMinOne+FnMinTwo)))
-		require.NoError(t, err)
-		proofA, proofB, proofC, err := zkinputs.GenerateProof(zkinputs.ZKInput{
-			Sender:           testEnv.auth.From,
-			Root:             oldRoot,
-			N:                int(n),
-			Fn:               FnMinOne + FnMinTwo,
-			SiblingsFn:       mtpN.Siblings,
-			OldKeyFn:         mtpN.OldKey,
-			OldValueFn:       mtpN.OldValue,
-			IsOld0Fn:         mtpN.IsOld0,
-			FnMinOne:         FnMinOne,
-			SiblingsFnMinOne: mtpNMinOne.Siblings,
-			FnMinTwo:         FnMinTwo,
-			SiblingsFnMinTwo: mtpNMinTwo.Siblings,
-		}, "../circuits")
-		require.NoError(t, err)
+		proofA, proofB, proofC := proveNext(t, merkleTree, testEnv.auth.From, int(n), FnMinOne, FnMinTwo)
 		// Capture the flag (mint token): send tx
 		nonce, err := testEnv.client.NonceAt(context.Background(), testEnv.auth.From, nil)
 		require.NoError(t, err)
 		testEnv.auth.Nonce = big.NewInt(int64(nonce))
 		tx, err := testEnv.zkOnacci.CaptureTheFlag(
 			testEnv.auth,
+			puzzle,
 			proofA,
 			proofB,
 			proofC,
@@ -163,6 +189,26 @@ func TestMintNFT(t *testing.T) {
 			uri, err := testEnv.zkOnacci.TokenURI(callOpts, big.NewInt(int64(n-2)))
 			require.NoError(t, err)
 			assert.Equal(t, expectedURI(n-2, tokenTiers, tokenURIs), uri)
+			// Assert FlagCaptured event
+			block := txReceipt.BlockNumber.Uint64()
+			events, err := testEnv.zkOnacci.FilterFlagCaptured(
+				&bind.FilterOpts{Start: block, End: &block},
+				[]common.Address{testEnv.auth.From},
+				[]*big.Int{big.NewInt(int64(n - 2))},
+				[]*big.Int{puzzle},
+			)
+			require.NoError(t, err)
+			require.True(t, events.Next())
+			captured := events.Event
+			assert.Equal(t, tx.Hash(), captured.Raw.TxHash)
+			assert.Equal(t, testEnv.auth.From, captured.Player)
+			assert.Equal(t, int64(n-2), captured.TokenId.Int64())
+			assert.Equal(t, int64(n), captured.N.Int64())
+			assert.Equal(t, expectedTier(n-2, tokenTiers), int(captured.Tier))
+			assert.Equal(t, oldRoot.BigInt().String(), captured.OldRoot.String())
+			assert.Equal(t, merkleTree.Root().BigInt().String(), captured.NewRoot.String())
+			require.False(t, events.Next())
+			require.NoError(t, events.Close())
 			// Values for next iteration
 			n++
 			tmpMinusOne := FnMinOne
@@ -176,10 +222,441 @@ func TestMintNFT(t *testing.T) {
 	}
 }
 
+// proveNext adds Fn = FnMinOne + FnMinTwo to merkleTree and proves it for sender
+func proveNext(t *testing.T, merkleTree *merkletree.MerkleTree, sender common.Address, n, FnMinOne, FnMinTwo int) (proofA [2]*big.Int, proofB [2][2]*big.Int, proofC [2]*big.Int) {
+	// Existence proofs for Fn-1 and Fn-2 BEFORE processing Fn
+	oldRoot := merkleTree.Root()
+	mtpNMinOne, err := merkleTree.GenerateCircomVerifierProof(big.NewInt(int64(n-1)), nil)
+	require.NoError(t, err)
+	mtpNMinTwo, err := merkleTree.GenerateCircomVerifierProof(big.NewInt(int64(n-2)), nil)
+	require.NoError(t, err)
+	// Add Fn and get processing proof
+	mtpN, err := merkleTree.AddAndGetCircomProof(big.NewInt(int64(n)), big.NewInt(int64(FnMinOne+FnMinTwo)))
+	require.NoError(t, err)
+	proofA, proofB, proofC, err = zkinputs.GenerateProof(zkinputs.ZKInput{
+		Sender:           sender,
+		Root:             oldRoot,
+		N:                n,
+		Fn:               FnMinOne + FnMinTwo,
+		SiblingsFn:       mtpN.Siblings,
+		OldKeyFn:         mtpN.OldKey,
+		OldValueFn:       mtpN.OldValue,
+		IsOld0Fn:         mtpN.IsOld0,
+		FnMinOne:         FnMinOne,
+		SiblingsFnMinOne: mtpNMinOne.Siblings,
+		FnMinTwo:         FnMinTwo,
+		SiblingsFnMinTwo: mtpNMinTwo.Siblings,
+	}, "../circuits")
+	require.NoError(t, err)
+	return proofA, proofB, proofC
+}
+
 func expectedURI(id uint16, tokenTiers []uint16, tokenURIs []string) string {
+	return tokenURIs[expectedTier(id, tokenTiers)]
+}
+
+func expectedTier(id uint16, tokenTiers []uint16) int {
 	var tier int
 	for tier < len(tokenTiers)-1 && id > tokenTiers[tier] {
 		tier++
 	}
-	return tokenURIs[tier]
+	return tier
+}
+
+func TestDeployInvalidTiers(t *testing.T) {
+	genesisRoot := big.NewInt(1)
+	for _, tiers := range []tierConfig{
+		{name: "no tiers", tokenTiers: []uint16{}, tokenURIs: []string{}},
+		{name: "missing URI", tokenTiers: []uint16{2, 4}, tokenURIs: []string{"first"}},
+		{name: "not increasing", tokenTiers: []uint16{2, 4, 4}, tokenURIs: []string{"first", "second", "third"}},
+		{name: "decreasing", tokenTiers: []uint16{4, 2}, tokenURIs: []string{"first", "second"}},
+	} {
+		// The gas limit is set, so the deployment tx is mined and reverts
+		testEnv, err := newTestingEnv(genesisRoot, tiers)
+		require.NoError(t, err)
+		code, err := testEnv.client.CodeAt(context.Background(), testEnv.scAddr, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 0, len(code))
+	}
+}
+
+// genesisTree returns the tree with the first two numbers of the sequence
+func genesisTree(t *testing.T) *merkletree.MerkleTree {
+	merkleTree, err := merkletree.NewMerkleTree(memory.NewMemoryStorage(), nLevels)
+	require.NoError(t, err)
+	require.NoError(t, merkleTree.Add(big.NewInt(0), big.NewInt(0)))
+	require.NoError(t, merkleTree.Add(big.NewInt(1), big.NewInt(1)))
+	return merkleTree
+}
+
+func TestSeasons(t *testing.T) {
+	ctx := context.Background()
+	callOpts := &bind.CallOpts{}
+	merkleTree := genesisTree(t)
+	testEnv, err := newTestingEnv(merkleTree.Root().BigInt(), tierConfigs[2])
+	require.NoError(t, err)
+	owner, err := testEnv.zkOnacci.Owner(callOpts)
+	require.NoError(t, err)
+	assert.Equal(t, testEnv.auth.From, owner)
+	// Without a gas limit, the reverts are caught by the gas estimation
+	opts := *testEnv.auth
+	opts.GasLimit = 0
+	nextRoot := genesisTree(t).Root().BigInt()
+	puzzle := big.NewInt(0)
+	startNext := func() error {
+		_, err := testEnv.zkOnacci.StartSeason(&opts, puzzle, common.Address{}, 0, nextRoot, "ipfs://", []uint16{0, 1}, []string{"first", "second"})
+		return err
+	}
+	capture := func(n, FnMinOne, FnMinTwo int) *big.Int {
+		proofA, proofB, proofC := proveNext(t, merkleTree, testEnv.auth.From, n, FnMinOne, FnMinTwo)
+		tx, err := testEnv.zkOnacci.CaptureTheFlag(&opts, puzzle, proofA, proofB, proofC, merkleTree.Root().BigInt())
+		require.NoError(t, err)
+		testEnv.client.Commit()
+		receipt, err := testEnv.client.TransactionReceipt(ctx, tx.Hash())
+		require.NoError(t, err)
+		require.Equal(t, uint64(1), receipt.Status)
+		captured, err := testEnv.zkOnacci.ParseFlagCaptured(*receipt.Logs[0])
+		require.NoError(t, err)
+		assert.Equal(t, int64(n), captured.N.Int64())
+		return captured.TokenId
+	}
+
+	// The season can't be changed until all its tokens are minted
+	err = startNext()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "SEASON_NOT_FINISHED")
+	FnMinOne, FnMinTwo := 1, 0
+	for n := 2; n <= 8; n++ {
+		tokenID := capture(n, FnMinOne, FnMinTwo)
+		assert.Equal(t, int64(n-2), tokenID.Int64())
+		FnMinOne, FnMinTwo = FnMinOne+FnMinTwo, FnMinOne
+	}
+
+	// New season, with the same verifier
+	tx, err := testEnv.zkOnacci.StartSeason(&opts, puzzle, common.Address{}, 0, nextRoot, "ipfs://", []uint16{0, 1}, []string{"first", "second"})
+	require.NoError(t, err)
+	testEnv.client.Commit()
+	receipt, err := testEnv.client.TransactionReceipt(ctx, tx.Hash())
+	require.NoError(t, err)
+	require.Equal(t, 1, len(receipt.Logs))
+	started, err := testEnv.zkOnacci.ParseSeasonStarted(*receipt.Logs[0])
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), started.PuzzleId.Int64())
+	assert.Equal(t, int64(1), started.Season.Int64())
+	assert.Equal(t, nextRoot.String(), started.GenesisRoot.String())
+	currentSeason, err := testEnv.zkOnacci.CurrentSeason(callOpts, puzzle)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), currentSeason.Int64())
+	tokenCounter, err := testEnv.zkOnacci.TokenCounter(callOpts, puzzle)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), tokenCounter.Int64())
+	root, err := testEnv.zkOnacci.Root(callOpts, puzzle)
+	require.NoError(t, err)
+	assert.Equal(t, nextRoot.String(), root.String())
+	nTiers, err := testEnv.zkOnacci.NTiers(callOpts, puzzle)
+	require.NoError(t, err)
+	assert.Equal(t, uint8(2), nTiers)
+	baseURI, err := testEnv.zkOnacci.BaseURI(callOpts, puzzle)
+	require.NoError(t, err)
+	assert.Equal(t, "ipfs://", baseURI)
+	previous, err := testEnv.zkOnacci.Season(callOpts, puzzle, big.NewInt(0))
+	require.NoError(t, err)
+	assert.Equal(t, tierConfigs[2].tokenTiers, previous.SeasonTokenTiers)
+	assert.Equal(t, tierConfigs[2].baseURI, previous.SeasonBaseURI)
+	assert.Equal(t, previous.VerifierAddr, started.Verifier)
+	// The order is kept along with the verifier
+	order, err := testEnv.zkOnacci.Order(callOpts, puzzle)
+	require.NoError(t, err)
+	assert.Equal(t, uint8(2), order)
+
+	// The tokens of the new season encode it in their ID, the tokens of the previous season keep their metadata
+	merkleTree = genesisTree(t)
+	tokenID := capture(2, 1, 0)
+	assert.Equal(t, int64(1<<16), tokenID.Int64())
+	season, err := testEnv.zkOnacci.SeasonOf(callOpts, tokenID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), season.Int64())
+	uri, err := testEnv.zkOnacci.TokenURI(callOpts, tokenID)
+	require.NoError(t, err)
+	assert.Equal(t, "ipfs://first", uri)
+	tokenID = capture(3, 1, 1)
+	assert.Equal(t, int64(1<<16+1), tokenID.Int64())
+	uri, err = testEnv.zkOnacci.TokenURI(callOpts, tokenID)
+	require.NoError(t, err)
+	assert.Equal(t, "ipfs://second", uri)
+	uri, err = testEnv.zkOnacci.TokenURI(callOpts, big.NewInt(6))
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/third", uri)
+	tokenOwner, err := testEnv.zkOnacci.OwnerOf(callOpts, big.NewInt(6))
+	require.NoError(t, err)
+	assert.Equal(t, testEnv.auth.From, tokenOwner)
+	// The supply of the season is checked before the proof
+	zero := big.NewInt(0)
+	_, err = testEnv.zkOnacci.CaptureTheFlag(&opts, puzzle, [2]*big.Int{zero, zero}, [2][2]*big.Int{{zero, zero}, {zero, zero}}, [2]*big.Int{zero, zero}, zero)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "ALL_TOKENS_MINTED")
+
+	// Only the owner can start seasons
+	_, err = testEnv.zkOnacci.TransferOwnership(&opts, common.HexToAddress("0x1234"))
+	require.NoError(t, err)
+	testEnv.client.Commit()
+	err = startNext()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "NOT_OWNER")
+}
+
+func TestPuzzles(t *testing.T) {
+	ctx := context.Background()
+	callOpts := &bind.CallOpts{}
+	merkleTree := genesisTree(t)
+	genesisRoot := merkleTree.Root().BigInt()
+	testEnv, err := newTestingEnv(genesisRoot, tierConfigs[0])
+	require.NoError(t, err)
+	opts := *testEnv.auth
+	opts.GasLimit = 0
+	addPuzzle := func(verifierAddr common.Address, order uint8) error {
+		_, err := testEnv.zkOnacci.AddPuzzle(&opts, verifierAddr, order, genesisRoot, "ipfs://", []uint16{0}, []string{"single"})
+		return err
+	}
+	nPuzzles, err := testEnv.zkOnacci.NPuzzles(callOpts)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), nPuzzles.Int64())
+
+	// The puzzle 1 has its own verifier (of the same circuit) and a single token
+	verifierAddr, _, _, err := DeployVerifier(&opts, testEnv.client)
+	require.NoError(t, err)
+	testEnv.client.Commit()
+	require.Error(t, addPuzzle(common.Address{}, 2))
+	err = addPuzzle(verifierAddr, 0)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "INVALID_ORDER")
+	require.NoError(t, addPuzzle(verifierAddr, 2))
+	testEnv.client.Commit()
+	nPuzzles, err = testEnv.zkOnacci.NPuzzles(callOpts)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), nPuzzles.Int64())
+	puzzleVerifier, err := testEnv.zkOnacci.Verifier(callOpts, big.NewInt(1))
+	require.NoError(t, err)
+	assert.Equal(t, verifierAddr, puzzleVerifier)
+	_, err = testEnv.zkOnacci.Root(callOpts, big.NewInt(2))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "INVALID_PUZZLE")
+
+	// Both puzzles start from the same root, so the same proof captures the first flag of each of them
+	proofA, proofB, proofC := proveNext(t, merkleTree, testEnv.auth.From, 2, 1, 0)
+	nextRoot := merkleTree.Root().BigInt()
+	for _, puzzle := range []int64{1, 0} {
+		tx, err := testEnv.zkOnacci.CaptureTheFlag(&opts, big.NewInt(puzzle), proofA, proofB, proofC, nextRoot)
+		require.NoError(t, err)
+		testEnv.client.Commit()
+		receipt, err := testEnv.client.TransactionReceipt(ctx, tx.Hash())
+		require.NoError(t, err)
+		captured, err := testEnv.zkOnacci.ParseFlagCaptured(*receipt.Logs[0])
+		require.NoError(t, err)
+		assert.Equal(t, puzzle, captured.PuzzleId.Int64())
+		assert.Equal(t, puzzle<<32, captured.TokenId.Int64())
+		assert.Equal(t, int64(2), captured.N.Int64())
+		puzzleOf, err := testEnv.zkOnacci.PuzzleOf(callOpts, captured.TokenId)
+		require.NoError(t, err)
+		assert.Equal(t, puzzle, puzzleOf.Int64())
+		root, err := testEnv.zkOnacci.Root(callOpts, big.NewInt(puzzle))
+		require.NoError(t, err)
+		assert.Equal(t, nextRoot.String(), root.String())
+	}
+	uri, err := testEnv.zkOnacci.TokenURI(callOpts, big.NewInt(1<<32))
+	require.NoError(t, err)
+	assert.Equal(t, "ipfs://single", uri)
+	uri, err = testEnv.zkOnacci.TokenURI(callOpts, big.NewInt(0))
+	require.NoError(t, err)
+	assert.Equal(t, tierConfigs[0].baseURI+tierConfigs[0].tokenURIs[0], uri)
+
+	// The puzzle 1 is sold out and can start a new season, while the puzzle 0 keeps going
+	proofA, proofB, proofC = proveNext(t, merkleTree, testEnv.auth.From, 3, 1, 1)
+	_, err = testEnv.zkOnacci.CaptureTheFlag(&opts, big.NewInt(1), proofA, proofB, proofC, merkleTree.Root().BigInt())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "ALL_TOKENS_MINTED")
+	_, err = testEnv.zkOnacci.StartSeason(&opts, big.NewInt(0), common.Address{}, 0, genesisRoot, "ipfs://", []uint16{0}, []string{"single"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "SEASON_NOT_FINISHED")
+	_, err = testEnv.zkOnacci.StartSeason(&opts, big.NewInt(1), common.Address{}, 0, genesisRoot, "ipfs://", []uint16{0}, []string{"single"})
+	require.NoError(t, err)
+	_, err = testEnv.zkOnacci.CaptureTheFlag(&opts, big.NewInt(0), proofA, proofB, proofC, merkleTree.Root().BigInt())
+	require.NoError(t, err)
+	testEnv.client.Commit()
+	for puzzle, expected := range []struct {
+		season       int64
+		tokenCounter int64
+	}{{0, 2}, {1, 0}} {
+		season, err := testEnv.zkOnacci.CurrentSeason(callOpts, big.NewInt(int64(puzzle)))
+		require.NoError(t, err)
+		assert.Equal(t, expected.season, season.Int64())
+		tokenCounter, err := testEnv.zkOnacci.TokenCounter(callOpts, big.NewInt(int64(puzzle)))
+		require.NoError(t, err)
+		assert.Equal(t, expected.tokenCounter, tokenCounter.Int64())
+	}
+
+	// Only the owner can add puzzles
+	_, err = testEnv.zkOnacci.TransferOwnership(&opts, common.HexToAddress("0x1234"))
+	require.NoError(t, err)
+	testEnv.client.Commit()
+	err = addPuzzle(verifierAddr, 2)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "NOT_OWNER")
+}
+
+func TestCaptureLimits(t *testing.T) {
+	callOpts := &bind.CallOpts{}
+	merkleTree := genesisTree(t)
+	// Tiers: tokens 0-2, 3-4, 5-8 and 9-16
+	testEnv, err := newTestingEnv(merkleTree.Root().BigInt(), tierConfigs[0])
+	require.NoError(t, err)
+	opts := *testEnv.auth
+	opts.GasLimit = 0
+	puzzle := big.NewInt(0)
+	owner := testEnv.auth.From
+	other := common.HexToAddress("0x1234")
+
+	// The tier limits must cover every tier
+	_, err = testEnv.zkOnacci.SetCaptureLimits(&opts, puzzle, big.NewInt(4), []*big.Int{big.NewInt(1), big.NewInt(1)})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "TIERS_LENGTH_MISMATCH")
+	// 4 captures per address, 2 of them on the first tier
+	maxTierCaptures := []*big.Int{big.NewInt(2), big.NewInt(0), big.NewInt(0), big.NewInt(0)}
+	_, err = testEnv.zkOnacci.SetCaptureLimits(&opts, puzzle, big.NewInt(4), maxTierCaptures)
+	require.NoError(t, err)
+	testEnv.client.Commit()
+	limits, err := testEnv.zkOnacci.CaptureLimits(callOpts, puzzle)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), limits.MaxCaptures.Int64())
+	assert.Equal(t, fmt.Sprint(maxTierCaptures), fmt.Sprint(limits.MaxTierCaptures))
+
+	capture := func(recipient common.Address, proofA [2]*big.Int, proofB [2][2]*big.Int, proofC [2]*big.Int) error {
+		_, err := testEnv.zkOnacci.CaptureTheFlagFor(&opts, recipient, puzzle, proofA, proofB, proofC, merkleTree.Root().BigInt())
+		testEnv.client.Commit()
+		return err
+	}
+	proofA, proofB, proofC := proveNext(t, merkleTree, owner, 2, 1, 0)
+	require.NoError(t, capture(owner, proofA, proofB, proofC))
+	proofA, proofB, proofC = proveNext(t, merkleTree, owner, 3, 1, 1)
+	require.NoError(t, capture(owner, proofA, proofB, proofC))
+	// The limits are checked before the proof, so a stale one gets the limit revert
+	err = capture(owner, proofA, proofB, proofC)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "TIER_CAPTURE_LIMIT_REACHED")
+	// The last token of the first tier goes to another player, the limits apply to the recipient
+	proofA, proofB, proofC = proveNext(t, merkleTree, other, 4, 2, 1)
+	require.NoError(t, capture(other, proofA, proofB, proofC))
+	proofA, proofB, proofC = proveNext(t, merkleTree, owner, 5, 3, 2)
+	require.NoError(t, capture(owner, proofA, proofB, proofC))
+	proofA, proofB, proofC = proveNext(t, merkleTree, owner, 6, 5, 3)
+	require.NoError(t, capture(owner, proofA, proofB, proofC))
+	err = capture(owner, proofA, proofB, proofC)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "CAPTURE_LIMIT_REACHED")
+	for _, expected := range []struct {
+		player   common.Address
+		captures int64
+		byTier   []int64
+	}{{owner, 4, []int64{2, 2, 0, 0}}, {other, 1, []int64{1, 0, 0, 0}}} {
+		captures, err := testEnv.zkOnacci.Captures(callOpts, puzzle, expected.player)
+		require.NoError(t, err)
+		assert.Equal(t, expected.captures, captures.Int64())
+		for tier, tierExpected := range expected.byTier {
+			tierCaptures, err := testEnv.zkOnacci.TierCaptures(callOpts, puzzle, expected.player, uint8(tier))
+			require.NoError(t, err)
+			assert.Equal(t, tierExpected, tierCaptures.Int64())
+		}
+	}
+
+	// Lifting the limits
+	_, err = testEnv.zkOnacci.SetCaptureLimits(&opts, puzzle, big.NewInt(0), nil)
+	require.NoError(t, err)
+	testEnv.client.Commit()
+	proofA, proofB, proofC = proveNext(t, merkleTree, owner, 7, 8, 5)
+	require.NoError(t, capture(owner, proofA, proofB, proofC))
+}
+
+func TestPrizePool(t *testing.T) {
+	ctx := context.Background()
+	callOpts := &bind.CallOpts{}
+	merkleTree := genesisTree(t)
+	// Tiers: tokens 0-2, 3-4, 5-8 and 9-16
+	testEnv, err := newTestingEnv(merkleTree.Root().BigInt(), tierConfigs[0])
+	require.NoError(t, err)
+	opts := *testEnv.auth
+	opts.GasLimit = 0
+	puzzle := big.NewInt(0)
+	player := testEnv.auth.From
+
+	_, err = testEnv.zkOnacci.SetTierRewards(&opts, puzzle, []*big.Int{big.NewInt(1000)})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "TIERS_LENGTH_MISMATCH")
+	_, err = testEnv.zkOnacci.SetTierRewards(&opts, puzzle, []*big.Int{big.NewInt(1000), big.NewInt(3000), big.NewInt(0), big.NewInt(0)})
+	require.NoError(t, err)
+	// Deposits through deposit and plain transfers
+	opts.Value = big.NewInt(2000)
+	_, err = testEnv.zkOnacci.Deposit(&opts)
+	require.NoError(t, err)
+	testEnv.client.Commit()
+	opts.Value = big.NewInt(500)
+	_, err = testEnv.zkOnacci.Receive(&opts)
+	require.NoError(t, err)
+	testEnv.client.Commit()
+	opts.Value = nil
+	prizePool, err := testEnv.zkOnacci.PrizePool(callOpts)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2500), prizePool.Int64())
+
+	// The rewards are credited while the pool has funds
+	for _, capture := range []struct {
+		n, FnMinOne, FnMinTwo int
+		credited              int64
+	}{{2, 1, 0, 1000}, {3, 1, 1, 1000}, {4, 2, 1, 500}, {5, 3, 2, 0}} {
+		proofA, proofB, proofC := proveNext(t, merkleTree, player, capture.n, capture.FnMinOne, capture.FnMinTwo)
+		tx, err := testEnv.zkOnacci.CaptureTheFlag(&opts, puzzle, proofA, proofB, proofC, merkleTree.Root().BigInt())
+		require.NoError(t, err)
+		testEnv.client.Commit()
+		receipt, err := testEnv.client.TransactionReceipt(ctx, tx.Hash())
+		require.NoError(t, err)
+		if capture.credited == 0 {
+			// FlagCaptured and the Transfer of the NFT
+			assert.Equal(t, 2, len(receipt.Logs))
+			continue
+		}
+		credited, err := testEnv.zkOnacci.ParseRewardCredited(*receipt.Logs[1])
+		require.NoError(t, err)
+		assert.Equal(t, player, credited.Player)
+		assert.Equal(t, int64(capture.n-2), credited.TokenId.Int64())
+		assert.Equal(t, capture.credited, credited.Amount.Int64())
+	}
+	rewards, err := testEnv.zkOnacci.Rewards(callOpts, player)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2500), rewards.Int64())
+	prizePool, err = testEnv.zkOnacci.PrizePool(callOpts)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), prizePool.Int64())
+
+	// Pull based withdrawal
+	tx, err := testEnv.zkOnacci.Withdraw(&opts)
+	require.NoError(t, err)
+	testEnv.client.Commit()
+	receipt, err := testEnv.client.TransactionReceipt(ctx, tx.Hash())
+	require.NoError(t, err)
+	withdrawn, err := testEnv.zkOnacci.ParseRewardWithdrawn(*receipt.Logs[0])
+	require.NoError(t, err)
+	assert.Equal(t, int64(2500), withdrawn.Amount.Int64())
+	balance, err := testEnv.client.BalanceAt(ctx, testEnv.scAddr, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), balance.Int64())
+	_, err = testEnv.zkOnacci.Withdraw(&opts)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "NO_REWARDS")
+
+	// Only the owner sets the rewards
+	_, err = testEnv.zkOnacci.TransferOwnership(&opts, common.HexToAddress("0x1234"))
+	require.NoError(t, err)
+	testEnv.client.Commit()
+	_, err = testEnv.zkOnacci.SetTierRewards(&opts, puzzle, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "NOT_OWNER")
 }
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/arnaubennassar/zkOnacci/contracts"
+	"github.com/arnaubennassar/zkOnacci/game"
+	"github.com/arnaubennassar/zkOnacci/txutil"
+	"github.com/arnaubennassar/zkOnacci/verify"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// factoryConfig selects the deployment of the contracts through a CREATE2 factory, so they get the same
+// addresses on every chain where the factory has the same address
+type factoryConfig struct {
+	address common.Address
+	salt    common.Hash
+}
+
+// parseSalt returns the salt of s, which is either a 32 bytes hex string or a string that is hashed with keccak256
+func parseSalt(s string) common.Hash {
+	if strings.HasPrefix(s, "0x") && len(s) == 2+2*common.HashLength {
+		return common.HexToHash(s)
+	}
+	return crypto.Keccak256Hash([]byte(s))
+}
+
+// initCode returns the creation code of bin followed by the ABI encoded constructor args
+func initCode(bin string, args []byte) []byte {
+	return append(common.FromHex(bin), args...)
+}
+
+// predict returns the address of the contract deployed through the factory with the init code
+func (f *factoryConfig) predict(code []byte) common.Address {
+	return crypto.CreateAddress2(f.address, f.salt, crypto.Keccak256(code))
+}
+
+// predictedAddresses are the addresses of the contracts deployed through the factory
+type predictedAddresses struct {
+	verifier common.Address
+	zkOnacci common.Address
+	hints    common.Address
+}
+
+// predictAddresses returns the addresses of the verifier, zkOnacci (deployed with the params of def) and the
+// hints contract, both owned by deployer
+func (f *factoryConfig) predictAddresses(def *game.Definition, deployer common.Address) (predictedAddresses, error) {
+	p := predictedAddresses{verifier: f.predict(initCode(contracts.VerifierBin, nil))}
+	args, err := verify.ZKOnacciArgs(p.verifier, deployer, def)
+	if err != nil {
+		return predictedAddresses{}, err
+	}
+	p.zkOnacci = f.predict(initCode(contracts.ZKOnacciBin, args))
+	if args, err = verify.HintsArgs(p.zkOnacci, deployer); err != nil {
+		return predictedAddresses{}, err
+	}
+	p.hints = f.predict(initCode(contracts.ZKOnacciHintsBin, args))
+	return p, nil
+}
+
+// print writes the predicted addresses to stdout
+func (p predictedAddresses) print() {
+	fmt.Println("verifier:", p.verifier.Hex())
+	fmt.Println("zkOnacci:", p.zkOnacci.Hex())
+	fmt.Println("hints:", p.hints.Hex())
+}
+
+// deployFunc returns a function that deploys the init code through the factory
+func (f *factoryConfig) deployFunc(backend bind.ContractBackend, code []byte) func(*bind.TransactOpts) (common.Address, *types.Transaction, error) {
+	return func(opts *bind.TransactOpts) (common.Address, *types.Transaction, error) {
+		factory, err := contracts.NewCreate2Factory(f.address, backend)
+		if err != nil {
+			return common.Address{}, nil, err
+		}
+		tx, err := factory.Deploy(opts, f.salt, code)
+		return f.predict(code), tx, err
+	}
+}
+
+// deploymentReceipt returns the receipt of the tx that deployed addr through the factory
+func (f *factoryConfig) deploymentReceipt(ctx context.Context, backend deployBackend, addr common.Address) (*types.Receipt, error) {
+	factory, err := contracts.NewCreate2Factory(f.address, backend)
+	if err != nil {
+		return nil, err
+	}
+	iter, err := factory.FilterDeployed(&bind.FilterOpts{Context: ctx}, []common.Address{addr}, [][32]byte{f.salt})
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+	if !iter.Next() {
+		if err := iter.Error(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%s has code but wasn't deployed by the factory %s", addr.Hex(), f.address.Hex())
+	}
+	return backend.TransactionReceipt(ctx, iter.Event.Raw.TxHash)
+}
+
+// deployFactory deploys the CREATE2 factory. It gets the same address on every chain where it's deployed by the
+// same account with the same nonce
+func deployFactory(
+	ctx context.Context,
+	backend deployBackend,
+	auth *bind.TransactOpts,
+	feeConfig txutil.FeeConfig,
+	waitConfig txutil.WaitConfig,
+) (common.Address, error) {
+	nonce, err := backend.PendingNonceAt(ctx, auth.From)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if nonce != 0 {
+		fmt.Printf("WARNING: the nonce of %s is %d, the factory only gets the same address on the chains where it's deployed with the same nonce\n",
+			auth.From.Hex(), nonce)
+	}
+	auth.Nonce = new(big.Int).SetUint64(nonce)
+	if err := txutil.SetFees(ctx, backend, auth, feeConfig); err != nil {
+		return common.Address{}, err
+	}
+	var addr common.Address
+	tx, err := txutil.Send(auth, feeConfig, func(opts *bind.TransactOpts) (tx *types.Transaction, err error) {
+		addr, tx, _, err = contracts.DeployCreate2Factory(opts, backend)
+		return
+	})
+	if err != nil {
+		return common.Address{}, err
+	}
+	fmt.Println("CREATE2 factory deployment tx sent:", tx.Hash().Hex())
+	if _, err := txutil.WaitMined(ctx, backend, tx, waitConfig); err != nil {
+		return common.Address{}, err
+	}
+	return addr, nil
+}
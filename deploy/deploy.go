@@ -0,0 +1,302 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/arnaubennassar/zkOnacci/contracts"
+	"github.com/arnaubennassar/zkOnacci/game"
+	"github.com/arnaubennassar/zkOnacci/manifest"
+	"github.com/arnaubennassar/zkOnacci/txutil"
+	"github.com/arnaubennassar/zkOnacci/verify"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// deployBackend is the functionality needed to deploy the contracts and wait for them
+type deployBackend interface {
+	txutil.Backend
+	txutil.WaitBackend
+}
+
+// deployer deploys the contracts that are missing from the manifest, which is saved after every step
+// so an interrupted deployment can be resumed
+type deployer struct {
+	backend       deployBackend
+	auth          *bind.TransactOpts
+	feeConfig     txutil.FeeConfig
+	waitConfig    txutil.WaitConfig
+	artifactsPath string
+	manifestPath  string
+	game          *game.Definition
+	// factory is the CREATE2 factory used to deploy the contracts (nil = deploy them directly)
+	factory *factoryConfig
+	m       *manifest.Manifest
+}
+
+// newDeployer resumes the deployment of the manifest of manifestPath (if it exists), zkOnacci is deployed with the
+// params of def. The contracts are deployed through factory if it's not nil. If force is set, the existing manifest
+// is ignored and all the contracts are deployed again
+func newDeployer(
+	ctx context.Context,
+	backend deployBackend,
+	auth *bind.TransactOpts,
+	feeConfig txutil.FeeConfig,
+	waitConfig txutil.WaitConfig,
+	artifactsPath string,
+	manifestPath string,
+	def *game.Definition,
+	factory *factoryConfig,
+	force bool,
+) (*deployer, error) {
+	chainID, err := backend.ChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m := &manifest.Manifest{}
+	if !force {
+		if m, err = manifest.Read(manifestPath); errors.Is(err, os.ErrNotExist) {
+			m = &manifest.Manifest{}
+		} else if err != nil {
+			return nil, fmt.Errorf("error reading the manifest %s: %w", manifestPath, err)
+		}
+	}
+	if m.ChainID != 0 && m.ChainID != chainID.Uint64() {
+		return nil, fmt.Errorf(
+			"the manifest %s belongs to the chain %d but the node is on the chain %d, use -force to deploy anyway",
+			manifestPath, m.ChainID, chainID,
+		)
+	}
+	factoryAddr, salt := common.Address{}, common.Hash{}
+	if factory != nil {
+		factoryAddr, salt = factory.address, factory.salt
+	}
+	if m.Verifier.Address != (common.Address{}) && (m.Factory != factoryAddr || m.Salt != salt) {
+		return nil, fmt.Errorf(
+			"the manifest %s was deployed with another factory or salt (factory %s, salt %s), use -force to deploy anyway",
+			manifestPath, m.Factory.Hex(), m.Salt.Hex(),
+		)
+	}
+	m.ChainID = chainID.Uint64()
+	m.Factory, m.Salt = factoryAddr, salt
+	return &deployer{
+		backend:       backend,
+		auth:          auth,
+		feeConfig:     feeConfig,
+		waitConfig:    waitConfig,
+		artifactsPath: artifactsPath,
+		manifestPath:  manifestPath,
+		game:          def,
+		factory:       factory,
+		m:             m,
+	}, nil
+}
+
+// run deploys the contracts that are not deployed yet. ZKOnacci is deployed again if the verifier is, and so are
+// the hints if zkOnacci is
+func (d *deployer) run(ctx context.Context) error {
+	verifierDeployed, err := d.resume(ctx, "verifier", &d.m.Verifier, contracts.VerifierBin, nil)
+	if err != nil {
+		return err
+	}
+	if !verifierDeployed {
+		artifacts, err := manifest.HashArtifacts(d.artifactsPath)
+		if err != nil {
+			return err
+		}
+		d.m.Artifacts = artifacts
+		// A previous zkOnacci uses another verifier
+		d.m.ZKOnacci = manifest.Deployment{}
+		if err := d.deployContract(ctx, "verifier", &d.m.Verifier, contracts.VerifierBin, nil, func(opts *bind.TransactOpts) (addr common.Address, tx *types.Transaction, err error) {
+			addr, tx, _, err = contracts.DeployVerifier(opts, d.backend)
+			return
+		}); err != nil {
+			return err
+		}
+	}
+	// zkOnacci and the hints are owned by the deployer
+	args, err := verify.ZKOnacciArgs(d.m.Verifier.Address, d.auth.From, d.game)
+	if err != nil {
+		return err
+	}
+	zkOnacciDeployed := false
+	if verifierDeployed {
+		if zkOnacciDeployed, err = d.resume(ctx, "zkOnacci", &d.m.ZKOnacci, contracts.ZKOnacciBin, args); err != nil {
+			return err
+		}
+	}
+	if !zkOnacciDeployed {
+		// A previous hints contract belongs to another zkOnacci
+		d.m.Hints = manifest.Deployment{}
+		if err := d.deployContract(ctx, "zkOnacci", &d.m.ZKOnacci, contracts.ZKOnacciBin, args, func(opts *bind.TransactOpts) (addr common.Address, tx *types.Transaction, err error) {
+			addr, tx, _, err = contracts.DeployZKOnacci(
+				opts, d.backend, d.m.Verifier.Address, d.game.GenesisRoot, d.game.BaseURI, d.game.TokenTiers(), d.game.TokenURIs(), d.auth.From,
+			)
+			return
+		}); err != nil {
+			return err
+		}
+	}
+	hintsArgs, err := verify.HintsArgs(d.m.ZKOnacci.Address, d.auth.From)
+	if err != nil {
+		return err
+	}
+	hintsDeployed := false
+	if zkOnacciDeployed {
+		if hintsDeployed, err = d.resume(ctx, "hints", &d.m.Hints, contracts.ZKOnacciHintsBin, hintsArgs); err != nil {
+			return err
+		}
+	}
+	if !hintsDeployed {
+		return d.deployContract(ctx, "hints", &d.m.Hints, contracts.ZKOnacciHintsBin, hintsArgs, func(opts *bind.TransactOpts) (addr common.Address, tx *types.Transaction, err error) {
+			addr, tx, _, err = contracts.DeployZKOnacciHints(opts, d.backend, d.m.ZKOnacci.Address, d.auth.From)
+			return
+		})
+	}
+	return nil
+}
+
+// resume checks the deployment of the manifest (if any), waiting for it if it's pending, and checks that it has
+// been deployed from the current build (the constructor of bin run with args). Returns false if the contract has to be deployed
+func (d *deployer) resume(ctx context.Context, name string, dep *manifest.Deployment, bin string, args []byte) (bool, error) {
+	if dep.Address == (common.Address{}) {
+		return false, nil
+	}
+	artifacts, err := manifest.HashArtifacts(d.artifactsPath)
+	if err != nil {
+		return false, err
+	}
+	if d.m.Artifacts[manifest.VerifierBytecodeArtifact] != artifacts[manifest.VerifierBytecodeArtifact] {
+		return false, errors.New("the manifest was deployed with another verifier bytecode, use -force to deploy again")
+	}
+	if dep.Pending() {
+		tx, _, err := d.backend.TransactionByHash(ctx, dep.TxHash)
+		if errors.Is(err, ethereum.NotFound) {
+			fmt.Println(name, "deployment tx", dep.TxHash.Hex(), "not found, deploying again")
+			return false, nil
+		} else if err != nil {
+			return false, err
+		}
+		fmt.Println("waiting for the", name, "deployment tx", dep.TxHash.Hex())
+		receipt, err := txutil.WaitMined(ctx, d.backend, tx, d.waitConfig)
+		var failed *txutil.TxFailedError
+		switch {
+		case errors.As(err, &failed), errors.Is(err, txutil.ErrTxDropped),
+			errors.Is(err, txutil.ErrTxReplaced), errors.Is(err, txutil.ErrTxReorged):
+			fmt.Printf("%s deployment tx %s won't be mined (%s), deploying again\n", name, dep.TxHash.Hex(), err)
+			return false, nil
+		case err != nil:
+			return false, err
+		}
+		*dep = manifest.NewDeployment(dep.Address, receipt)
+		if err := d.m.Save(d.manifestPath); err != nil {
+			return false, err
+		}
+	}
+	code, err := d.backend.CodeAt(ctx, dep.Address, nil)
+	if err != nil {
+		return false, err
+	}
+	if len(code) == 0 {
+		return false, fmt.Errorf("the manifest has the %s at %s but it has no code, use -force to deploy again", name, dep.Address.Hex())
+	}
+	matches, err := verify.CodeMatches(code, bin, args, new(big.Int).SetUint64(d.m.ChainID))
+	if err != nil {
+		return false, err
+	}
+	if !matches {
+		return false, fmt.Errorf("the code of the %s at %s doesn't match the current build, use -force to deploy again", name, dep.Address.Hex())
+	}
+	fmt.Println(name, "already deployed at", dep.Address.Hex())
+	return true, nil
+}
+
+// deployContract deploys bin with the ABI encoded constructor args, through the factory if there's one (otherwise
+// deployDirectly is used). The deployment through the factory is skipped if the predicted address already has the code
+func (d *deployer) deployContract(
+	ctx context.Context,
+	name string,
+	dep *manifest.Deployment,
+	bin string,
+	args []byte,
+	deployDirectly func(*bind.TransactOpts) (common.Address, *types.Transaction, error),
+) error {
+	if d.factory == nil {
+		return d.deploy(ctx, name, dep, deployDirectly)
+	}
+	code := initCode(bin, args)
+	addr := d.factory.predict(code)
+	existingCode, err := d.backend.CodeAt(ctx, addr, nil)
+	if err != nil {
+		return err
+	}
+	if len(existingCode) == 0 {
+		return d.deploy(ctx, name, dep, d.factory.deployFunc(d.backend, code))
+	}
+	matches, err := verify.CodeMatches(existingCode, bin, args, new(big.Int).SetUint64(d.m.ChainID))
+	if err != nil {
+		return err
+	}
+	if !matches {
+		return fmt.Errorf("the code of the %s at the predicted address %s doesn't match the current build", name, addr.Hex())
+	}
+	receipt, err := d.factory.deploymentReceipt(ctx, d.backend, addr)
+	if err != nil {
+		return err
+	}
+	*dep = manifest.NewDeployment(addr, receipt)
+	if err := d.m.Save(d.manifestPath); err != nil {
+		return err
+	}
+	fmt.Println(name, "already deployed at the predicted address", addr.Hex())
+	return nil
+}
+
+// deploy sends the deployment tx of a contract and waits for it, recording the progress in the manifest
+func (d *deployer) deploy(
+	ctx context.Context,
+	name string,
+	dep *manifest.Deployment,
+	deployContract func(*bind.TransactOpts) (common.Address, *types.Transaction, error),
+) error {
+	// The nonce is read before each deployment, so txs that have been dropped don't leave gaps
+	nonce, err := d.backend.PendingNonceAt(ctx, d.auth.From)
+	if err != nil {
+		return err
+	}
+	d.auth.Nonce = new(big.Int).SetUint64(nonce)
+	if err := txutil.SetFees(ctx, d.backend, d.auth, d.feeConfig); err != nil {
+		return err
+	}
+	var addr common.Address
+	tx, err := txutil.Send(d.auth, d.feeConfig, func(opts *bind.TransactOpts) (tx *types.Transaction, err error) {
+		addr, tx, err = deployContract(opts)
+		return
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Println(name, "deployment tx sent:")
+	fmt.Println(addr.Hex())
+	fmt.Println(tx.Hash().Hex())
+	d.m.Deployer = d.auth.From
+	*dep = manifest.Deployment{Address: addr, TxHash: tx.Hash()}
+	if err := d.m.Save(d.manifestPath); err != nil {
+		return err
+	}
+	receipt, err := txutil.WaitMined(ctx, d.backend, tx, d.waitConfig)
+	if err != nil {
+		return err
+	}
+	*dep = manifest.NewDeployment(addr, receipt)
+	if err := d.m.Save(d.manifestPath); err != nil {
+		return err
+	}
+	fmt.Println(name, "deployed successfully")
+	return nil
+}
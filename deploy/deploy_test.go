@@ -0,0 +1,483 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/arnaubennassar/zkOnacci/contracts"
+	"github.com/arnaubennassar/zkOnacci/game"
+	"github.com/arnaubennassar/zkOnacci/manifest"
+	"github.com/arnaubennassar/zkOnacci/recurrence"
+	"github.com/arnaubennassar/zkOnacci/signer"
+	"github.com/arnaubennassar/zkOnacci/testutil"
+	"github.com/arnaubennassar/zkOnacci/txutil"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testDeployment struct {
+	backend      testutil.SimulatedBackend
+	auth         *bind.TransactOpts
+	manifestPath string
+	game         *game.Definition
+	factory      *factoryConfig
+}
+
+func newTestDeployment(t *testing.T) *testDeployment {
+	return newTestDeploymentWithKey(t, testutil.NewKey(t))
+}
+
+func newTestDeploymentWithKey(t *testing.T, privateKey *ecdsa.PrivateKey) *testDeployment {
+	backend := testutil.NewSimulatedBackend(privateKey)
+	auth, err := txutil.NewTransactOpts(context.Background(), backend, signer.NewKeySigner(privateKey), txutil.FeeConfig{})
+	require.NoError(t, err)
+	return &testDeployment{
+		backend:      backend,
+		auth:         auth,
+		manifestPath: filepath.Join(t.TempDir(), "deployment.json"),
+		game:         testutil.LoadGame(t),
+	}
+}
+
+// run deploys with a block mined on every wait check
+func (td *testDeployment) run(t *testing.T, force bool) (*manifest.Manifest, error) {
+	ctx := context.Background()
+	d, err := newDeployer(ctx, td.backend, td.auth, txutil.FeeConfig{}, td.waitConfig(), t.TempDir(), td.manifestPath, td.game, td.factory, force)
+	if err != nil {
+		return nil, err
+	}
+	if err := d.run(ctx); err != nil {
+		return nil, err
+	}
+	return manifest.Load(td.manifestPath)
+}
+
+// waitConfig mines a block on every wait check
+func (td *testDeployment) waitConfig() txutil.WaitConfig {
+	return txutil.WaitConfig{
+		PollInterval: time.Millisecond * 10,
+		OnPending: func(*types.Receipt, uint64, uint64) {
+			td.backend.Commit()
+		},
+	}
+}
+
+func (td *testDeployment) nonce(t *testing.T) uint64 {
+	nonce, err := td.backend.NonceAt(context.Background(), td.auth.From, nil)
+	require.NoError(t, err)
+	return nonce
+}
+
+func TestDeployIsIdempotent(t *testing.T) {
+	td := newTestDeployment(t)
+	m, err := td.run(t, false)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1337), m.ChainID)
+	assert.Equal(t, td.auth.From, m.Deployer)
+	assert.False(t, m.Verifier.Pending())
+	assert.False(t, m.ZKOnacci.Pending())
+	assert.False(t, m.Hints.Pending())
+	assert.NotEqual(t, common.Address{}, m.Hints.Address)
+	assert.Contains(t, m.Artifacts, manifest.VerifierBytecodeArtifact)
+	assert.Equal(t, uint64(3), td.nonce(t))
+	// Nothing is deployed again
+	rerun, err := td.run(t, false)
+	require.NoError(t, err)
+	assert.Equal(t, m, rerun)
+	assert.Equal(t, uint64(3), td.nonce(t))
+	// Unless forced
+	forced, err := td.run(t, true)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(6), td.nonce(t))
+	assert.NotEqual(t, m.Verifier.Address, forced.Verifier.Address)
+	assert.NotEqual(t, m.ZKOnacci.Address, forced.ZKOnacci.Address)
+	assert.NotEqual(t, m.Hints.Address, forced.Hints.Address)
+}
+
+func TestDeployResume(t *testing.T) {
+	td := newTestDeployment(t)
+	m, err := td.run(t, false)
+	require.NoError(t, err)
+	// Interrupted after deploying the verifier: only zkOnacci and the hints are deployed
+	m.ZKOnacci = manifest.Deployment{}
+	require.NoError(t, m.Save(td.manifestPath))
+	resumed, err := td.run(t, false)
+	require.NoError(t, err)
+	assert.Equal(t, m.Verifier, resumed.Verifier)
+	assert.NotEqual(t, common.Address{}, resumed.ZKOnacci.Address)
+	assert.NotEqual(t, m.Hints.Address, resumed.Hints.Address)
+	assert.Equal(t, uint64(5), td.nonce(t))
+	zkOnacci, err := contracts.NewZKOnacci(resumed.ZKOnacci.Address, td.backend)
+	require.NoError(t, err)
+	tokenCounter, err := zkOnacci.TokenCounter(&bind.CallOpts{}, big.NewInt(0))
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), tokenCounter.Int64())
+	// Interrupted while the zkOnacci deployment tx is pending: the tx is waited for instead of deploying again
+	td.auth.Nonce = nil
+	addr, tx, _, err := contracts.DeployZKOnacci(
+		td.auth, td.backend, resumed.Verifier.Address, td.game.GenesisRoot, td.game.BaseURI, td.game.TokenTiers(), td.game.TokenURIs(), td.auth.From,
+	)
+	require.NoError(t, err)
+	resumed.ZKOnacci = manifest.Deployment{Address: addr, TxHash: tx.Hash()}
+	resumed.Hints = manifest.Deployment{}
+	require.NoError(t, resumed.Save(td.manifestPath))
+	_, err = manifest.Load(td.manifestPath)
+	assert.Error(t, err)
+	waited, err := td.run(t, false)
+	require.NoError(t, err)
+	assert.Equal(t, addr, waited.ZKOnacci.Address)
+	assert.NotZero(t, waited.ZKOnacci.BlockNumber)
+	assert.Equal(t, uint64(7), td.nonce(t))
+	hints, err := contracts.NewZKOnacciHints(waited.Hints.Address, td.backend)
+	require.NoError(t, err)
+	hintsZKOnacci, err := hints.ZkOnacci(&bind.CallOpts{})
+	require.NoError(t, err)
+	assert.Equal(t, addr, hintsZKOnacci)
+	// Missing code
+	waited.Verifier.Address = common.Address{1}
+	require.NoError(t, waited.Save(td.manifestPath))
+	_, err = td.run(t, false)
+	assert.Error(t, err)
+}
+
+func TestDeployResumeOtherBuild(t *testing.T) {
+	td := newTestDeployment(t)
+	m, err := td.run(t, false)
+	require.NoError(t, err)
+	// Deployed with another verifier bytecode
+	otherArtifacts := *m
+	otherArtifacts.Artifacts = map[string]string{manifest.VerifierBytecodeArtifact: "00"}
+	require.NoError(t, otherArtifacts.Save(td.manifestPath))
+	_, err = td.run(t, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "-force")
+	// Another contract at the address of zkOnacci
+	otherCode := *m
+	otherCode.ZKOnacci.Address = m.Verifier.Address
+	require.NoError(t, otherCode.Save(td.manifestPath))
+	_, err = td.run(t, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "doesn't match the current build")
+	assert.Equal(t, uint64(3), td.nonce(t))
+	// Nothing is deployed until forced
+	forced, err := td.run(t, true)
+	require.NoError(t, err)
+	assert.NotEqual(t, m.Verifier.Address, forced.Verifier.Address)
+	assert.Equal(t, uint64(6), td.nonce(t))
+}
+
+func TestDeployOtherChain(t *testing.T) {
+	td := newTestDeployment(t)
+	m := &manifest.Manifest{ChainID: 1, ZKOnacci: manifest.Deployment{Address: common.Address{1}}}
+	require.NoError(t, m.Save(td.manifestPath))
+	_, err := td.run(t, false)
+	assert.Error(t, err)
+	_, err = td.run(t, true)
+	assert.NoError(t, err)
+}
+
+func TestDeployCreate2(t *testing.T) {
+	// The factory gets the same address on every chain
+	privateKey := testutil.NewKey(t)
+	deployments := []*testDeployment{newTestDeploymentWithKey(t, privateKey), newTestDeploymentWithKey(t, privateKey)}
+	manifests := make([]*manifest.Manifest, len(deployments))
+	for i, td := range deployments {
+		factoryAddr, err := deployFactory(context.Background(), td.backend, td.auth, txutil.FeeConfig{}, td.waitConfig())
+		require.NoError(t, err)
+		td.factory = &factoryConfig{address: factoryAddr, salt: parseSalt("zkOnacci")}
+		td.auth.Nonce = nil
+		manifests[i], err = td.run(t, false)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(4), td.nonce(t))
+	}
+	td := deployments[0]
+	m := manifests[0]
+	assert.Equal(t, m.Factory, manifests[1].Factory)
+	assert.Equal(t, m.Salt, manifests[1].Salt)
+	assert.Equal(t, m.Verifier.Address, manifests[1].Verifier.Address)
+	assert.Equal(t, m.ZKOnacci.Address, manifests[1].ZKOnacci.Address)
+	assert.Equal(t, m.Hints.Address, manifests[1].Hints.Address)
+	// The contracts are deployed at the predicted addresses
+	predicted, err := td.factory.predictAddresses(td.game, td.auth.From)
+	require.NoError(t, err)
+	assert.Equal(t, predicted.verifier, m.Verifier.Address)
+	assert.Equal(t, predicted.zkOnacci, m.ZKOnacci.Address)
+	assert.Equal(t, predicted.hints, m.Hints.Address)
+	zkOnacci, err := contracts.NewZKOnacci(predicted.zkOnacci, td.backend)
+	require.NoError(t, err)
+	root, err := zkOnacci.Root(&bind.CallOpts{}, big.NewInt(0))
+	require.NoError(t, err)
+	assert.Equal(t, td.game.GenesisRoot, root)
+
+	// The contracts that already have code at the predicted addresses are not deployed again
+	forced, err := td.run(t, true)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), td.nonce(t))
+	assert.Equal(t, m.Verifier.Address, forced.Verifier.Address)
+	assert.Equal(t, m.ZKOnacci, forced.ZKOnacci)
+	assert.Equal(t, m.Hints, forced.Hints)
+	// Another salt gives other addresses
+	td.factory = &factoryConfig{address: m.Factory, salt: parseSalt("other")}
+	_, err = td.run(t, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "-force")
+	salted, err := td.run(t, true)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(7), td.nonce(t))
+	assert.NotEqual(t, m.Verifier.Address, salted.Verifier.Address)
+	assert.NotEqual(t, m.ZKOnacci.Address, salted.ZKOnacci.Address)
+}
+
+func TestParseSalt(t *testing.T) {
+	salt := "0x0000000000000000000000000000000000000000000000000000000000000001"
+	assert.Equal(t, common.HexToHash(salt), parseSalt(salt))
+	assert.Equal(t, crypto.Keccak256Hash([]byte("zkOnacci")), parseSalt("zkOnacci"))
+}
+
+func TestStartSeason(t *testing.T) {
+	ctx := context.Background()
+	td := newTestDeployment(t)
+	// A single token per season
+	td.game.Tiers = []game.Tier{{LastTokenID: 0, URI: "first"}}
+	m, err := td.run(t, false)
+	require.NoError(t, err)
+	zkOnacci, err := contracts.NewZKOnacci(m.ZKOnacci.Address, td.backend)
+	require.NoError(t, err)
+	next := &game.Definition{GenesisRoot: td.game.GenesisRoot, BaseURI: "ipfs://", Tiers: []game.Tier{{LastTokenID: 1, URI: "second"}}}
+	// The tokens of the current season must be minted first
+	_, err = startSeason(ctx, td.backend, td.auth, txutil.FeeConfig{}, td.waitConfig(), m.ZKOnacci.Address, 0, next, nil, 0)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "SEASON_NOT_FINISHED")
+	capture := testutil.ProveFirstCapture(t, td.auth.From)
+	td.auth.Nonce = nil
+	_, err = zkOnacci.CaptureTheFlag(td.auth, big.NewInt(0), capture.ProofA, capture.ProofB, capture.ProofC, capture.NextRoot)
+	require.NoError(t, err)
+	td.backend.Commit()
+
+	// New season with a new verifier
+	season, err := startSeason(ctx, td.backend, td.auth, txutil.FeeConfig{}, td.waitConfig(), m.ZKOnacci.Address, 0, next, buildVerifier(td.backend), game.Order)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), season)
+	callOpts := &bind.CallOpts{}
+	settings, err := zkOnacci.Season(callOpts, big.NewInt(0), big.NewInt(1))
+	require.NoError(t, err)
+	assert.Equal(t, []uint16{1}, settings.SeasonTokenTiers)
+	assert.Equal(t, []string{"second"}, settings.SeasonTokenURIs)
+	assert.NotEqual(t, m.Verifier.Address, settings.VerifierAddr)
+	code, err := td.backend.CodeAt(ctx, settings.VerifierAddr, nil)
+	require.NoError(t, err)
+	assert.NotEmpty(t, code)
+	uri, err := zkOnacci.TokenURI(callOpts, big.NewInt(0))
+	require.NoError(t, err)
+	assert.Equal(t, td.game.BaseURI+"first", uri)
+
+	// Only the owner can start seasons
+	other := newTestDeployment(t)
+	_, err = startSeason(ctx, td.backend, other.auth, txutil.FeeConfig{}, td.waitConfig(), m.ZKOnacci.Address, 0, next, nil, 0)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "only the owner")
+}
+
+func TestStartRecurrenceSeason(t *testing.T) {
+	ctx := context.Background()
+	td := newTestDeployment(t)
+	td.game.Tiers = []game.Tier{{LastTokenID: 0, URI: "first"}}
+	m, err := td.run(t, false)
+	require.NoError(t, err)
+	zkOnacci, err := contracts.NewZKOnacci(m.ZKOnacci.Address, td.backend)
+	require.NoError(t, err)
+	capture := testutil.ProveFirstCapture(t, td.auth.From)
+	td.auth.Nonce = nil
+	_, err = zkOnacci.CaptureTheFlag(td.auth, big.NewInt(0), capture.ProofA, capture.ProofB, capture.ProofC, capture.NextRoot)
+	require.NoError(t, err)
+	td.backend.Commit()
+
+	// The verification key of the variant is taken from its artifacts directory
+	trapdoor := testutil.NewGroth16Trapdoor(t)
+	artifactsPath := t.TempDir()
+	_, _, err = recurrenceVerifier("lucas", artifactsPath, testutil.NLevels, &game.Definition{}, td.backend)
+	assert.Error(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(artifactsPath, "lucas"), 0755))
+	vkPath := filepath.Join(artifactsPath, "lucas", recurrence.VerificationKeyFile)
+	require.NoError(t, ioutil.WriteFile(vkPath, trapdoor.VerificationKeyJSON(t), 0644))
+	next := &game.Definition{GenesisRoot: td.game.GenesisRoot, BaseURI: "ipfs://", Tiers: []game.Tier{{LastTokenID: 1, URI: "lucas"}}}
+	deployVerifier, order, err := recurrenceVerifier("lucas", artifactsPath, testutil.NLevels, next, td.backend)
+	require.NoError(t, err)
+	assert.Equal(t, uint8(2), order)
+	lucas, err := recurrence.Load("lucas")
+	require.NoError(t, err)
+	lucasRoot, err := lucas.GenesisRoot(testutil.NLevels)
+	require.NoError(t, err)
+	assert.Equal(t, lucasRoot, next.GenesisRoot)
+	season, err := startSeason(ctx, td.backend, td.auth, txutil.FeeConfig{}, td.waitConfig(), m.ZKOnacci.Address, 0, next, deployVerifier, order)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), season)
+
+	// The flags of the season are captured with proofs of the Lucas sequence
+	tree, err := recurrence.NewTree(lucas, testutil.NLevels)
+	require.NoError(t, err)
+	_, currentRoot, nextRoot, err := tree.NextInput(td.auth.From)
+	require.NoError(t, err)
+	root, err := zkOnacci.Root(&bind.CallOpts{}, big.NewInt(0))
+	require.NoError(t, err)
+	assert.Equal(t, currentRoot.BigInt(), root)
+	sender := new(big.Int).SetBytes(td.auth.From.Bytes())
+	proofA, proofB, proofC := trapdoor.Prove([3]*big.Int{sender, currentRoot.BigInt(), nextRoot.BigInt()})
+	td.auth.Nonce = nil
+	_, err = zkOnacci.CaptureTheFlag(td.auth, big.NewInt(0), proofA, proofB, proofC, nextRoot.BigInt())
+	require.NoError(t, err)
+	td.backend.Commit()
+	owner, err := zkOnacci.OwnerOf(&bind.CallOpts{}, game.TokenID(0, 1, 0))
+	require.NoError(t, err)
+	assert.Equal(t, td.auth.From, owner)
+}
+
+func TestAddPuzzle(t *testing.T) {
+	ctx := context.Background()
+	td := newTestDeployment(t)
+	m, err := td.run(t, false)
+	require.NoError(t, err)
+	zkOnacci, err := contracts.NewZKOnacci(m.ZKOnacci.Address, td.backend)
+	require.NoError(t, err)
+	callOpts := &bind.CallOpts{}
+
+	// A puzzle with another game definition shares the verifier of the puzzle 0
+	other := &game.Definition{GenesisRoot: td.game.GenesisRoot, BaseURI: "ipfs://", Tiers: []game.Tier{{LastTokenID: 0, URI: "other"}}}
+	puzzle, err := addPuzzle(ctx, td.backend, td.auth, txutil.FeeConfig{}, td.waitConfig(), m.ZKOnacci.Address, other, nil, 0)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), puzzle)
+	verifierAddr, err := zkOnacci.Verifier(callOpts, big.NewInt(1))
+	require.NoError(t, err)
+	assert.Equal(t, m.Verifier.Address, verifierAddr)
+	order, err := zkOnacci.Order(callOpts, big.NewInt(1))
+	require.NoError(t, err)
+	assert.Equal(t, uint8(game.Order), order)
+
+	// A recurrence variant runs next to the puzzle 0, with its own verifier
+	trapdoor := testutil.NewGroth16Trapdoor(t)
+	artifactsPath := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(artifactsPath, "pell"), 0755))
+	vkPath := filepath.Join(artifactsPath, "pell", recurrence.VerificationKeyFile)
+	require.NoError(t, ioutil.WriteFile(vkPath, trapdoor.VerificationKeyJSON(t), 0644))
+	pellGame := &game.Definition{BaseURI: "ipfs://", Tiers: []game.Tier{{LastTokenID: 3, URI: "pell"}}}
+	deployVerifier, order, err := recurrenceVerifier("pell", artifactsPath, testutil.NLevels, pellGame, td.backend)
+	require.NoError(t, err)
+	puzzle, err = addPuzzle(ctx, td.backend, td.auth, txutil.FeeConfig{}, td.waitConfig(), m.ZKOnacci.Address, pellGame, deployVerifier, order)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), puzzle)
+	nPuzzles, err := zkOnacci.NPuzzles(callOpts)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), nPuzzles.Int64())
+	pell, err := recurrence.Load("pell")
+	require.NoError(t, err)
+	tree, err := recurrence.NewTree(pell, testutil.NLevels)
+	require.NoError(t, err)
+	_, currentRoot, nextRoot, err := tree.NextInput(td.auth.From)
+	require.NoError(t, err)
+	sender := new(big.Int).SetBytes(td.auth.From.Bytes())
+	proofA, proofB, proofC := trapdoor.Prove([3]*big.Int{sender, currentRoot.BigInt(), nextRoot.BigInt()})
+	td.auth.Nonce = nil
+	// The proof is only valid for the puzzle of its verifier
+	_, err = zkOnacci.CaptureTheFlag(td.auth, big.NewInt(0), proofA, proofB, proofC, nextRoot.BigInt())
+	require.Error(t, err)
+	_, err = zkOnacci.CaptureTheFlag(td.auth, big.NewInt(2), proofA, proofB, proofC, nextRoot.BigInt())
+	require.NoError(t, err)
+	td.backend.Commit()
+	owner, err := zkOnacci.OwnerOf(callOpts, game.TokenID(2, 0, 0))
+	require.NoError(t, err)
+	assert.Equal(t, td.auth.From, owner)
+	tokenCounter, err := zkOnacci.TokenCounter(callOpts, big.NewInt(0))
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), tokenCounter.Int64())
+
+	// Only the owner can add puzzles
+	notOwner := newTestDeployment(t)
+	_, err = addPuzzle(ctx, td.backend, notOwner.auth, txutil.FeeConfig{}, td.waitConfig(), m.ZKOnacci.Address, other, nil, 0)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "only the owner")
+}
+
+func TestSetCaptureLimits(t *testing.T) {
+	ctx := context.Background()
+	td := newTestDeployment(t)
+	m, err := td.run(t, false)
+	require.NoError(t, err)
+	zkOnacci, err := contracts.NewZKOnacci(m.ZKOnacci.Address, td.backend)
+	require.NoError(t, err)
+	callOpts := &bind.CallOpts{}
+
+	// The game definition has no limits, as the deployed season
+	set, err := setCaptureLimits(ctx, td.backend, td.auth, txutil.FeeConfig{}, td.waitConfig(), m.ZKOnacci.Address, 0, td.game)
+	require.NoError(t, err)
+	assert.False(t, set)
+	limited := *td.game
+	limited.MaxCaptures = 3
+	limited.Tiers = append([]game.Tier{}, td.game.Tiers...)
+	limited.Tiers[0].MaxCaptures = 1
+	set, err = setCaptureLimits(ctx, td.backend, td.auth, txutil.FeeConfig{}, td.waitConfig(), m.ZKOnacci.Address, 0, &limited)
+	require.NoError(t, err)
+	assert.True(t, set)
+	limits, err := zkOnacci.CaptureLimits(callOpts, big.NewInt(0))
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), limits.MaxCaptures.Int64())
+	require.Len(t, limits.MaxTierCaptures, len(limited.Tiers))
+	assert.Equal(t, int64(1), limits.MaxTierCaptures[0].Int64())
+	assert.Equal(t, int64(0), limits.MaxTierCaptures[1].Int64())
+	nonce := td.nonce(t)
+	set, err = setCaptureLimits(ctx, td.backend, td.auth, txutil.FeeConfig{}, td.waitConfig(), m.ZKOnacci.Address, 0, &limited)
+	require.NoError(t, err)
+	assert.False(t, set)
+	assert.Equal(t, nonce, td.nonce(t))
+
+	// New puzzles get the limits of their game definition
+	other := &game.Definition{GenesisRoot: td.game.GenesisRoot, BaseURI: "ipfs://", Tiers: []game.Tier{{LastTokenID: 3, URI: "other"}}, MaxCaptures: 2}
+	puzzle, err := addPuzzle(ctx, td.backend, td.auth, txutil.FeeConfig{}, td.waitConfig(), m.ZKOnacci.Address, other, nil, 0)
+	require.NoError(t, err)
+	limits, err = zkOnacci.CaptureLimits(callOpts, new(big.Int).SetUint64(puzzle))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), limits.MaxCaptures.Int64())
+	assert.Empty(t, limits.MaxTierCaptures)
+}
+
+func TestSetTierRewards(t *testing.T) {
+	ctx := context.Background()
+	td := newTestDeployment(t)
+	m, err := td.run(t, false)
+	require.NoError(t, err)
+	zkOnacci, err := contracts.NewZKOnacci(m.ZKOnacci.Address, td.backend)
+	require.NoError(t, err)
+
+	set, err := setTierRewards(ctx, td.backend, td.auth, txutil.FeeConfig{}, td.waitConfig(), m.ZKOnacci.Address, 0, td.game)
+	require.NoError(t, err)
+	assert.False(t, set)
+	rewarded := *td.game
+	rewarded.Tiers = append([]game.Tier{}, td.game.Tiers...)
+	rewarded.Tiers[1].Reward = big.NewInt(1000)
+	set, err = setTierRewards(ctx, td.backend, td.auth, txutil.FeeConfig{}, td.waitConfig(), m.ZKOnacci.Address, 0, &rewarded)
+	require.NoError(t, err)
+	assert.True(t, set)
+	tierRewards, err := zkOnacci.TierRewards(&bind.CallOpts{}, big.NewInt(0))
+	require.NoError(t, err)
+	require.Len(t, tierRewards, len(rewarded.Tiers))
+	assert.Equal(t, int64(0), tierRewards[0].Int64())
+	assert.Equal(t, int64(1000), tierRewards[1].Int64())
+	set, err = setTierRewards(ctx, td.backend, td.auth, txutil.FeeConfig{}, td.waitConfig(), m.ZKOnacci.Address, 0, &rewarded)
+	require.NoError(t, err)
+	assert.False(t, set)
+
+	// Only the owner can set them
+	notOwner := newTestDeployment(t)
+	_, err = setTierRewards(ctx, td.backend, notOwner.auth, txutil.FeeConfig{}, td.waitConfig(), m.ZKOnacci.Address, 0, td.game)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "only the owner")
+}
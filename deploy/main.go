@@ -2,117 +2,205 @@ package main
 
 import (
 	"context"
-	"crypto/ecdsa"
+	"flag"
 	"fmt"
-	"math/big"
-	"os"
-	"time"
 
-	"github.com/arnaubennassar/zkOnacci/contracts"
-	"github.com/ethereum/go-ethereum/accounts/abi/bind"
-	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/arnaubennassar/zkOnacci/config"
+	"github.com/arnaubennassar/zkOnacci/game"
+	"github.com/arnaubennassar/zkOnacci/manifest"
+	"github.com/arnaubennassar/zkOnacci/signer"
+	"github.com/arnaubennassar/zkOnacci/txutil"
+	"github.com/arnaubennassar/zkOnacci/verify"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 )
 
 func main() {
-	web3URL := os.Getenv("WEB3_URL")
-	if web3URL == "" {
-		panic("Must provide the env var WEB3_URL")
-	}
-	client, err := ethclient.Dial(web3URL)
+	configFlags := config.RegisterFlags(flag.CommandLine)
+	confirmations := flag.Uint64("confirmations", 1, "blocks needed on top of each deployment tx, including its own block")
+	force := flag.Bool("force", false, "ignore the existing manifest and deploy all the contracts again")
+	factoryAddr := flag.String("factory", "", "address of a CREATE2 factory to deploy the contracts through, so they get the same addresses on every chain")
+	salt := flag.String("salt", "zkOnacci", "CREATE2 salt, either 32 bytes in hex or a string that is hashed with keccak256")
+	deployer := flag.String("deployer", "", "address of the deployer, which owns zkOnacci and the hints contract (used by predict, defaults to the signer address)")
+	newVerifier := flag.Bool("new-verifier", false, "deploy the verifier of the current build for the new season or puzzle instead of reusing the current one (season and puzzle)")
+	flag.Parse()
+	// The manifest is written by this command instead of loaded
+	configFlags.SkipManifest = true
+	conf, err := configFlags.Load()
 	if err != nil {
 		panic(err)
 	}
-	privKeyStr := os.Getenv("PRIVATE_KEY")
-	if privKeyStr == "" {
-		panic("Must provide the env var PRIVATE_KEY")
+	manifestPath := conf.ManifestPath
+	if manifestPath == "" {
+		manifestPath = "deployment.json"
 	}
-
-	privateKey, err := crypto.HexToECDSA(privKeyStr)
+	// The game definition is validated before sending any tx
+	def, err := game.Load(conf.GamePath, conf.NLevels)
 	if err != nil {
 		panic(err)
 	}
-
-	publicKey := privateKey.Public()
-	publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
-	if !ok {
-		panic("error casting public key to ECDSA")
+	var factory *factoryConfig
+	if *factoryAddr != "" {
+		if !common.IsHexAddress(*factoryAddr) {
+			panic(fmt.Sprintf("Invalid factory address: %s", *factoryAddr))
+		}
+		factory = &factoryConfig{address: common.HexToAddress(*factoryAddr), salt: parseSalt(*salt)}
 	}
-
-	fromAddress := crypto.PubkeyToAddress(*publicKeyECDSA)
-	nonce, err := client.PendingNonceAt(context.Background(), fromAddress)
+	subcommand := flag.Arg(0)
+	if conf.Recurrence != "" && subcommand != "season" && subcommand != "puzzle" {
+		panic("The recurrence variants are played as seasons or puzzles: deploy the game and then run the season or puzzle subcommand with -recurrence")
+	}
+	switch subcommand {
+	case "", "factory", "season", "puzzle", "limits", "tier-rewards":
+	case "predict":
+		if factory == nil {
+			panic("Must provide the address of the CREATE2 factory (-factory flag)")
+		}
+		deployerAddr := conf.Signer.Address
+		if *deployer != "" {
+			if !common.IsHexAddress(*deployer) {
+				panic(fmt.Sprintf("Invalid deployer address: %s", *deployer))
+			}
+			deployerAddr = common.HexToAddress(*deployer)
+		}
+		if deployerAddr == (common.Address{}) {
+			panic("Must provide the address of the deployer (-deployer flag or the signer address)")
+		}
+		predicted, err := factory.predictAddresses(def, deployerAddr)
+		if err != nil {
+			panic(err)
+		}
+		predicted.print()
+		return
+	default:
+		panic(fmt.Sprintf("Unknown subcommand %s, use predict, factory, season, puzzle, limits, tier-rewards or no subcommand to deploy the contracts", subcommand))
+	}
+	if conf.Web3URL == "" {
+		panic("Must provide the web3 URL (web3URL of the profile, env var WEB3_URL or -web3-url flag)")
+	}
+	client, err := ethclient.Dial(conf.Web3URL)
 	if err != nil {
 		panic(err)
 	}
-
-	gasPrice, err := client.SuggestGasPrice(context.Background())
+	s, err := signer.New(conf.Signer)
 	if err != nil {
 		panic(err)
 	}
 
-	auth := bind.NewKeyedTransactor(privateKey)
-	auth.Nonce = big.NewInt(int64(nonce))
-	auth.Value = big.NewInt(0)      // in wei
-	auth.GasLimit = uint64(1500000) // in units
-	auth.GasPrice = gasPrice
-
-	// Deploy verifier
-	verifierAddr, tx, _, err := contracts.DeployVerifier(
-		auth,
-		client,
-	)
+	ctx := context.Background()
+	auth, err := txutil.NewTransactOpts(ctx, client, s, conf.Fees)
 	if err != nil {
 		panic(err)
 	}
-	fmt.Println("verifier deployment tx sent:")
-	fmt.Println(verifierAddr.Hex())
-	fmt.Println(tx.Hash().Hex())
-	for {
-		time.Sleep(time.Second * 15)
-		receipt, err := client.TransactionReceipt(context.Background(), tx.Hash())
+	waitConfig := txutil.WaitConfig{Confirmations: *confirmations, OnPending: txutil.PrintProgress}
+	if subcommand == "factory" {
+		addr, err := deployFactory(ctx, client, auth, conf.Fees, waitConfig)
+		if err != nil {
+			panic(err)
+		}
+		fmt.Println("CREATE2 factory deployed at", addr.Hex())
+		return
+	}
+	if subcommand == "season" || subcommand == "puzzle" || subcommand == "limits" || subcommand == "tier-rewards" {
+		// The zkOnacci of the profile, env or flags, or the one of the manifest
+		scAddr := conf.ZKOnacciAddr
+		if scAddr == (common.Address{}) {
+			m, err := manifest.Load(manifestPath)
+			if err != nil {
+				panic(fmt.Sprintf("Must provide the address of the zkOnacci SC (manifest, contracts.zkOnacci of the profile, env var SC_ADDR or -sc-addr flag): %s", err))
+			}
+			scAddr = m.ZKOnacci.Address
+		}
+		if subcommand == "limits" {
+			set, err := setCaptureLimits(ctx, client, auth, conf.Fees, waitConfig, scAddr, conf.Puzzle, def)
+			if err != nil {
+				panic(err)
+			}
+			if !set {
+				fmt.Println("The current season of the puzzle", conf.Puzzle, "already has the capture limits of the game definition")
+				return
+			}
+			fmt.Println("Capture limits of the game definition set on the current season of the puzzle", conf.Puzzle)
+			return
+		}
+		if subcommand == "tier-rewards" {
+			set, err := setTierRewards(ctx, client, auth, conf.Fees, waitConfig, scAddr, conf.Puzzle, def)
+			if err != nil {
+				panic(err)
+			}
+			if !set {
+				fmt.Println("The current season of the puzzle", conf.Puzzle, "already has the tier rewards of the game definition")
+				return
+			}
+			fmt.Println("Tier rewards of the game definition set on the current season of the puzzle", conf.Puzzle)
+			return
+		}
+		var deployVerifier verifierDeployment
+		order := uint8(game.Order)
+		if *newVerifier {
+			deployVerifier = buildVerifier(client)
+		}
+		if conf.Recurrence != "" {
+			if *newVerifier {
+				panic("-new-verifier can't be used with -recurrence, the verifier of the recurrence is always deployed")
+			}
+			if deployVerifier, order, err = recurrenceVerifier(conf.Recurrence, conf.ArtifactsPath, conf.NLevels, def, client); err != nil {
+				panic(err)
+			}
+		}
+		if subcommand == "puzzle" {
+			puzzle, err := addPuzzle(ctx, client, auth, conf.Fees, waitConfig, scAddr, def, deployVerifier, order)
+			if err != nil {
+				panic(err)
+			}
+			fmt.Println("Puzzle", puzzle, "added to", scAddr.Hex())
+			return
+		}
+		season, err := startSeason(ctx, client, auth, conf.Fees, waitConfig, scAddr, conf.Puzzle, def, deployVerifier, order)
 		if err != nil {
 			panic(err)
 		}
-		if receipt.Status == 1 {
-			fmt.Println("verifier deployed successfully")
-			break
-		} else {
-			// Wait before checking again if tx has already been forged
-			fmt.Println("tx not mined yet")
+		fmt.Println("Season", season, "of the puzzle", conf.Puzzle, "of", scAddr.Hex(), "started")
+		return
+	}
+	if factory != nil {
+		predicted, err := factory.predictAddresses(def, auth.From)
+		if err != nil {
+			panic(err)
 		}
+		fmt.Println("deploying through the CREATE2 factory", factory.address.Hex(), "to the predicted addresses:")
+		predicted.print()
+	}
+	d, err := newDeployer(ctx, client, auth, conf.Fees, waitConfig, conf.ArtifactsPath, manifestPath, def, factory, *force)
+	if err != nil {
+		panic(err)
 	}
+	if err := d.run(ctx); err != nil {
+		panic(err)
+	}
+	fmt.Println("deployment manifest written to", manifestPath)
 
-	// Deploy zkOnacci
-	gasPrice, err = client.SuggestGasPrice(context.Background())
+	// Check the deployed code and initial state
+	report, err := verify.Deployment(ctx, client, d.m, def)
 	if err != nil {
 		panic(err)
 	}
-	auth.GasPrice = gasPrice
-	auth.GasLimit = uint64(2000000) // in units
-	auth.Nonce = big.NewInt(int64(nonce + 1))
-	scAddr, tx, _, err := contracts.DeployZKOnacci(
-		auth,
-		client,
-		verifierAddr,
-	)
+	report.Print()
+	if !report.OK() {
+		panic("the deployment doesn't match the compiled artifacts and the game definition, run verify-deployment for details")
+	}
+	// The constructor doesn't take the capture limits nor the tier rewards
+	set, err := setCaptureLimits(ctx, client, auth, conf.Fees, waitConfig, d.m.ZKOnacci.Address, 0, def)
 	if err != nil {
 		panic(err)
 	}
-	fmt.Println("zkOnacci deployment tx sent:")
-	fmt.Println(scAddr.Hex()) // 0x09aC8A7DD8D00C049af7C6117ECa9E3aeD8a43Ac
-	fmt.Println(tx.Hash().Hex())
-	for {
-		time.Sleep(time.Second * 15)
-		receipt, err := client.TransactionReceipt(context.Background(), tx.Hash())
-		if err != nil {
-			panic(err)
-		}
-		if receipt.Status == 1 {
-			fmt.Println("zkOnacci deployed successfully")
-			break
-		} else {
-			// Wait before checking again if tx has already been forged
-			fmt.Println("tx not mined yet")
-		}
+	if set {
+		fmt.Println("capture limits of the game definition set")
+	}
+	if set, err = setTierRewards(ctx, client, auth, conf.Fees, waitConfig, d.m.ZKOnacci.Address, 0, def); err != nil {
+		panic(err)
+	}
+	if set {
+		fmt.Println("tier rewards of the game definition set")
 	}
 }
@@ -0,0 +1,333 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/arnaubennassar/zkOnacci/contracts"
+	"github.com/arnaubennassar/zkOnacci/game"
+	"github.com/arnaubennassar/zkOnacci/recurrence"
+	"github.com/arnaubennassar/zkOnacci/txutil"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// verifierDeployment deploys the verifier of a season
+type verifierDeployment func(opts *bind.TransactOpts) (common.Address, *types.Transaction, error)
+
+// buildVerifier deploys the verifier of the current build
+func buildVerifier(backend deployBackend) verifierDeployment {
+	return func(opts *bind.TransactOpts) (addr common.Address, tx *types.Transaction, err error) {
+		addr, tx, _, err = contracts.DeployVerifier(opts, backend)
+		return
+	}
+}
+
+// startSeason starts a new season of the puzzle of the zkOnacci at scAddr with the params of def, once all the tokens
+// of its current season are minted, along with the capture limits and tier rewards of def. If deployVerifier is set, the verifier it deploys
+// is used for the new season with order (the amount of numbers of its sequence in the genesis tree), otherwise the verifier of
+// the current season and its order are kept. Returns the number of the new season
+func startSeason(
+	ctx context.Context,
+	backend deployBackend,
+	auth *bind.TransactOpts,
+	feeConfig txutil.FeeConfig,
+	waitConfig txutil.WaitConfig,
+	scAddr common.Address,
+	puzzle uint64,
+	def *game.Definition,
+	deployVerifier verifierDeployment,
+	order uint8,
+) (uint64, error) {
+	zkOnacci, err := ownedZKOnacci(ctx, backend, auth, scAddr)
+	if err != nil {
+		return 0, err
+	}
+	var verifierAddr common.Address
+	if deployVerifier != nil {
+		if verifierAddr, err = deploySeasonVerifier(ctx, backend, auth, feeConfig, waitConfig, deployVerifier); err != nil {
+			return 0, err
+		}
+	}
+	receipt, err := sendAndWait(ctx, backend, auth, feeConfig, waitConfig, "startSeason", func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return zkOnacci.StartSeason(opts, new(big.Int).SetUint64(puzzle), verifierAddr, order, def.GenesisRoot, def.BaseURI, def.TokenTiers(), def.TokenURIs())
+	})
+	if err != nil {
+		return 0, err
+	}
+	started, err := parseSeasonStarted(zkOnacci, scAddr, receipt)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := applyCaptureLimits(ctx, backend, auth, feeConfig, waitConfig, zkOnacci, puzzle, def); err != nil {
+		return 0, err
+	}
+	if _, err := applyTierRewards(ctx, backend, auth, feeConfig, waitConfig, zkOnacci, puzzle, def); err != nil {
+		return 0, err
+	}
+	return started.Season.Uint64(), nil
+}
+
+// addPuzzle registers a new puzzle on the zkOnacci at scAddr with the params, capture limits and tier rewards of def. If deployVerifier is set,
+// the verifier it deploys is used for the puzzle with order, otherwise it shares the verifier of the puzzle 0 and its order.
+// Returns the ID of the new puzzle
+func addPuzzle(
+	ctx context.Context,
+	backend deployBackend,
+	auth *bind.TransactOpts,
+	feeConfig txutil.FeeConfig,
+	waitConfig txutil.WaitConfig,
+	scAddr common.Address,
+	def *game.Definition,
+	deployVerifier verifierDeployment,
+	order uint8,
+) (uint64, error) {
+	zkOnacci, err := ownedZKOnacci(ctx, backend, auth, scAddr)
+	if err != nil {
+		return 0, err
+	}
+	var verifierAddr common.Address
+	if deployVerifier != nil {
+		verifierAddr, err = deploySeasonVerifier(ctx, backend, auth, feeConfig, waitConfig, deployVerifier)
+	} else {
+		callOpts := &bind.CallOpts{Context: ctx}
+		if verifierAddr, err = zkOnacci.Verifier(callOpts, big.NewInt(0)); err == nil {
+			order, err = zkOnacci.Order(callOpts, big.NewInt(0))
+		}
+	}
+	if err != nil {
+		return 0, err
+	}
+	receipt, err := sendAndWait(ctx, backend, auth, feeConfig, waitConfig, "addPuzzle", func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return zkOnacci.AddPuzzle(opts, verifierAddr, order, def.GenesisRoot, def.BaseURI, def.TokenTiers(), def.TokenURIs())
+	})
+	if err != nil {
+		return 0, err
+	}
+	started, err := parseSeasonStarted(zkOnacci, scAddr, receipt)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := applyCaptureLimits(ctx, backend, auth, feeConfig, waitConfig, zkOnacci, started.PuzzleId.Uint64(), def); err != nil {
+		return 0, err
+	}
+	if _, err := applyTierRewards(ctx, backend, auth, feeConfig, waitConfig, zkOnacci, started.PuzzleId.Uint64(), def); err != nil {
+		return 0, err
+	}
+	return started.PuzzleId.Uint64(), nil
+}
+
+// setCaptureLimits sets the capture limits of def on the current season of the puzzle of the zkOnacci at scAddr.
+// Returns false if the season already had them
+func setCaptureLimits(
+	ctx context.Context,
+	backend deployBackend,
+	auth *bind.TransactOpts,
+	feeConfig txutil.FeeConfig,
+	waitConfig txutil.WaitConfig,
+	scAddr common.Address,
+	puzzle uint64,
+	def *game.Definition,
+) (bool, error) {
+	zkOnacci, err := ownedZKOnacci(ctx, backend, auth, scAddr)
+	if err != nil {
+		return false, err
+	}
+	return applyCaptureLimits(ctx, backend, auth, feeConfig, waitConfig, zkOnacci, puzzle, def)
+}
+
+// applyCaptureLimits sends a setCaptureLimits tx if the current season of the puzzle doesn't have the limits of def
+func applyCaptureLimits(
+	ctx context.Context,
+	backend deployBackend,
+	auth *bind.TransactOpts,
+	feeConfig txutil.FeeConfig,
+	waitConfig txutil.WaitConfig,
+	zkOnacci *contracts.ZKOnacci,
+	puzzle uint64,
+	def *game.Definition,
+) (bool, error) {
+	puzzleID := new(big.Int).SetUint64(puzzle)
+	maxCaptures, maxTierCaptures := def.CaptureLimits()
+	current, err := zkOnacci.CaptureLimits(&bind.CallOpts{Context: ctx}, puzzleID)
+	if err != nil {
+		return false, err
+	}
+	if current.MaxCaptures.Cmp(maxCaptures) == 0 && equalLimits(current.MaxTierCaptures, maxTierCaptures) {
+		return false, nil
+	}
+	_, err = sendAndWait(ctx, backend, auth, feeConfig, waitConfig, "setCaptureLimits", func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return zkOnacci.SetCaptureLimits(opts, puzzleID, maxCaptures, maxTierCaptures)
+	})
+	return err == nil, err
+}
+
+// setTierRewards sets the tier rewards of def on the current season of the puzzle of the zkOnacci at scAddr.
+// Returns false if the season already had them
+func setTierRewards(
+	ctx context.Context,
+	backend deployBackend,
+	auth *bind.TransactOpts,
+	feeConfig txutil.FeeConfig,
+	waitConfig txutil.WaitConfig,
+	scAddr common.Address,
+	puzzle uint64,
+	def *game.Definition,
+) (bool, error) {
+	zkOnacci, err := ownedZKOnacci(ctx, backend, auth, scAddr)
+	if err != nil {
+		return false, err
+	}
+	return applyTierRewards(ctx, backend, auth, feeConfig, waitConfig, zkOnacci, puzzle, def)
+}
+
+// applyTierRewards sends a setTierRewards tx if the current season of the puzzle doesn't have the tier rewards of def
+func applyTierRewards(
+	ctx context.Context,
+	backend deployBackend,
+	auth *bind.TransactOpts,
+	feeConfig txutil.FeeConfig,
+	waitConfig txutil.WaitConfig,
+	zkOnacci *contracts.ZKOnacci,
+	puzzle uint64,
+	def *game.Definition,
+) (bool, error) {
+	puzzleID := new(big.Int).SetUint64(puzzle)
+	tierRewards := def.TierRewards()
+	current, err := zkOnacci.TierRewards(&bind.CallOpts{Context: ctx}, puzzleID)
+	if err != nil {
+		return false, err
+	}
+	if equalLimits(current, tierRewards) {
+		return false, nil
+	}
+	_, err = sendAndWait(ctx, backend, auth, feeConfig, waitConfig, "setTierRewards", func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return zkOnacci.SetTierRewards(opts, puzzleID, tierRewards)
+	})
+	return err == nil, err
+}
+
+// equalLimits returns true if both lists of limits or rewards are the same
+func equalLimits(a, b []*big.Int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Cmp(b[i]) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ownedZKOnacci returns the zkOnacci at scAddr, checking that the signer of auth is its owner
+func ownedZKOnacci(ctx context.Context, backend deployBackend, auth *bind.TransactOpts, scAddr common.Address) (*contracts.ZKOnacci, error) {
+	zkOnacci, err := contracts.NewZKOnacci(scAddr, backend)
+	if err != nil {
+		return nil, err
+	}
+	owner, err := zkOnacci.Owner(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return nil, err
+	}
+	if owner != auth.From {
+		return nil, fmt.Errorf("only the owner of zkOnacci (%s) can manage the puzzles, the signer is %s", owner.Hex(), auth.From.Hex())
+	}
+	return zkOnacci, nil
+}
+
+// deploySeasonVerifier sends the verifier deployment and waits for it
+func deploySeasonVerifier(
+	ctx context.Context,
+	backend deployBackend,
+	auth *bind.TransactOpts,
+	feeConfig txutil.FeeConfig,
+	waitConfig txutil.WaitConfig,
+	deployVerifier verifierDeployment,
+) (common.Address, error) {
+	var verifierAddr common.Address
+	if _, err := sendAndWait(ctx, backend, auth, feeConfig, waitConfig, "verifier deployment", func(opts *bind.TransactOpts) (tx *types.Transaction, err error) {
+		verifierAddr, tx, err = deployVerifier(opts)
+		return
+	}); err != nil {
+		return common.Address{}, err
+	}
+	fmt.Println("verifier deployed at", verifierAddr.Hex())
+	return verifierAddr, nil
+}
+
+// parseSeasonStarted gets the SeasonStarted event of the startSeason or addPuzzle tx of receipt
+func parseSeasonStarted(zkOnacci *contracts.ZKOnacci, scAddr common.Address, receipt *types.Receipt) (*contracts.ZKOnacciSeasonStarted, error) {
+	zkOnacciABI, err := abi.JSON(strings.NewReader(contracts.ZKOnacciABI))
+	if err != nil {
+		return nil, err
+	}
+	seasonStartedID := zkOnacciABI.Events["SeasonStarted"].ID
+	for _, log := range receipt.Logs {
+		if log.Address != scAddr || len(log.Topics) == 0 || log.Topics[0] != seasonStartedID {
+			continue
+		}
+		return zkOnacci.ParseSeasonStarted(*log)
+	}
+	return nil, errors.New("SeasonStarted event not found in the tx receipt")
+}
+
+// sendAndWait sends the tx built by send with the pending nonce of the sender and waits for it to be mined
+func sendAndWait(
+	ctx context.Context,
+	backend deployBackend,
+	auth *bind.TransactOpts,
+	feeConfig txutil.FeeConfig,
+	waitConfig txutil.WaitConfig,
+	name string,
+	send func(*bind.TransactOpts) (*types.Transaction, error),
+) (*types.Receipt, error) {
+	nonce, err := backend.PendingNonceAt(ctx, auth.From)
+	if err != nil {
+		return nil, err
+	}
+	auth.Nonce = new(big.Int).SetUint64(nonce)
+	if err := txutil.SetFees(ctx, backend, auth, feeConfig); err != nil {
+		return nil, err
+	}
+	tx, err := txutil.Send(auth, feeConfig, send)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Println(name, "tx sent:", tx.Hash().Hex())
+	return txutil.WaitMined(ctx, backend, tx, waitConfig)
+}
+
+// recurrenceVerifier returns the deployment of the verifier of the recurrence variant nameOrPath, built in artifactsPath,
+// along with its order, and sets the genesis root of def to the tree with its seeds
+func recurrenceVerifier(
+	nameOrPath string,
+	artifactsPath string,
+	nLevels int,
+	def *game.Definition,
+	backend deployBackend,
+) (verifierDeployment, uint8, error) {
+	r, err := recurrence.Load(nameOrPath)
+	if err != nil {
+		return nil, 0, err
+	}
+	// The sequences of higher orders start further, so the tokens may not fit the tree
+	if err := def.CheckCapacity(nLevels, r.Order()); err != nil {
+		return nil, 0, fmt.Errorf("the game definition doesn't fit %s: %w", r.Name, err)
+	}
+	vk, err := r.LoadVerifyingKey(artifactsPath)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error loading the verification key of %s, build it with circuitgen -build: %w", r.Name, err)
+	}
+	if def.GenesisRoot, err = r.GenesisRoot(nLevels); err != nil {
+		return nil, 0, err
+	}
+	return func(opts *bind.TransactOpts) (common.Address, *types.Transaction, error) {
+		return recurrence.DeployVerifier(opts, backend, vk)
+	}, uint8(r.Order()), nil
+}
@@ -0,0 +1,442 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/filters"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// clientVersion is returned by web3_clientVersion
+const clientVersion = "zkOnacci-devnet"
+
+// newHandler serves the JSON-RPC API of c over HTTP and WebSocket on the same endpoint
+func newHandler(c *chain) (http.Handler, error) {
+	server := rpc.NewServer()
+	apis := map[string]interface{}{
+		"eth":  &ethAPI{chain: c},
+		"net":  &netAPI{},
+		"web3": &web3API{},
+	}
+	for namespace, api := range apis {
+		if err := server.RegisterName(namespace, api); err != nil {
+			return nil, err
+		}
+	}
+	ws := server.WebsocketHandler([]string{"*"})
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isWebsocket(r) {
+			ws.ServeHTTP(w, r)
+			return
+		}
+		// Dapps served from other origins can query the devnet
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		if r.Method == http.MethodOptions {
+			w.Header().Set("Access-Control-Allow-Methods", "POST, GET, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+			return
+		}
+		server.ServeHTTP(w, r)
+	}), nil
+}
+
+func isWebsocket(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket") &&
+		strings.Contains(strings.ToLower(r.Header.Get("Connection")), "upgrade")
+}
+
+// ethAPI serves the eth namespace
+type ethAPI struct {
+	chain *chain
+}
+
+// ChainId returns the chain ID of the devnet
+func (api *ethAPI) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(api.chain.sim.Blockchain().Config().ChainID)
+}
+
+// BlockNumber returns the number of the latest block
+func (api *ethAPI) BlockNumber() hexutil.Uint64 {
+	return hexutil.Uint64(api.chain.sim.Blockchain().CurrentBlock().NumberU64())
+}
+
+// Accounts returns no accounts, the txs are signed by the clients
+func (api *ethAPI) Accounts() []common.Address {
+	return []common.Address{}
+}
+
+// Syncing returns false, the devnet is always in sync
+func (api *ethAPI) Syncing() bool {
+	return false
+}
+
+// GasPrice returns the base fee of the pending block plus the suggested tip
+func (api *ethAPI) GasPrice(ctx context.Context) (*hexutil.Big, error) {
+	baseFee, err := api.chain.sim.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tip, err := api.chain.sim.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(new(big.Int).Add(baseFee, tip)), nil
+}
+
+// MaxPriorityFeePerGas returns the suggested tip
+func (api *ethAPI) MaxPriorityFeePerGas(ctx context.Context) (*hexutil.Big, error) {
+	tip, err := api.chain.sim.SuggestGasTipCap(ctx)
+	return (*hexutil.Big)(tip), err
+}
+
+// GetBalance returns the balance of address
+func (api *ethAPI) GetBalance(address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (*hexutil.Big, error) {
+	stateDB, _, err := api.chain.stateAt(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(stateDB.GetBalance(address)), nil
+}
+
+// GetCode returns the code of address
+func (api *ethAPI) GetCode(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
+	if isPending(blockNrOrHash) {
+		return api.chain.sim.PendingCodeAt(ctx, address)
+	}
+	stateDB, _, err := api.chain.stateAt(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	return stateDB.GetCode(address), nil
+}
+
+// GetStorageAt returns the value of the storage slot key of address
+func (api *ethAPI) GetStorageAt(address common.Address, key common.Hash, blockNrOrHash rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
+	stateDB, _, err := api.chain.stateAt(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	return stateDB.GetState(address, key).Bytes(), nil
+}
+
+// GetTransactionCount returns the nonce of address
+func (api *ethAPI) GetTransactionCount(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (hexutil.Uint64, error) {
+	if isPending(blockNrOrHash) {
+		nonce, err := api.chain.sim.PendingNonceAt(ctx, address)
+		return hexutil.Uint64(nonce), err
+	}
+	stateDB, _, err := api.chain.stateAt(blockNrOrHash)
+	if err != nil {
+		return 0, err
+	}
+	return hexutil.Uint64(stateDB.GetNonce(address)), nil
+}
+
+// callArgs are the arguments of eth_call and eth_estimateGas
+type callArgs struct {
+	From                 *common.Address   `json:"from"`
+	To                   *common.Address   `json:"to"`
+	Gas                  *hexutil.Uint64   `json:"gas"`
+	GasPrice             *hexutil.Big      `json:"gasPrice"`
+	MaxFeePerGas         *hexutil.Big      `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big      `json:"maxPriorityFeePerGas"`
+	Value                *hexutil.Big      `json:"value"`
+	Data                 *hexutil.Bytes    `json:"data"`
+	Input                *hexutil.Bytes    `json:"input"`
+	AccessList           *types.AccessList `json:"accessList"`
+}
+
+func (args callArgs) msg() ethereum.CallMsg {
+	msg := ethereum.CallMsg{
+		To:        args.To,
+		GasPrice:  (*big.Int)(args.GasPrice),
+		GasFeeCap: (*big.Int)(args.MaxFeePerGas),
+		GasTipCap: (*big.Int)(args.MaxPriorityFeePerGas),
+		Value:     (*big.Int)(args.Value),
+	}
+	if args.From != nil {
+		msg.From = *args.From
+	}
+	if args.Gas != nil {
+		msg.Gas = uint64(*args.Gas)
+	}
+	if args.Input != nil {
+		msg.Data = *args.Input
+	} else if args.Data != nil {
+		msg.Data = *args.Data
+	}
+	if args.AccessList != nil {
+		msg.AccessList = *args.AccessList
+	}
+	return msg
+}
+
+// Call executes a message call without creating a tx
+func (api *ethAPI) Call(ctx context.Context, args callArgs, blockNrOrHash rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
+	if isPending(blockNrOrHash) {
+		return api.chain.sim.PendingCallContract(ctx, args.msg())
+	}
+	header, err := api.chain.headerAt(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	return api.chain.call(args.msg(), header)
+}
+
+// EstimateGas estimates the gas needed by a tx on top of the pending state
+func (api *ethAPI) EstimateGas(ctx context.Context, args callArgs, blockNrOrHash *rpc.BlockNumberOrHash) (hexutil.Uint64, error) {
+	gas, err := api.chain.sim.EstimateGas(ctx, args.msg())
+	return hexutil.Uint64(gas), err
+}
+
+// SendRawTransaction adds a signed tx to the pending block
+func (api *ethAPI) SendRawTransaction(ctx context.Context, input hexutil.Bytes) (common.Hash, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(input); err != nil {
+		return common.Hash{}, err
+	}
+	if err := api.chain.sendTransaction(ctx, tx); err != nil {
+		return common.Hash{}, err
+	}
+	return tx.Hash(), nil
+}
+
+// GetTransactionByHash returns the tx of hash, or null if it's unknown
+func (api *ethAPI) GetTransactionByHash(ctx context.Context, hash common.Hash) (map[string]interface{}, error) {
+	receipt, err := api.chain.sim.TransactionReceipt(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if receipt != nil {
+		block := api.chain.sim.Blockchain().GetBlockByHash(receipt.BlockHash)
+		return api.marshalTx(block.Transactions()[receipt.TransactionIndex], block, receipt.TransactionIndex)
+	}
+	tx, _, err := api.chain.sim.TransactionByHash(ctx, hash)
+	if errors.Is(err, ethereum.NotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return api.marshalTx(tx, nil, 0)
+}
+
+// GetTransactionReceipt returns the receipt of the tx of hash, or null if it's not mined
+func (api *ethAPI) GetTransactionReceipt(ctx context.Context, hash common.Hash) (map[string]interface{}, error) {
+	receipt, err := api.chain.sim.TransactionReceipt(ctx, hash)
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+	block := api.chain.sim.Blockchain().GetBlockByHash(receipt.BlockHash)
+	tx := block.Transactions()[receipt.TransactionIndex]
+	fields, err := toMap(receipt)
+	if err != nil {
+		return nil, err
+	}
+	from, err := types.Sender(api.chain.signer(), tx)
+	if err != nil {
+		return nil, err
+	}
+	fields["from"] = from
+	fields["to"] = tx.To()
+	fields["effectiveGasPrice"] = (*hexutil.Big)(effectiveGasPrice(tx, block.BaseFee()))
+	if tx.To() != nil {
+		fields["contractAddress"] = nil
+	}
+	if receipt.Logs == nil {
+		fields["logs"] = []*types.Log{}
+	}
+	return fields, nil
+}
+
+// GetBlockByNumber returns the block of number (the pending block is served by the latest one),
+// or null if it doesn't exist
+func (api *ethAPI) GetBlockByNumber(number rpc.BlockNumber, fullTx bool) (map[string]interface{}, error) {
+	blockchain := api.chain.sim.Blockchain()
+	block := blockchain.CurrentBlock()
+	if number >= 0 {
+		block = blockchain.GetBlockByNumber(uint64(number))
+	}
+	if block == nil {
+		return nil, nil
+	}
+	return api.marshalBlock(block, fullTx)
+}
+
+// GetBlockByHash returns the block of hash, or null if it doesn't exist
+func (api *ethAPI) GetBlockByHash(hash common.Hash, fullTx bool) (map[string]interface{}, error) {
+	block := api.chain.sim.Blockchain().GetBlockByHash(hash)
+	if block == nil {
+		return nil, nil
+	}
+	return api.marshalBlock(block, fullTx)
+}
+
+// GetLogs returns the logs that match crit
+func (api *ethAPI) GetLogs(ctx context.Context, crit filters.FilterCriteria) ([]types.Log, error) {
+	logs, err := api.chain.sim.FilterLogs(ctx, ethereum.FilterQuery(crit))
+	if err != nil {
+		return nil, err
+	}
+	if logs == nil {
+		logs = []types.Log{}
+	}
+	return logs, nil
+}
+
+// NewHeads notifies the header of each new block
+func (api *ethAPI) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	sub := notifier.CreateSubscription()
+	headers := make(chan *types.Header)
+	// The context of the request is done once the subscription is created
+	headSub, err := api.chain.sim.SubscribeNewHead(context.Background(), headers)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		defer headSub.Unsubscribe()
+		for {
+			select {
+			case header := <-headers:
+				_ = notifier.Notify(sub.ID, header)
+			case <-headSub.Err():
+				return
+			case <-sub.Err():
+				return
+			}
+		}
+	}()
+	return sub, nil
+}
+
+// Logs notifies the new logs that match crit
+func (api *ethAPI) Logs(ctx context.Context, crit filters.FilterCriteria) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	sub := notifier.CreateSubscription()
+	logs := make(chan types.Log)
+	logsSub, err := api.chain.sim.SubscribeFilterLogs(context.Background(), ethereum.FilterQuery(crit), logs)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		defer logsSub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				_ = notifier.Notify(sub.ID, &log)
+			case <-logsSub.Err():
+				return
+			case <-sub.Err():
+				return
+			}
+		}
+	}()
+	return sub, nil
+}
+
+// marshalBlock encodes block like geth does
+func (api *ethAPI) marshalBlock(block *types.Block, fullTx bool) (map[string]interface{}, error) {
+	fields, err := toMap(block.Header())
+	if err != nil {
+		return nil, err
+	}
+	fields["size"] = hexutil.Uint64(block.Size())
+	fields["totalDifficulty"] = (*hexutil.Big)(api.chain.sim.Blockchain().GetTd(block.Hash(), block.NumberU64()))
+	fields["uncles"] = []common.Hash{}
+	txs := make([]interface{}, len(block.Transactions()))
+	for i, tx := range block.Transactions() {
+		if !fullTx {
+			txs[i] = tx.Hash()
+			continue
+		}
+		if txs[i], err = api.marshalTx(tx, block, uint(i)); err != nil {
+			return nil, err
+		}
+	}
+	fields["transactions"] = txs
+	return fields, nil
+}
+
+// marshalTx encodes tx like geth does. block is nil for pending txs
+func (api *ethAPI) marshalTx(tx *types.Transaction, block *types.Block, index uint) (map[string]interface{}, error) {
+	fields, err := toMap(tx)
+	if err != nil {
+		return nil, err
+	}
+	from, err := types.Sender(api.chain.signer(), tx)
+	if err != nil {
+		return nil, err
+	}
+	fields["from"] = from
+	fields["blockHash"] = nil
+	fields["blockNumber"] = nil
+	fields["transactionIndex"] = nil
+	fields["gasPrice"] = (*hexutil.Big)(tx.GasFeeCap())
+	if block != nil {
+		fields["blockHash"] = block.Hash()
+		fields["blockNumber"] = (*hexutil.Big)(block.Number())
+		fields["transactionIndex"] = hexutil.Uint(index)
+		fields["gasPrice"] = (*hexutil.Big)(effectiveGasPrice(tx, block.BaseFee()))
+	}
+	return fields, nil
+}
+
+// effectiveGasPrice returns the price per gas paid by tx in a block with baseFee
+func effectiveGasPrice(tx *types.Transaction, baseFee *big.Int) *big.Int {
+	if baseFee == nil {
+		return tx.GasPrice()
+	}
+	return new(big.Int).Add(tx.EffectiveGasTipValue(baseFee), baseFee)
+}
+
+func isPending(blockNrOrHash rpc.BlockNumberOrHash) bool {
+	number, ok := blockNrOrHash.Number()
+	return ok && number == rpc.PendingBlockNumber
+}
+
+// toMap returns the JSON fields of v
+func toMap(v interface{}) (map[string]interface{}, error) {
+	content, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]interface{}{}
+	return fields, json.Unmarshal(content, &fields)
+}
+
+// netAPI serves the net namespace
+type netAPI struct{}
+
+// Version returns the network ID, which matches the chain ID
+func (api *netAPI) Version() string {
+	return strconv.Itoa(chainID)
+}
+
+// Listening returns true, the devnet accepts connections
+func (api *netAPI) Listening() bool {
+	return true
+}
+
+// web3API serves the web3 namespace
+type web3API struct{}
+
+// ClientVersion returns the name of the devnet
+func (api *web3API) ClientVersion() string {
+	return clientVersion
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"math/big"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arnaubennassar/zkOnacci/contracts"
+	"github.com/arnaubennassar/zkOnacci/manifest"
+	"github.com/arnaubennassar/zkOnacci/testutil"
+	"github.com/arnaubennassar/zkOnacci/txutil"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var devnetAddr = crypto.PubkeyToAddress(devnetKey(0).PublicKey)
+
+// newTestDevnet deploys the contracts on a devnet where the first account is funded, and serves its API
+func newTestDevnet(t *testing.T, blockTime time.Duration) (*chain, *manifest.Manifest, *httptest.Server) {
+	balance := new(big.Int).Mul(big.NewInt(10), big.NewInt(params.Ether))
+	c := newChain(core.GenesisAlloc{devnetAddr: {Balance: balance}}, blockTime)
+	m, err := c.deploy(context.Background(), devnetKey(0), testutil.ArtifactsPath, testutil.LoadGame(t))
+	require.NoError(t, err)
+	handler, err := newHandler(c)
+	require.NoError(t, err)
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return c, m, server
+}
+
+// sendTransfer sends wei to `to` from the first account
+func sendTransfer(t *testing.T, client *ethclient.Client, to common.Address, wei int64) *types.Transaction {
+	ctx := context.Background()
+	nonce, err := client.PendingNonceAt(ctx, devnetAddr)
+	require.NoError(t, err)
+	tip, err := client.SuggestGasTipCap(ctx)
+	require.NoError(t, err)
+	header, err := client.HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+	tx, err := types.SignNewTx(devnetKey(0), types.NewLondonSigner(big.NewInt(chainID)), &types.DynamicFeeTx{
+		Nonce:     nonce,
+		GasTipCap: tip,
+		GasFeeCap: new(big.Int).Add(tip, new(big.Int).Mul(header.BaseFee, big.NewInt(2))),
+		Gas:       params.TxGas,
+		To:        &to,
+		Value:     big.NewInt(wei),
+	})
+	require.NoError(t, err)
+	require.NoError(t, client.SendTransaction(ctx, tx))
+	return tx
+}
+
+func TestDevnetAutomine(t *testing.T) {
+	_, m, server := newTestDevnet(t, 0)
+	client, err := ethclient.Dial(server.URL)
+	require.NoError(t, err)
+	ctx := context.Background()
+	chainID, err := client.ChainID(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(testutil.ChainID), chainID.Int64())
+
+	// The contracts are deployed
+	code, err := client.CodeAt(ctx, m.Verifier.Address, nil)
+	require.NoError(t, err)
+	assert.NotEmpty(t, code)
+	code, err = client.CodeAt(ctx, m.Hints.Address, nil)
+	require.NoError(t, err)
+	assert.NotEmpty(t, code)
+	zkOnacci, err := contracts.NewZKOnacci(m.ZKOnacci.Address, client)
+	require.NoError(t, err)
+	tokenCounter, err := zkOnacci.TokenCounter(&bind.CallOpts{}, big.NewInt(0))
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), tokenCounter.Int64())
+	_, err = zkOnacci.TokenURI(&bind.CallOpts{}, big.NewInt(0))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "execution reverted: ERC721Metadata: URI query for nonexistent token")
+	// Historical calls run on the state of their block
+	_, err = zkOnacci.TokenCounter(&bind.CallOpts{BlockNumber: big.NewInt(0)}, big.NewInt(0))
+	require.Error(t, err)
+
+	// Txs are mined right away
+	to := common.HexToAddress("0x1234")
+	tx := sendTransfer(t, client, to, 1000)
+	receipt, err := txutil.WaitMined(ctx, client, tx, txutil.WaitConfig{PollInterval: time.Millisecond})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), receipt.BlockNumber.Uint64())
+	minedTx, isPending, err := client.TransactionByHash(ctx, tx.Hash())
+	require.NoError(t, err)
+	assert.False(t, isPending)
+	assert.Equal(t, tx.Hash(), minedTx.Hash())
+	block, err := client.BlockByNumber(ctx, receipt.BlockNumber)
+	require.NoError(t, err)
+	require.Len(t, block.Transactions(), 1)
+	assert.Equal(t, tx.Hash(), block.Transactions()[0].Hash())
+	balance, err := client.BalanceAt(ctx, to, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000), balance.Int64())
+	balance, err = client.BalanceAt(ctx, to, big.NewInt(1))
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), balance.Int64())
+
+	// Invalid txs are rejected without stopping the devnet
+	err = client.SendTransaction(ctx, tx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid transaction nonce")
+	blockNumber, err := client.BlockNumber(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), blockNumber)
+}
+
+func TestDevnetBlockTime(t *testing.T) {
+	c, _, server := newTestDevnet(t, 10*time.Millisecond)
+	client, err := ethclient.Dial("ws" + strings.TrimPrefix(server.URL, "http"))
+	require.NoError(t, err)
+	defer client.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	headers := make(chan *types.Header)
+	sub, err := client.SubscribeNewHead(ctx, headers)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	// The tx stays pending until the next block
+	tx := sendTransfer(t, client, common.HexToAddress("0x1234"), 1000)
+	_, isPending, err := client.TransactionByHash(ctx, tx.Hash())
+	require.NoError(t, err)
+	assert.True(t, isPending)
+	nonce, err := client.PendingNonceAt(ctx, devnetAddr)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), nonce)
+
+	go c.mine(ctx)
+	select {
+	case header := <-headers:
+		assert.Equal(t, uint64(2), header.Number.Uint64())
+	case err := <-sub.Err():
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no block has been mined")
+	}
+	receipt, err := txutil.WaitMined(ctx, client, tx, txutil.WaitConfig{Confirmations: 2, PollInterval: time.Millisecond})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), receipt.BlockNumber.Uint64())
+}
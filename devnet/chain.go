@@ -0,0 +1,274 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/arnaubennassar/zkOnacci/contracts"
+	"github.com/arnaubennassar/zkOnacci/game"
+	"github.com/arnaubennassar/zkOnacci/manifest"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	cmath "github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// chainID is the chain ID of the simulated backend
+	chainID = 1337
+	// blockGasLimit is the gas limit of the blocks of the devnet
+	blockGasLimit = 30000000
+)
+
+// chain is an in-process chain built on top of the simulated backend. The txs are mined as soon as they
+// are received (automine) or every blockTime
+type chain struct {
+	sim       *backends.SimulatedBackend
+	blockTime time.Duration
+	// mu serializes the txs and the blocks, so each automined tx gets its own block
+	mu sync.Mutex
+}
+
+// newChain starts a chain where the accounts of alloc are funded
+func newChain(alloc core.GenesisAlloc, blockTime time.Duration) *chain {
+	return &chain{
+		sim:       backends.NewSimulatedBackend(alloc, blockGasLimit),
+		blockTime: blockTime,
+	}
+}
+
+// devnetKey returns the deterministic private key of the i-th pre-funded account
+func devnetKey(i int) *ecdsa.PrivateKey {
+	seed := crypto.Keccak256([]byte(fmt.Sprintf("zkOnacci devnet account %d", i)))
+	key, err := crypto.ToECDSA(seed)
+	if err != nil {
+		// The keccak of the seed is a valid key with overwhelming probability
+		panic(err)
+	}
+	return key
+}
+
+// signer returns the signer that recovers the sender of any tx accepted by the devnet
+func (c *chain) signer() types.Signer {
+	return types.LatestSigner(c.sim.Blockchain().Config())
+}
+
+// sendTransaction adds tx to the pending block, which is mined right away on automine mode.
+// The simulated backend panics on invalid txs, so the panics are returned as errors
+func (c *chain) sendTransaction(ctx context.Context, tx *types.Transaction) (err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("rejected tx: %v", r)
+		}
+	}()
+	if _, err := types.Sender(c.signer(), tx); err != nil {
+		return err
+	}
+	if err := c.sim.SendTransaction(ctx, tx); err != nil {
+		return err
+	}
+	if c.blockTime == 0 {
+		c.commit()
+	}
+	return nil
+}
+
+// commit mines the pending block. Must be called holding mu
+func (c *chain) commit() {
+	c.sim.Commit()
+	block := c.sim.Blockchain().CurrentBlock()
+	fmt.Printf("Block %d mined with %d txs\n", block.NumberU64(), len(block.Transactions()))
+}
+
+// mine mines a block every blockTime until ctx is done
+func (c *chain) mine(ctx context.Context) {
+	ticker := time.NewTicker(c.blockTime)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.mu.Lock()
+			c.commit()
+			c.mu.Unlock()
+		}
+	}
+}
+
+// headerAt returns the header of the block selected by blockNrOrHash. The pending block is served
+// by the latest one
+func (c *chain) headerAt(blockNrOrHash rpc.BlockNumberOrHash) (*types.Header, error) {
+	blockchain := c.sim.Blockchain()
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		header := blockchain.GetHeaderByHash(hash)
+		if header == nil {
+			return nil, fmt.Errorf("block %s not found", hash.Hex())
+		}
+		return header, nil
+	}
+	number, _ := blockNrOrHash.Number()
+	if number < 0 {
+		return blockchain.CurrentHeader(), nil
+	}
+	header := blockchain.GetHeaderByNumber(uint64(number))
+	if header == nil {
+		return nil, fmt.Errorf("block %d not found", number)
+	}
+	return header, nil
+}
+
+// stateAt returns the state after the block selected by blockNrOrHash
+func (c *chain) stateAt(blockNrOrHash rpc.BlockNumberOrHash) (*state.StateDB, *types.Header, error) {
+	header, err := c.headerAt(blockNrOrHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	stateDB, err := c.sim.Blockchain().StateAt(header.Root)
+	if err != nil {
+		return nil, nil, err
+	}
+	return stateDB, header, nil
+}
+
+// call executes msg on top of the state after the block of header, like the simulated backend does
+// for the latest block
+func (c *chain) call(msg ethereum.CallMsg, header *types.Header) ([]byte, error) {
+	stateDB, err := c.sim.Blockchain().StateAt(header.Root)
+	if err != nil {
+		return nil, err
+	}
+	if msg.GasPrice != nil && (msg.GasFeeCap != nil || msg.GasTipCap != nil) {
+		return nil, errors.New("both gasPrice and (maxFeePerGas or maxPriorityFeePerGas) specified")
+	}
+	if msg.GasPrice == nil {
+		msg.GasPrice = new(big.Int)
+	}
+	if msg.GasFeeCap == nil {
+		msg.GasFeeCap = msg.GasPrice
+	}
+	if msg.GasTipCap == nil {
+		msg.GasTipCap = msg.GasPrice
+	}
+	if msg.Gas == 0 {
+		msg.Gas = header.GasLimit
+	}
+	if msg.Value == nil {
+		msg.Value = new(big.Int)
+	}
+	// The sender can pay for any call
+	stateDB.SetBalance(msg.From, cmath.MaxBig256)
+	coreMsg := types.NewMessage(msg.From, msg.To, 0, msg.Value, msg.Gas, msg.GasPrice, msg.GasFeeCap, msg.GasTipCap, msg.Data, msg.AccessList, false)
+	blockchain := c.sim.Blockchain()
+	evm := vm.NewEVM(core.NewEVMBlockContext(header, blockchain, nil), core.NewEVMTxContext(coreMsg), stateDB, blockchain.Config(), vm.Config{NoBaseFee: true})
+	result, err := core.ApplyMessage(evm, coreMsg, new(core.GasPool).AddGas(math.MaxUint64))
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Revert()) > 0 {
+		return nil, newRevertError(result.Revert())
+	}
+	return result.Return(), result.Err
+}
+
+// revertError is returned when a call reverts. Like geth, the revert data is sent as the data of the error
+type revertError struct {
+	error
+	data string
+}
+
+func newRevertError(data []byte) *revertError {
+	err := errors.New("execution reverted")
+	if reason, errUnpack := abi.UnpackRevert(data); errUnpack == nil {
+		err = fmt.Errorf("execution reverted: %v", reason)
+	}
+	return &revertError{error: err, data: hexutil.Encode(data)}
+}
+
+// ErrorCode returns the JSON-RPC error code of reverted calls
+func (e *revertError) ErrorCode() int {
+	return 3
+}
+
+// ErrorData returns the hex encoded revert data
+func (e *revertError) ErrorData() interface{} {
+	return e.data
+}
+
+// deploy deploys the verifier, zkOnacci (with the params of def) and the hints contract from the account of key,
+// and returns the manifest of the deployment
+func (c *chain) deploy(ctx context.Context, key *ecdsa.PrivateKey, artifactsPath string, def *game.Definition) (*manifest.Manifest, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	auth, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(chainID))
+	if err != nil {
+		return nil, err
+	}
+	verifierAddr, verifierTx, _, err := contracts.DeployVerifier(auth, c.sim)
+	if err != nil {
+		return nil, err
+	}
+	scAddr, scTx, _, err := contracts.DeployZKOnacci(
+		auth, c.sim, verifierAddr, def.GenesisRoot, def.BaseURI, def.TokenTiers(), def.TokenURIs(), auth.From,
+	)
+	if err != nil {
+		return nil, err
+	}
+	hintsAddr, hintsTx, _, err := contracts.DeployZKOnacciHints(auth, c.sim, scAddr, auth.From)
+	if err != nil {
+		return nil, err
+	}
+	c.commit()
+	verifierReceipt, err := c.minedReceipt(ctx, verifierTx)
+	if err != nil {
+		return nil, err
+	}
+	scReceipt, err := c.minedReceipt(ctx, scTx)
+	if err != nil {
+		return nil, err
+	}
+	hintsReceipt, err := c.minedReceipt(ctx, hintsTx)
+	if err != nil {
+		return nil, err
+	}
+	artifacts, err := manifest.HashArtifacts(artifactsPath)
+	if err != nil {
+		return nil, err
+	}
+	return &manifest.Manifest{
+		ChainID:   chainID,
+		Deployer:  auth.From,
+		Verifier:  manifest.NewDeployment(verifierAddr, verifierReceipt),
+		ZKOnacci:  manifest.NewDeployment(scAddr, scReceipt),
+		Hints:     manifest.NewDeployment(hintsAddr, hintsReceipt),
+		Artifacts: artifacts,
+	}, nil
+}
+
+// minedReceipt returns the receipt of a tx that has been mined successfully
+func (c *chain) minedReceipt(ctx context.Context, tx *types.Transaction) (*types.Receipt, error) {
+	receipt, err := c.sim.TransactionReceipt(ctx, tx.Hash())
+	if err != nil {
+		return nil, err
+	}
+	if receipt == nil || receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("deployment tx %s failed", tx.Hash().Hex())
+	}
+	return receipt, nil
+}
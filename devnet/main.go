@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"strings"
+
+	"github.com/arnaubennassar/zkOnacci/config"
+	"github.com/arnaubennassar/zkOnacci/game"
+	"github.com/arnaubennassar/zkOnacci/signer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func main() {
+	configFlags := config.RegisterFlags(flag.CommandLine)
+	listenAddr := flag.String("addr", "127.0.0.1:8545", "address where JSON-RPC is served over HTTP and WebSocket")
+	blockTime := flag.Duration("block-time", 0, "time between blocks (0 = mine a block for each tx)")
+	nAccounts := flag.Int("accounts", 10, "amount of pre-funded accounts")
+	balanceETH := flag.Int64("balance", 1000, "ETH of each pre-funded account")
+	fund := flag.String("fund", "", "comma separated addresses to pre-fund in addition to the accounts and the configured signer")
+	flag.Parse()
+	// The manifest is written by this command instead of loaded
+	configFlags.SkipManifest = true
+	conf, err := configFlags.Load()
+	if err != nil {
+		panic(err)
+	}
+	def, err := game.Load(conf.GamePath, conf.NLevels)
+	if err != nil {
+		panic(err)
+	}
+	manifestPath := conf.ManifestPath
+	if manifestPath == "" {
+		manifestPath = "deployment.json"
+	}
+
+	// Pre-fund the accounts
+	balance := new(big.Int).Mul(big.NewInt(*balanceETH), big.NewInt(params.Ether))
+	alloc := core.GenesisAlloc{}
+	keys := make([]*ecdsa.PrivateKey, *nAccounts)
+	for i := range keys {
+		keys[i] = devnetKey(i)
+		alloc[crypto.PubkeyToAddress(keys[i].PublicKey)] = core.GenesisAccount{Balance: balance}
+	}
+	if len(keys) == 0 {
+		panic("At least one account is needed to deploy the contracts")
+	}
+	extraAddrs, err := fundedAddresses(*fund, conf.Signer)
+	if err != nil {
+		panic(err)
+	}
+	for _, addr := range extraAddrs {
+		alloc[addr] = core.GenesisAccount{Balance: balance}
+	}
+	c := newChain(alloc, *blockTime)
+
+	// Deploy the contracts
+	ctx := context.Background()
+	m, err := c.deploy(ctx, keys[0], conf.ArtifactsPath, def)
+	if err != nil {
+		panic(err)
+	}
+	if err := m.Save(manifestPath); err != nil {
+		panic(err)
+	}
+	fmt.Println("Verifier deployed at", m.Verifier.Address.Hex())
+	fmt.Println("zkOnacci deployed at", m.ZKOnacci.Address.Hex())
+	fmt.Println("Hints deployed at", m.Hints.Address.Hex())
+	fmt.Println("deployment manifest written to", manifestPath)
+
+	fmt.Println("Pre-funded accounts (DON'T USE THESE KEYS OUTSIDE THE DEVNET):")
+	for i, key := range keys {
+		fmt.Printf("  (%d) %s %x\n", i, crypto.PubkeyToAddress(key.PublicKey).Hex(), crypto.FromECDSA(key))
+	}
+	for _, addr := range extraAddrs {
+		fmt.Printf("  %s\n", addr.Hex())
+	}
+
+	if *blockTime > 0 {
+		go c.mine(ctx)
+	}
+	handler, err := newHandler(c)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("Serving chain %d on http://%s and ws://%s\n", chainID, *listenAddr, *listenAddr)
+	panic(http.ListenAndServe(*listenAddr, handler))
+}
+
+// fundedAddresses returns the addresses of fund and the address of the configured signer (if it can be known
+// without unlocking it)
+func fundedAddresses(fund string, cfg signer.Config) ([]common.Address, error) {
+	addrs := []common.Address{}
+	for _, addr := range strings.Split(fund, ",") {
+		addr = strings.TrimSpace(addr)
+		if addr == "" {
+			continue
+		}
+		if !common.IsHexAddress(addr) {
+			return nil, fmt.Errorf("invalid address to fund: %s", addr)
+		}
+		addrs = append(addrs, common.HexToAddress(addr))
+	}
+	switch {
+	case cfg.Address != (common.Address{}):
+		addrs = append(addrs, cfg.Address)
+	case cfg.KeystorePath != "":
+		addr, err := keystoreAddress(cfg.KeystorePath)
+		if err != nil {
+			return nil, err
+		}
+		addrs = append(addrs, addr)
+	case cfg.PrivateKey != "" && cfg.AllowPrivateKey:
+		key, err := crypto.HexToECDSA(cfg.PrivateKey)
+		if err != nil {
+			return nil, err
+		}
+		addrs = append(addrs, crypto.PubkeyToAddress(key.PublicKey))
+	}
+	return addrs, nil
+}
+
+// keystoreAddress reads the address of a keystore file, which is stored unencrypted
+func keystoreAddress(path string) (common.Address, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return common.Address{}, err
+	}
+	var key struct {
+		Address string `json:"address"`
+	}
+	if err := json.Unmarshal(content, &key); err != nil {
+		return common.Address{}, err
+	}
+	if !common.IsHexAddress(key.Address) {
+		return common.Address{}, fmt.Errorf("the keystore %s doesn't have a valid address", path)
+	}
+	return common.HexToAddress(key.Address), nil
+}
@@ -0,0 +1,250 @@
+package game
+
+import (
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"math/big"
+	"path/filepath"
+	"strings"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/iden3/go-merkletree"
+	"github.com/iden3/go-merkletree/db/memory"
+)
+
+// DefinitionFile is the name of the game definition file of the NFTs directory
+const DefinitionFile = "game.json"
+
+// Order is the amount of seeds of the sequence of the zkOnacci circuit, the first token of a season proves n = Order
+const Order = 2
+
+// Tier is the on chain configuration of a tier
+type Tier struct {
+	// LastTokenID is the last token ID of the tier (tokenTiers)
+	LastTokenID uint16
+	// URI is appended to the base URI to get the token URI of the tier (tokenURIs)
+	URI string
+	// MaxCaptures is the max amount of tokens of the tier an address can capture (0 = no limit)
+	MaxCaptures uint64
+	// Reward is the amount of wei credited from the prize pool for each token of the tier (nil = no reward)
+	Reward *big.Int
+}
+
+// Definition holds the constructor params of zkOnacci
+type Definition struct {
+	// GenesisRoot is the root of the tree with the first numbers of the sequence
+	GenesisRoot *big.Int
+	// BaseURI is the prefix of the token URIs
+	BaseURI string
+	Tiers   []Tier
+	// MaxCaptures is the max amount of tokens an address can capture on a season (0 = no limit)
+	MaxCaptures uint64
+}
+
+// definitionFile is the content of a game definition file
+type definitionFile struct {
+	// GenesisRoot is a decimal string, the root of the tree with [0, 1] is used if empty
+	GenesisRoot string `json:"genesisRoot"`
+	BaseURI     string `json:"baseURI"`
+	// MaxCaptures limits the captures of each address on a season, overall and on each tier
+	MaxCaptures uint64 `json:"maxCapturesPerAddress"`
+	Tiers       []struct {
+		// Metadata is the path of the metadata of the tier, relative to the definition file.
+		// The URI of the tier is its CID
+		Metadata string `json:"metadata"`
+		// URI is used for metadata that is not available locally
+		URI         string `json:"uri"`
+		LastTokenID uint16 `json:"lastTokenId"`
+		MaxCaptures uint64 `json:"maxCapturesPerAddress"`
+		// Reward is a decimal string in wei
+		Reward string `json:"reward"`
+	} `json:"tiers"`
+}
+
+// Load reads and validates the game definition of path. The genesis root defaults to the root of the tree
+// with nLevels that holds the first two numbers of the sequence
+func Load(path string, nLevels int) (*Definition, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	file := definitionFile{}
+	if err := json.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", path, err)
+	}
+	def := &Definition{BaseURI: file.BaseURI, Tiers: make([]Tier, 0, len(file.Tiers)), MaxCaptures: file.MaxCaptures}
+	if file.GenesisRoot != "" {
+		var ok bool
+		if def.GenesisRoot, ok = new(big.Int).SetString(file.GenesisRoot, 10); !ok {
+			return nil, fmt.Errorf("invalid genesisRoot: %s", file.GenesisRoot)
+		}
+	} else if def.GenesisRoot, err = GenesisRoot(nLevels); err != nil {
+		return nil, err
+	}
+	for i, t := range file.Tiers {
+		tier := Tier{LastTokenID: t.LastTokenID, URI: t.URI, MaxCaptures: t.MaxCaptures}
+		if t.Reward != "" {
+			var ok bool
+			if tier.Reward, ok = new(big.Int).SetString(t.Reward, 10); !ok || tier.Reward.Sign() < 0 {
+				return nil, fmt.Errorf("invalid reward of tier %d: %s", i, t.Reward)
+			}
+		}
+		switch {
+		case t.Metadata != "" && t.URI != "":
+			return nil, fmt.Errorf("tier %d has both metadata and uri", i)
+		case t.Metadata != "":
+			metadata, err := ioutil.ReadFile(filepath.Join(filepath.Dir(path), t.Metadata))
+			if err != nil {
+				return nil, err
+			}
+			tier.URI = RawCID(metadata)
+		}
+		def.Tiers = append(def.Tiers, tier)
+	}
+	if err := def.Validate(nLevels); err != nil {
+		return nil, fmt.Errorf("invalid game definition %s: %w", path, err)
+	}
+	return def, nil
+}
+
+// Validate checks the rules enforced by the constructor of zkOnacci, that the genesis root is a field element
+// and that the tokens of a season fit in the tree of the zkOnacci circuit with nLevels
+func (d *Definition) Validate(nLevels int) error {
+	if d.GenesisRoot == nil || d.GenesisRoot.Sign() <= 0 || d.GenesisRoot.Cmp(bn256.Order) >= 0 {
+		return errors.New("the genesis root must be a non zero element of the field of the circuit")
+	}
+	if len(d.Tiers) == 0 {
+		return errors.New("at least one tier is needed")
+	}
+	if len(d.Tiers) > math.MaxUint8 {
+		return fmt.Errorf("too many tiers: %d, the maximum is %d", len(d.Tiers), math.MaxUint8)
+	}
+	for i, tier := range d.Tiers {
+		if tier.URI == "" {
+			return fmt.Errorf("tier %d has no metadata or uri", i)
+		}
+		if i > 0 && tier.LastTokenID <= d.Tiers[i-1].LastTokenID {
+			return fmt.Errorf("the tiers must be strictly increasing: tier %d ends at %d, after tier %d that ends at %d",
+				i, tier.LastTokenID, i-1, d.Tiers[i-1].LastTokenID)
+		}
+	}
+	return d.CheckCapacity(nLevels, Order)
+}
+
+// CheckCapacity checks that the positions of the sequence proven by the tokens of a season (the last token proves
+// n = last token ID + order) are keys of a tree with nLevels
+func (d *Definition) CheckCapacity(nLevels, order int) error {
+	if nLevels <= 0 || nLevels > 63 {
+		return fmt.Errorf("invalid nLevels: %d", nLevels)
+	}
+	if len(d.Tiers) == 0 {
+		return errors.New("at least one tier is needed")
+	}
+	lastTokenID := d.Tiers[len(d.Tiers)-1].LastTokenID
+	if lastN := uint64(lastTokenID) + uint64(order); lastN >= 1<<uint(nLevels) {
+		return fmt.Errorf("the last token (%d) proves n = %d, which doesn't fit a tree with %d levels (max n = %d)",
+			lastTokenID, lastN, nLevels, uint64(1)<<uint(nLevels)-1)
+	}
+	return nil
+}
+
+// TokenTiers returns the last token ID of each tier
+func (d *Definition) TokenTiers() []uint16 {
+	tokenTiers := make([]uint16, len(d.Tiers))
+	for i, tier := range d.Tiers {
+		tokenTiers[i] = tier.LastTokenID
+	}
+	return tokenTiers
+}
+
+// TokenURIs returns the URI of each tier
+func (d *Definition) TokenURIs() []string {
+	tokenURIs := make([]string, len(d.Tiers))
+	for i, tier := range d.Tiers {
+		tokenURIs[i] = tier.URI
+	}
+	return tokenURIs
+}
+
+// CaptureLimits returns the params of setCaptureLimits: the max captures of an address on a season and on each tier.
+// The tier limits are empty if none of the tiers has a limit
+func (d *Definition) CaptureLimits() (maxCaptures *big.Int, maxTierCaptures []*big.Int) {
+	maxTierCaptures = []*big.Int{}
+	for _, tier := range d.Tiers {
+		if tier.MaxCaptures != 0 {
+			maxTierCaptures = make([]*big.Int, len(d.Tiers))
+			for i, tier := range d.Tiers {
+				maxTierCaptures[i] = new(big.Int).SetUint64(tier.MaxCaptures)
+			}
+			break
+		}
+	}
+	return new(big.Int).SetUint64(d.MaxCaptures), maxTierCaptures
+}
+
+// TierRewards returns the params of setTierRewards: the reward of each tier, empty if none of the tiers has a reward
+func (d *Definition) TierRewards() []*big.Int {
+	for _, tier := range d.Tiers {
+		if tier.Reward != nil && tier.Reward.Sign() > 0 {
+			tierRewards := make([]*big.Int, len(d.Tiers))
+			for i, tier := range d.Tiers {
+				tierRewards[i] = new(big.Int)
+				if tier.Reward != nil {
+					tierRewards[i].Set(tier.Reward)
+				}
+			}
+			return tierRewards
+		}
+	}
+	return []*big.Int{}
+}
+
+// RawCID returns the CIDv1 (raw codec, SHA-256, base32) that IPFS gives to a file added with raw leaves
+func RawCID(content []byte) string {
+	hash := sha256.Sum256(content)
+	// CID version 1, raw codec, SHA-256 multihash of 32 bytes
+	cid := append([]byte{0x01, 0x55, 0x12, 0x20}, hash[:]...)
+	return "b" + strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(cid))
+}
+
+// GenesisRoot returns the root of the tree that holds the first two numbers of the sequence
+func GenesisRoot(nLevels int) (*big.Int, error) {
+	merkleTree, err := merkletree.NewMerkleTree(memory.NewMemoryStorage(), nLevels)
+	if err != nil {
+		return nil, err
+	}
+	if err := merkleTree.Add(big.NewInt(0), big.NewInt(0)); err != nil {
+		return nil, err
+	}
+	if err := merkleTree.Add(big.NewInt(1), big.NewInt(1)); err != nil {
+		return nil, err
+	}
+	return merkleTree.Root().BigInt(), nil
+}
+
+// SeasonShift is the amount of bits the season is shifted by in the token IDs (SEASON_SHIFT of zkOnacci)
+const SeasonShift = 16
+
+// PuzzleShift is the amount of bits the puzzle is shifted by in the token IDs (PUZZLE_SHIFT of zkOnacci)
+const PuzzleShift = 32
+
+// TokenID returns the ID of the token minted in the position index of a season of a puzzle
+func TokenID(puzzle, season, index uint64) *big.Int {
+	id := new(big.Int).Lsh(new(big.Int).SetUint64(puzzle), PuzzleShift)
+	id.Or(id, new(big.Int).Lsh(new(big.Int).SetUint64(season), SeasonShift))
+	return id.Or(id, new(big.Int).SetUint64(index))
+}
+
+// SplitTokenID returns the puzzle and season of tokenID and its position within the season
+func SplitTokenID(tokenID *big.Int) (puzzle, season, index uint64) {
+	seasonMask := big.NewInt(1<<(PuzzleShift-SeasonShift) - 1)
+	indexMask := big.NewInt(1<<SeasonShift - 1)
+	puzzle = new(big.Int).Rsh(tokenID, PuzzleShift).Uint64()
+	season = new(big.Int).And(new(big.Int).Rsh(tokenID, SeasonShift), seasonMask).Uint64()
+	return puzzle, season, new(big.Int).And(tokenID, indexMask).Uint64()
+}
@@ -0,0 +1,144 @@
+package game
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// genesisRoot is the root of the tree with [0, 1] and 6 levels
+const genesisRoot = "19733998167332688543494136895553318319796515049857122158390636597337826955912"
+
+func writeDefinition(t *testing.T, content string) string {
+	dir := t.TempDir()
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "meta.json"), []byte(`{"name": "tier"}`), 0644))
+	path := filepath.Join(dir, DefinitionFile)
+	require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestGenesisRoot(t *testing.T) {
+	root, err := GenesisRoot(6)
+	require.NoError(t, err)
+	assert.Equal(t, genesisRoot, root.String())
+}
+
+func TestLoadRepoDefinition(t *testing.T) {
+	def, err := Load("../NFTs/"+DefinitionFile, 6)
+	require.NoError(t, err)
+	assert.Equal(t, genesisRoot, def.GenesisRoot.String())
+	assert.Equal(t, "https://ipfs.io/ipfs/", def.BaseURI)
+	require.Len(t, def.Tiers, 4)
+	assert.Equal(t, Tier{LastTokenID: 2, URI: "bafkreignwngx3twej6cdn26hyaet3gg7scrtpgafkqjnkqtv37a2r6qf4u"}, def.Tiers[0])
+	assert.Equal(t, Tier{LastTokenID: 16, URI: "bafkreif5kgo5c2pool3s5bvnjo7gsxast45yioagh6tfhxfp5aae67ut6m"}, def.Tiers[3])
+	assert.Equal(t, []uint16{2, 4, 8, 16}, def.TokenTiers())
+}
+
+func TestLoad(t *testing.T) {
+	path := writeDefinition(t, `{
+		"genesisRoot": "1234",
+		"baseURI": "ipfs://",
+		"tiers": [
+			{ "metadata": "meta.json", "lastTokenId": 0 },
+			{ "uri": "remote", "lastTokenId": 10 }
+		]
+	}`)
+	def, err := Load(path, 6)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(1234), def.GenesisRoot)
+	assert.Equal(t, "ipfs://", def.BaseURI)
+	assert.Equal(t, []uint16{0, 10}, def.TokenTiers())
+	assert.Equal(t, []string{RawCID([]byte(`{"name": "tier"}`)), "remote"}, def.TokenURIs())
+	maxCaptures, maxTierCaptures := def.CaptureLimits()
+	assert.Equal(t, "0", maxCaptures.String())
+	assert.Empty(t, maxTierCaptures)
+	assert.Empty(t, def.TierRewards())
+}
+
+func TestCaptureLimits(t *testing.T) {
+	path := writeDefinition(t, `{
+		"maxCapturesPerAddress": 3,
+		"tiers": [
+			{ "uri": "first", "lastTokenId": 2, "maxCapturesPerAddress": 1 },
+			{ "uri": "second", "lastTokenId": 10 }
+		]
+	}`)
+	def, err := Load(path, 6)
+	require.NoError(t, err)
+	maxCaptures, maxTierCaptures := def.CaptureLimits()
+	assert.Equal(t, "3", maxCaptures.String())
+	assert.Equal(t, "[1 0]", fmt.Sprint(maxTierCaptures))
+}
+
+func TestTierRewards(t *testing.T) {
+	path := writeDefinition(t, `{
+		"tiers": [
+			{ "uri": "first", "lastTokenId": 2 },
+			{ "uri": "second", "lastTokenId": 10, "reward": "1000000000000000000" }
+		]
+	}`)
+	def, err := Load(path, 6)
+	require.NoError(t, err)
+	assert.Equal(t, "[0 1000000000000000000]", fmt.Sprint(def.TierRewards()))
+}
+
+func TestLoadInvalid(t *testing.T) {
+	for name, content := range map[string]string{
+		"no tiers":         `{"tiers": []}`,
+		"not increasing":   `{"tiers": [{"uri": "a", "lastTokenId": 2}, {"uri": "b", "lastTokenId": 2}]}`,
+		"decreasing":       `{"tiers": [{"uri": "a", "lastTokenId": 4}, {"uri": "b", "lastTokenId": 2}]}`,
+		"no uri":           `{"tiers": [{"lastTokenId": 2}]}`,
+		"metadata and uri": `{"tiers": [{"metadata": "meta.json", "uri": "a", "lastTokenId": 2}]}`,
+		"missing metadata": `{"tiers": [{"metadata": "other.json", "lastTokenId": 2}]}`,
+		"zero root":        `{"genesisRoot": "0", "tiers": [{"uri": "a", "lastTokenId": 2}]}`,
+		"root not in field": `{"genesisRoot": "21888242871839275222246405745257275088548364400416034343698204186575808495617",
+			"tiers": [{"uri": "a", "lastTokenId": 2}]}`,
+		"invalid root":     `{"genesisRoot": "0x12", "tiers": [{"uri": "a", "lastTokenId": 2}]}`,
+		"token ID too big": `{"tiers": [{"uri": "a", "lastTokenId": 65536}]}`,
+		"invalid reward":   `{"tiers": [{"uri": "a", "lastTokenId": 2, "reward": "1 ETH"}]}`,
+		"negative reward":  `{"tiers": [{"uri": "a", "lastTokenId": 2, "reward": "-1"}]}`,
+	} {
+		_, err := Load(writeDefinition(t, content), 6)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestCapacity(t *testing.T) {
+	// A tree with 6 levels holds the keys up to 63, so the last token proves n = 63 at most
+	path := writeDefinition(t, `{"tiers": [{"uri": "a", "lastTokenId": 2}, {"uri": "b", "lastTokenId": 61}]}`)
+	def, err := Load(path, 6)
+	require.NoError(t, err)
+	assert.NoError(t, def.CheckCapacity(6, Order))
+	// Higher orders start further
+	assert.Error(t, def.CheckCapacity(6, 3))
+	assert.NoError(t, def.CheckCapacity(7, 3))
+	def.Tiers[1].LastTokenID = 62
+	assert.Error(t, def.Validate(6))
+	assert.NoError(t, def.Validate(7))
+	assert.Error(t, def.Validate(0))
+	// Rejected on load
+	_, err = Load(writeDefinition(t, `{"tiers": [{"uri": "a", "lastTokenId": 62}]}`), 6)
+	assert.Error(t, err)
+	_, err = Load(writeDefinition(t, `{"tiers": [{"uri": "a", "lastTokenId": 62}]}`), 7)
+	assert.NoError(t, err)
+}
+
+func TestTokenID(t *testing.T) {
+	assert.Equal(t, "2", TokenID(0, 0, 2).String())
+	assert.Equal(t, "65536", TokenID(0, 1, 0).String())
+	assert.Equal(t, "131088", TokenID(0, 2, 16).String())
+	assert.Equal(t, "4295098384", TokenID(1, 2, 16).String())
+	puzzle, season, index := SplitTokenID(big.NewInt(4295098384))
+	assert.Equal(t, uint64(1), puzzle)
+	assert.Equal(t, uint64(2), season)
+	assert.Equal(t, uint64(16), index)
+	puzzle, season, index = SplitTokenID(big.NewInt(16))
+	assert.Equal(t, uint64(0), puzzle)
+	assert.Equal(t, uint64(0), season)
+	assert.Equal(t, uint64(16), index)
+}
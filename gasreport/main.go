@@ -0,0 +1,44 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+
+	"github.com/arnaubennassar/zkOnacci/config"
+	"github.com/arnaubennassar/zkOnacci/game"
+)
+
+func main() {
+	configFlags := config.RegisterFlags(flag.CommandLine)
+	gasPrices := flag.String("gas-prices", "1,10,50,100", "comma separated gas prices (in gwei) to estimate the costs at")
+	jsonOutput := flag.Bool("json", false, "print the report as JSON")
+	flag.Parse()
+	// Everything runs on a simulated backend, no deployment is needed
+	configFlags.SkipManifest = true
+	conf, err := configFlags.Load()
+	if err != nil {
+		panic(err)
+	}
+	prices, err := parseGasPrices(*gasPrices)
+	if err != nil {
+		panic(err)
+	}
+	def, err := game.Load(conf.GamePath, conf.NLevels)
+	if err != nil {
+		panic(err)
+	}
+	report, err := lifecycle(def, conf.NLevels, conf.ArtifactsPath, prices)
+	if err != nil {
+		panic(err)
+	}
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			panic(err)
+		}
+		return
+	}
+	report.print()
+}
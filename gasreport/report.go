@@ -0,0 +1,361 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/arnaubennassar/zkOnacci/contracts"
+	"github.com/arnaubennassar/zkOnacci/game"
+	"github.com/arnaubennassar/zkOnacci/recurrence"
+	"github.com/arnaubennassar/zkOnacci/testutil"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// entry is the gas used by a tx (or a group of txs) and its cost at each gas price of the report
+type entry struct {
+	Name    string `json:"name"`
+	GasUsed uint64 `json:"gasUsed"`
+	// Costs are in ETH, in the same order as the gas prices of the report
+	Costs []string `json:"costs"`
+}
+
+// capture is the mint of a token through captureTheFlag
+type capture struct {
+	entry
+	TokenID uint16 `json:"tokenId"`
+	Tier    int    `json:"tier"`
+	// Player is the index of the player that captures the token
+	Player int `json:"player"`
+	// First is set on the first mint of the tier
+	First bool `json:"first"`
+}
+
+// tierSummary breaks down the gas used by the mints of a tier
+type tierSummary struct {
+	Tier   int `json:"tier"`
+	Tokens int `json:"tokens"`
+	// FirstMint is the first mint of the tier, LaterMints the average of the rest (nil if the tier has one token)
+	FirstMint  entry  `json:"firstMint"`
+	LaterMints *entry `json:"laterMints,omitempty"`
+	Total      entry  `json:"total"`
+}
+
+// gasReport holds the gas used by each tx of the lifecycle of a game: the deployment of the contracts and
+// the mint of every token
+type gasReport struct {
+	// GasPrices are in gwei
+	GasPrices []string `json:"gasPrices"`
+	// Players is the amount of players needed to capture every token within the capture limits
+	Players    int           `json:"players"`
+	Deployment []entry       `json:"deployment"`
+	Captures   []capture     `json:"captures"`
+	Tiers      []tierSummary `json:"tiers"`
+	Totals     []entry       `json:"totals"`
+}
+
+// gasPrice is a gas price in wei, along with the gwei string it has been parsed from
+type gasPrice struct {
+	gwei string
+	wei  *big.Int
+}
+
+// parseGasPrices parses a comma separated list of gas prices in gwei (decimals are allowed, e.g. 0.5)
+func parseGasPrices(s string) ([]gasPrice, error) {
+	prices := []gasPrice{}
+	for _, gwei := range strings.Split(s, ",") {
+		gwei = strings.TrimSpace(gwei)
+		if gwei == "" {
+			continue
+		}
+		price, ok := new(big.Rat).SetString(gwei)
+		if !ok || price.Sign() < 0 {
+			return nil, fmt.Errorf("invalid gas price: %s", gwei)
+		}
+		price.Mul(price, new(big.Rat).SetInt64(params.GWei))
+		if !price.IsInt() {
+			return nil, fmt.Errorf("the gas price %s gwei is not an integer amount of wei", gwei)
+		}
+		prices = append(prices, gasPrice{gwei: gwei, wei: price.Num()})
+	}
+	if len(prices) == 0 {
+		return nil, errors.New("at least one gas price is needed")
+	}
+	return prices, nil
+}
+
+// newEntry returns the entry of gasUsed with its cost at each gas price
+func newEntry(name string, gasUsed uint64, prices []gasPrice) entry {
+	costs := make([]string, len(prices))
+	for i, price := range prices {
+		wei := new(big.Int).Mul(new(big.Int).SetUint64(gasUsed), price.wei)
+		costs[i] = new(big.Rat).SetFrac(wei, big.NewInt(params.Ether)).FloatString(6)
+	}
+	return entry{Name: name, GasUsed: gasUsed, Costs: costs}
+}
+
+// lifecycle deploys the contracts (zkOnacci with the params of def, along with its capture limits and tier rewards)
+// on a simulated backend and mints every token with proofs generated from the circom artifacts, recording the gas used
+// by each tx. zkOnacci is deployed with the genesis root of the sequence instead of the one of def, so the proofs are
+// valid. The prize pool is funded with the rewards of every token, and the tokens are captured by as many players as
+// the capture limits need
+func lifecycle(def *game.Definition, nLevels int, artifactsPath string, prices []gasPrice) (*gasReport, error) {
+	ctx := context.Background()
+	fibonacci, err := recurrence.Load("fibonacci")
+	if err != nil {
+		return nil, err
+	}
+	tree, err := recurrence.NewTree(fibonacci, nLevels)
+	if err != nil {
+		return nil, err
+	}
+	tokenPlayers := assignPlayers(def)
+	keys := make([]*ecdsa.PrivateKey, tokenPlayers[len(tokenPlayers)-1]+1)
+	for i := range keys {
+		// The owner is the first player
+		if keys[i], err = crypto.ToECDSA(crypto.Keccak256([]byte(fmt.Sprintf("zkOnacci gas report %d", i)))); err != nil {
+			return nil, err
+		}
+	}
+	prizePool := totalRewards(def)
+	balance := new(big.Int).Add(prizePool, new(big.Int).Mul(big.NewInt(1000), big.NewInt(params.Ether)))
+	sim := testutil.NewFundedSimulatedBackend(balance, keys...)
+	auths := make([]*bind.TransactOpts, len(keys))
+	for i, key := range keys {
+		if auths[i], err = bind.NewKeyedTransactorWithChainID(key, big.NewInt(testutil.ChainID)); err != nil {
+			return nil, err
+		}
+	}
+	auth := auths[0]
+	report := &gasReport{Players: len(keys)}
+	for _, price := range prices {
+		report.GasPrices = append(report.GasPrices, price.gwei)
+	}
+
+	// Deploy the contracts
+	deployment := func(name string, send func(opts *bind.TransactOpts) (*types.Transaction, error)) error {
+		tx, err := send(auth)
+		if err != nil {
+			return fmt.Errorf("error sending %s: %w", name, err)
+		}
+		gasUsed, err := minedGas(ctx, sim, tx)
+		if err != nil {
+			return err
+		}
+		report.Deployment = append(report.Deployment, newEntry(name, gasUsed, prices))
+		return nil
+	}
+	var verifierAddr, scAddr common.Address
+	var zkOnacci *contracts.ZKOnacci
+	if err := deployment("verifier", func(opts *bind.TransactOpts) (tx *types.Transaction, err error) {
+		verifierAddr, tx, _, err = contracts.DeployVerifier(opts, sim)
+		return tx, err
+	}); err != nil {
+		return nil, err
+	}
+	if err := deployment("zkOnacci", func(opts *bind.TransactOpts) (tx *types.Transaction, err error) {
+		scAddr, tx, zkOnacci, err = contracts.DeployZKOnacci(
+			opts, sim, verifierAddr, tree.Root().BigInt(), def.BaseURI, def.TokenTiers(), def.TokenURIs(), opts.From,
+		)
+		return tx, err
+	}); err != nil {
+		return nil, err
+	}
+	if err := deployment("hints", func(opts *bind.TransactOpts) (tx *types.Transaction, err error) {
+		_, tx, _, err = contracts.DeployZKOnacciHints(opts, sim, scAddr, opts.From)
+		return tx, err
+	}); err != nil {
+		return nil, err
+	}
+	puzzle := big.NewInt(0)
+	if maxCaptures, maxTierCaptures := def.CaptureLimits(); maxCaptures.Sign() > 0 || len(maxTierCaptures) > 0 {
+		if err := deployment("setCaptureLimits", func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			return zkOnacci.SetCaptureLimits(opts, puzzle, maxCaptures, maxTierCaptures)
+		}); err != nil {
+			return nil, err
+		}
+	}
+	if tierRewards := def.TierRewards(); len(tierRewards) > 0 {
+		if err := deployment("setTierRewards", func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			return zkOnacci.SetTierRewards(opts, puzzle, tierRewards)
+		}); err != nil {
+			return nil, err
+		}
+		if err := deployment("deposit", func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			depositOpts := *opts
+			depositOpts.Value = prizePool
+			return zkOnacci.Deposit(&depositOpts)
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	// Mint every token
+	tier := 0
+	for id, player := range tokenPlayers {
+		first := id == 0 || id > int(def.Tiers[tier].LastTokenID)
+		if id > int(def.Tiers[tier].LastTokenID) {
+			tier++
+		}
+		// The progress goes to stderr, so the JSON output can be piped
+		fmt.Fprintf(os.Stderr, "Minting token #%d (tier %d) by player %d\n", id, tier, player)
+		playerAuth := auths[player]
+		input, _, nextRoot, err := tree.NextInput(playerAuth.From)
+		if err != nil {
+			return nil, err
+		}
+		proofA, proofB, proofC, err := recurrence.GenerateZKOnacciProof(input, artifactsPath)
+		if err != nil {
+			return nil, err
+		}
+		tx, err := zkOnacci.CaptureTheFlag(playerAuth, puzzle, proofA, proofB, proofC, nextRoot.BigInt())
+		if err != nil {
+			return nil, fmt.Errorf("error capturing the flag of token #%d: %w", id, err)
+		}
+		gasUsed, err := minedGas(ctx, sim, tx)
+		if err != nil {
+			return nil, err
+		}
+		report.Captures = append(report.Captures, capture{
+			entry:   newEntry(fmt.Sprintf("captureTheFlag #%d", id), gasUsed, prices),
+			TokenID: uint16(id),
+			Tier:    tier,
+			Player:  player,
+			First:   first,
+		})
+	}
+	report.summarize(prices)
+	return report, nil
+}
+
+// assignPlayers returns the player that captures each token of a season of def: a player captures tokens until
+// the capture limits of def don't allow it to capture the next one, which is captured by a new player
+func assignPlayers(def *game.Definition) []int {
+	lastTokenID := def.Tiers[len(def.Tiers)-1].LastTokenID
+	players := make([]int, 0, int(lastTokenID)+1)
+	player, captures, tierCaptures := 0, uint64(0), make([]uint64, len(def.Tiers))
+	tier := 0
+	for id := 0; id <= int(lastTokenID); id++ {
+		if id > int(def.Tiers[tier].LastTokenID) {
+			tier++
+		}
+		maxTierCaptures := def.Tiers[tier].MaxCaptures
+		if (def.MaxCaptures > 0 && captures >= def.MaxCaptures) || (maxTierCaptures > 0 && tierCaptures[tier] >= maxTierCaptures) {
+			player, captures, tierCaptures = player+1, 0, make([]uint64, len(def.Tiers))
+		}
+		captures++
+		tierCaptures[tier]++
+		players = append(players, player)
+	}
+	return players
+}
+
+// totalRewards returns the rewards credited by the mints of every token of a season of def
+func totalRewards(def *game.Definition) *big.Int {
+	total := new(big.Int)
+	firstTokenID := 0
+	for _, tier := range def.Tiers {
+		if tier.Reward != nil {
+			tokens := big.NewInt(int64(tier.LastTokenID) - int64(firstTokenID) + 1)
+			total.Add(total, new(big.Int).Mul(tier.Reward, tokens))
+		}
+		firstTokenID = int(tier.LastTokenID) + 1
+	}
+	return total
+}
+
+// summarize adds the per tier breakdown and the totals of the deployment and the captures
+func (r *gasReport) summarize(prices []gasPrice) {
+	var deploymentGas, capturesGas uint64
+	for _, e := range r.Deployment {
+		deploymentGas += e.GasUsed
+	}
+	r.Tiers = nil
+	for _, c := range r.Captures {
+		capturesGas += c.GasUsed
+		if c.First {
+			r.Tiers = append(r.Tiers, tierSummary{Tier: c.Tier})
+		}
+		summary := &r.Tiers[len(r.Tiers)-1]
+		summary.Tokens++
+		summary.Total.GasUsed += c.GasUsed
+		if c.First {
+			summary.FirstMint = newEntry("first mint", c.GasUsed, prices)
+		}
+	}
+	for i := range r.Tiers {
+		summary := &r.Tiers[i]
+		if summary.Tokens > 1 {
+			later := newEntry("later mints (average)", (summary.Total.GasUsed-summary.FirstMint.GasUsed)/uint64(summary.Tokens-1), prices)
+			summary.LaterMints = &later
+		}
+		summary.Total = newEntry("total", summary.Total.GasUsed, prices)
+	}
+	r.Totals = []entry{
+		newEntry("deployment", deploymentGas, prices),
+		newEntry("captures", capturesGas, prices),
+		newEntry("game", deploymentGas+capturesGas, prices),
+	}
+}
+
+// minedGas mines tx and returns the gas it used, failing if it reverted
+func minedGas(ctx context.Context, sim testutil.SimulatedBackend, tx *types.Transaction) (uint64, error) {
+	sim.Commit()
+	receipt, err := sim.TransactionReceipt(ctx, tx.Hash())
+	if err != nil {
+		return 0, err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return 0, fmt.Errorf("tx %s reverted", tx.Hash().Hex())
+	}
+	return receipt.GasUsed, nil
+}
+
+// print writes the report as a table to stdout
+func (r *gasReport) print() {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	header := "\tgas"
+	for _, price := range r.GasPrices {
+		header += fmt.Sprintf("\tETH @ %s gwei", price)
+	}
+	row := func(name string, e entry) {
+		fmt.Fprintf(w, "%s\t%d\t%s\t\n", name, e.GasUsed, strings.Join(e.Costs, "\t"))
+	}
+	fmt.Fprintln(w, "Deployment"+header+"\t")
+	for _, e := range r.Deployment {
+		row(e.Name, e)
+	}
+	fmt.Fprintln(w, "\t")
+	fmt.Fprintln(w, "Captures"+header+"\t")
+	for _, c := range r.Captures {
+		name := fmt.Sprintf("#%d (tier %d, player %d)", c.TokenID, c.Tier, c.Player)
+		if c.First {
+			name += " first"
+		}
+		row(name, c.entry)
+	}
+	fmt.Fprintln(w, "\t")
+	fmt.Fprintln(w, "Tiers"+header+"\t")
+	for _, t := range r.Tiers {
+		row(fmt.Sprintf("tier %d first mint", t.Tier), t.FirstMint)
+		if t.LaterMints != nil {
+			row(fmt.Sprintf("tier %d later mints (avg)", t.Tier), *t.LaterMints)
+		}
+		row(fmt.Sprintf("tier %d total (%d tokens)", t.Tier, t.Tokens), t.Total)
+	}
+	fmt.Fprintln(w, "\t")
+	fmt.Fprintln(w, "Totals"+header+"\t")
+	for _, e := range r.Totals {
+		row(e.Name, e)
+	}
+	w.Flush()
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/arnaubennassar/zkOnacci/testutil"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGasPrices(t *testing.T) {
+	prices, err := parseGasPrices("1, 0.5,100")
+	require.NoError(t, err)
+	require.Len(t, prices, 3)
+	assert.Equal(t, "1000000000", prices[0].wei.String())
+	assert.Equal(t, "500000000", prices[1].wei.String())
+	assert.Equal(t, "0.5", prices[1].gwei)
+	assert.Equal(t, "100000000000", prices[2].wei.String())
+	for _, invalid := range []string{"", "abc", "-1", "0.0000000001"} {
+		_, err := parseGasPrices(invalid)
+		assert.Error(t, err, invalid)
+	}
+	assert.Equal(t, []string{"0.000021", "0.002100"}, newEntry("transfer", 21000, []gasPrice{prices[0], prices[2]}).Costs)
+}
+
+func TestLifecycle(t *testing.T) {
+	def := testutil.LoadGame(t)
+	prices, err := parseGasPrices("10,100")
+	require.NoError(t, err)
+	report, err := lifecycle(def, testutil.NLevels, testutil.ArtifactsPath, prices)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10", "100"}, report.GasPrices)
+	// No limits nor rewards
+	assert.Equal(t, 1, report.Players)
+	require.Len(t, report.Deployment, 3)
+	for _, e := range report.Deployment {
+		assert.NotZero(t, e.GasUsed)
+		assert.Len(t, e.Costs, 2)
+	}
+
+	// Every token is minted
+	lastTokenID := def.Tiers[len(def.Tiers)-1].LastTokenID
+	require.Len(t, report.Captures, int(lastTokenID)+1)
+	firsts := []uint16{}
+	for i, c := range report.Captures {
+		assert.Equal(t, uint16(i), c.TokenID)
+		assert.Equal(t, 0, c.Player)
+		assert.NotZero(t, c.GasUsed)
+		if c.First {
+			firsts = append(firsts, c.TokenID)
+		}
+	}
+	assert.Equal(t, []uint16{0, 3, 5, 9}, firsts)
+	// The first mint of the game initializes the storage of the counter and the balance of the player
+	assert.Greater(t, report.Captures[0].GasUsed, report.Captures[1].GasUsed)
+
+	// Tier breakdown
+	require.Len(t, report.Tiers, len(def.Tiers))
+	var capturesGas uint64
+	for i, tier := range report.Tiers {
+		assert.Equal(t, i, tier.Tier)
+		require.NotNil(t, tier.LaterMints)
+		capturesGas += tier.Total.GasUsed
+	}
+	assert.Equal(t, 3, report.Tiers[0].Tokens)
+	assert.Equal(t, 8, report.Tiers[3].Tokens)
+	require.Len(t, report.Totals, 3)
+	assert.Equal(t, capturesGas, report.Totals[1].GasUsed)
+	assert.Equal(t, report.Totals[0].GasUsed+report.Totals[1].GasUsed, report.Totals[2].GasUsed)
+}
+
+func TestLifecycleLimitsAndRewards(t *testing.T) {
+	def := testutil.LoadGame(t)
+	def.MaxCaptures = 5
+	def.Tiers[3].MaxCaptures = 2
+	def.Tiers[1].Reward = big.NewInt(params.Ether)
+	def.Tiers[3].Reward = big.NewInt(params.GWei)
+	// Tiers: 0-2, 3-4, 5-8 and 9-16
+	assert.Equal(t, []int{0, 0, 0, 0, 0, 1, 1, 1, 1, 1, 2, 2, 3, 3, 4, 4, 5}, assignPlayers(def))
+	assert.Equal(t, new(big.Int).Add(big.NewInt(2*params.Ether), big.NewInt(8*params.GWei)), totalRewards(def))
+	prices, err := parseGasPrices("10")
+	require.NoError(t, err)
+	// Every capture would revert if it went beyond the limits
+	report, err := lifecycle(def, testutil.NLevels, testutil.ArtifactsPath, prices)
+	require.NoError(t, err)
+	assert.Equal(t, 6, report.Players)
+	names := []string{}
+	for _, e := range report.Deployment {
+		names = append(names, e.Name)
+		assert.NotZero(t, e.GasUsed)
+	}
+	assert.Equal(t, []string{"verifier", "zkOnacci", "hints", "setCaptureLimits", "setTierRewards", "deposit"}, names)
+	require.Len(t, report.Captures, 17)
+	for i, player := range assignPlayers(def) {
+		assert.Equal(t, player, report.Captures[i].Player)
+	}
+}
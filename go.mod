@@ -9,8 +9,10 @@ require (
 	github.com/deckarep/golang-set v1.7.1 // indirect
 	github.com/ethereum/go-ethereum v1.10.6
 	github.com/go-kit/kit v0.10.0 // indirect
+	github.com/google/uuid v1.1.5
 	github.com/iden3/go-circom-prover-verifier v0.0.1
 	github.com/iden3/go-merkletree v0.1.0
+	github.com/naoina/toml v0.1.2-0.20170918210437-9fafd6967416
 	github.com/rjeczalik/notify v0.9.2 // indirect
 	github.com/status-im/keycard-go v0.0.0-20190424133014-d95853db0f48 // indirect
 	github.com/stretchr/testify v1.7.0
@@ -18,4 +20,5 @@ require (
 	golang.org/x/crypto v0.0.0-20210616213533-5ff15b29337e // indirect
 	golang.org/x/sys v0.0.0-20210630005230-0f9fa26af87c // indirect
 	gopkg.in/go-playground/assert.v1 v1.2.1
+	gopkg.in/yaml.v3 v3.0.0-20200313102051-9f266ea9e77c
 )
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/arnaubennassar/zkOnacci/contracts"
+	"github.com/arnaubennassar/zkOnacci/signer"
+	"github.com/arnaubennassar/zkOnacci/txutil"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// hintsBackend is the node the hints are published through
+type hintsBackend interface {
+	txutil.Backend
+	txutil.WaitBackend
+}
+
+// publishedHint is a hint released on chain, for a tier of a season of a puzzle of zkOnacci
+type publishedHint struct {
+	Puzzle uint64 `json:"puzzle"`
+	Season uint64 `json:"season"`
+	Tier   int    `json:"tier"`
+	Index  uint64 `json:"index"`
+	URI    string `json:"uri"`
+	// ContentHash is the keccak256 of the content of the hint (zero if it was published without it)
+	ContentHash common.Hash `json:"contentHash"`
+	BlockNumber uint64      `json:"blockNumber"`
+}
+
+// publish sends the publishHint tx signed by s (which must be the owner of the hints contract) and waits for it
+func publish(
+	ctx context.Context,
+	backend hintsBackend,
+	hintsAddr common.Address,
+	s signer.Signer,
+	feeConfig txutil.FeeConfig,
+	waitConfig txutil.WaitConfig,
+	puzzle uint64,
+	season uint64,
+	tier uint8,
+	uri string,
+	contentHash common.Hash,
+) (*publishedHint, error) {
+	hints, err := contracts.NewZKOnacciHints(hintsAddr, backend)
+	if err != nil {
+		return nil, err
+	}
+	auth, err := txutil.NewTransactOpts(ctx, backend, s, feeConfig)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := txutil.Send(auth, feeConfig, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return hints.PublishHint(opts, new(big.Int).SetUint64(puzzle), new(big.Int).SetUint64(season), tier, uri, contentHash)
+	})
+	if err != nil {
+		return nil, err
+	}
+	fmt.Println("Tx sent to the blockchain. Tx Hash:", tx.Hash().Hex())
+	receipt, err := txutil.WaitMined(ctx, backend, tx, waitConfig)
+	if err != nil {
+		return nil, err
+	}
+	hintsABI, err := abi.JSON(strings.NewReader(contracts.ZKOnacciHintsABI))
+	if err != nil {
+		return nil, err
+	}
+	publishedID := hintsABI.Events["HintPublished"].ID
+	for _, log := range receipt.Logs {
+		if log.Address != hintsAddr || len(log.Topics) == 0 || log.Topics[0] != publishedID {
+			continue
+		}
+		published, err := hints.ParseHintPublished(*log)
+		if err != nil {
+			return nil, err
+		}
+		return &publishedHint{
+			Puzzle:      published.PuzzleId.Uint64(),
+			Season:      published.Season.Uint64(),
+			Tier:        int(published.Tier),
+			Index:       published.Index.Uint64(),
+			URI:         published.Uri,
+			ContentHash: published.ContentHash,
+			BlockNumber: receipt.BlockNumber.Uint64(),
+		}, nil
+	}
+	return nil, errors.New("HintPublished event not found in the tx receipt")
+}
+
+// currentSeason returns the current season of puzzle on the zkOnacci SC of the hints contract
+func currentSeason(ctx context.Context, backend bind.ContractCaller, hintsAddr common.Address, puzzle uint64) (uint64, error) {
+	hints, err := contracts.NewZKOnacciHintsCaller(hintsAddr, backend)
+	if err != nil {
+		return 0, err
+	}
+	callOpts := &bind.CallOpts{Context: ctx}
+	scAddr, err := hints.ZkOnacci(callOpts)
+	if err != nil {
+		return 0, err
+	}
+	zkOnacci, err := contracts.NewZKOnacciCaller(scAddr, backend)
+	if err != nil {
+		return 0, err
+	}
+	season, err := zkOnacci.CurrentSeason(callOpts, new(big.Int).SetUint64(puzzle))
+	if err != nil {
+		return 0, err
+	}
+	return season.Uint64(), nil
+}
+
+// listHints returns the hints published so far on every tier of every season of the puzzles of the game
+func listHints(ctx context.Context, backend bind.ContractCaller, hintsAddr common.Address) ([]publishedHint, error) {
+	hints, err := contracts.NewZKOnacciHintsCaller(hintsAddr, backend)
+	if err != nil {
+		return nil, err
+	}
+	callOpts := &bind.CallOpts{Context: ctx}
+	scAddr, err := hints.ZkOnacci(callOpts)
+	if err != nil {
+		return nil, err
+	}
+	zkOnacci, err := contracts.NewZKOnacciCaller(scAddr, backend)
+	if err != nil {
+		return nil, err
+	}
+	nPuzzles, err := zkOnacci.NPuzzles(callOpts)
+	if err != nil {
+		return nil, err
+	}
+	list := []publishedHint{}
+	for puzzle := uint64(0); puzzle < nPuzzles.Uint64(); puzzle++ {
+		current, err := zkOnacci.CurrentSeason(callOpts, new(big.Int).SetUint64(puzzle))
+		if err != nil {
+			return nil, err
+		}
+		for season := uint64(0); season <= current.Uint64(); season++ {
+			settings, err := zkOnacci.Season(callOpts, new(big.Int).SetUint64(puzzle), new(big.Int).SetUint64(season))
+			if err != nil {
+				return nil, err
+			}
+			for tier := range settings.SeasonTokenTiers {
+				tierList, err := tierHints(callOpts, hints, puzzle, season, uint8(tier))
+				if err != nil {
+					return nil, err
+				}
+				list = append(list, tierList...)
+			}
+		}
+	}
+	return list, nil
+}
+
+// tierHints returns the hints published so far on a tier of a season of a puzzle
+func tierHints(
+	callOpts *bind.CallOpts,
+	hints *contracts.ZKOnacciHintsCaller,
+	puzzle uint64,
+	season uint64,
+	tier uint8,
+) ([]publishedHint, error) {
+	puzzleID, seasonID := new(big.Int).SetUint64(puzzle), new(big.Int).SetUint64(season)
+	count, err := hints.HintsCount(callOpts, puzzleID, seasonID, tier)
+	if err != nil {
+		return nil, err
+	}
+	list := []publishedHint{}
+	for i := uint64(0); i < count.Uint64(); i++ {
+		hint, err := hints.Hint(callOpts, puzzleID, seasonID, tier, new(big.Int).SetUint64(i))
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, publishedHint{
+			Puzzle:      puzzle,
+			Season:      season,
+			Tier:        int(tier),
+			Index:       i,
+			URI:         hint.Uri,
+			ContentHash: hint.ContentHash,
+			BlockNumber: hint.BlockNumber.Uint64(),
+		})
+	}
+	return list, nil
+}
+
+// printHints writes a line per hint to stdout
+func printHints(list []publishedHint) {
+	if len(list) == 0 {
+		fmt.Println("No hints have been published yet")
+		return
+	}
+	for _, hint := range list {
+		fmt.Printf("Puzzle %d, season %d, tier %d, hint #%d (block %d): %s",
+			hint.Puzzle, hint.Season, hint.Tier, hint.Index, hint.BlockNumber, hint.URI)
+		if hint.ContentHash != (common.Hash{}) {
+			fmt.Printf(" keccak256: %s", hint.ContentHash.Hex())
+		}
+		fmt.Println()
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/arnaubennassar/zkOnacci/game"
+	"github.com/arnaubennassar/zkOnacci/signer"
+	"github.com/arnaubennassar/zkOnacci/testutil"
+	"github.com/arnaubennassar/zkOnacci/txutil"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublishAndList(t *testing.T) {
+	ownerKey, playerKey := testutil.NewKey(t), testutil.NewKey(t)
+	backend := testutil.NewSimulatedBackend(ownerKey, playerKey)
+	auth := testutil.NewTransactor(t, ownerKey)
+	verifierAddr, scAddr, zkOnacci := testutil.Deploy(t, backend, auth)
+	hintsAddr, _ := testutil.DeployHints(t, backend, auth, scAddr)
+	ctx := context.Background()
+	waitConfig := txutil.WaitConfig{
+		PollInterval: time.Millisecond * 10,
+		OnPending: func(*types.Receipt, uint64, uint64) {
+			backend.Commit()
+		},
+	}
+	list, err := listHints(ctx, backend, hintsAddr)
+	require.NoError(t, err)
+	assert.Empty(t, list)
+
+	// The owner publishes hints
+	contentHash := crypto.Keccak256Hash([]byte("hint"))
+	hint, err := publish(ctx, backend, hintsAddr, signer.NewKeySigner(ownerKey), txutil.FeeConfig{}, waitConfig, 0, 0, 1, "ipfs://first", contentHash)
+	require.NoError(t, err)
+	assert.Equal(t, publishedHint{Tier: 1, Index: 0, URI: "ipfs://first", ContentHash: contentHash, BlockNumber: hint.BlockNumber}, *hint)
+	assert.NotZero(t, hint.BlockNumber)
+	second, err := publish(ctx, backend, hintsAddr, signer.NewKeySigner(ownerKey), txutil.FeeConfig{}, waitConfig, 0, 0, 0, "ipfs://second", common.Hash{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), second.Index)
+	third, err := publish(ctx, backend, hintsAddr, signer.NewKeySigner(ownerKey), txutil.FeeConfig{}, waitConfig, 0, 0, 1, "ipfs://third", common.Hash{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), third.Index)
+	// Other accounts can't
+	_, err = publish(ctx, backend, hintsAddr, signer.NewKeySigner(playerKey), txutil.FeeConfig{}, waitConfig, 0, 0, 0, "ipfs://fake", common.Hash{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "NOT_OWNER")
+
+	// The hints of another puzzle are checked against its own tiers
+	def := testutil.LoadGame(t)
+	_, err = zkOnacci.AddPuzzle(auth, verifierAddr, game.Order, def.GenesisRoot, def.BaseURI, def.TokenTiers()[:1], def.TokenURIs()[:1])
+	require.NoError(t, err)
+	backend.Commit()
+	_, err = publish(ctx, backend, hintsAddr, signer.NewKeySigner(ownerKey), txutil.FeeConfig{}, waitConfig, 1, 0, 1, "ipfs://fourth", common.Hash{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "INVALID_TIER")
+	fourth, err := publish(ctx, backend, hintsAddr, signer.NewKeySigner(ownerKey), txutil.FeeConfig{}, waitConfig, 1, 0, 0, "ipfs://fourth", common.Hash{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), fourth.Puzzle)
+	assert.Equal(t, uint64(0), fourth.Index)
+	season, err := currentSeason(ctx, backend, hintsAddr, 1)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), season)
+
+	// Players list them by puzzle, season and tier
+	list, err = listHints(ctx, backend, hintsAddr)
+	require.NoError(t, err)
+	assert.Equal(t, []publishedHint{*second, *hint, *third, *fourth}, list)
+}
+
+func TestHintHash(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hint.md")
+	require.NoError(t, ioutil.WriteFile(path, []byte("hint"), 0644))
+	hash, err := hintHash(path, "")
+	require.NoError(t, err)
+	assert.Equal(t, crypto.Keccak256Hash([]byte("hint")), hash)
+	hash, err = hintHash("", hash.Hex())
+	require.NoError(t, err)
+	assert.Equal(t, crypto.Keccak256Hash([]byte("hint")), hash)
+	hash, err = hintHash("", "")
+	require.NoError(t, err)
+	assert.Equal(t, common.Hash{}, hash)
+	_, err = hintHash(path, hash.Hex())
+	assert.Error(t, err)
+	_, err = hintHash("", "0x1234")
+	assert.Error(t, err)
+}
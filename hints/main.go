@@ -0,0 +1,198 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/arnaubennassar/zkOnacci/config"
+	"github.com/arnaubennassar/zkOnacci/contracts"
+	"github.com/arnaubennassar/zkOnacci/signer"
+	"github.com/arnaubennassar/zkOnacci/txutil"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+func main() {
+	configFlags := config.RegisterFlags(flag.CommandLine)
+	season := flag.Int64("season", -1, "season of the published hint, the current season of the puzzle by default (publish)")
+	tier := flag.Int("tier", -1, "tier of the published hint (publish)")
+	uri := flag.String("uri", "", "URI where the hint can be downloaded (publish)")
+	file := flag.String("file", "", "content of the hint, its keccak256 is published along with the URI (publish)")
+	hash := flag.String("hash", "", "keccak256 of the content of the hint, if it's not available locally (publish)")
+	confirmations := flag.Uint64("confirmations", 1, "blocks (including the one that mines the tx) to wait for (publish)")
+	jsonOutput := flag.Bool("json", false, "print the hints as JSON (list)")
+	cataloguePath := flag.String("catalogue", "", "hint catalogue with the milestone of each hint (schedule)")
+	site := flag.String("site", "", "static site directory where the hints are released, instead of on chain (schedule)")
+	statePath := flag.String("state", "hints_schedule.json", "file where the released hints are persisted (schedule)")
+	pollInterval := flag.Duration("poll-interval", time.Second*15, "time between checks of the milestones (schedule)")
+	flag.Parse()
+	conf, err := configFlags.Load()
+	if err != nil {
+		panic(err)
+	}
+	if conf.Web3URL == "" {
+		panic("Must provide the web3 URL (web3URL of the profile, env var WEB3_URL or -web3-url flag)")
+	}
+	client, err := ethclient.Dial(conf.Web3URL)
+	if err != nil {
+		panic(err)
+	}
+	ctx := context.Background()
+	subcommand := flag.Arg(0)
+	if conf.HintsAddr == (common.Address{}) && (subcommand != "schedule" || *site == "") {
+		panic("Must provide the address of the hints contract (manifest, contracts.hints of the profile or env var HINTS_ADDR)")
+	}
+	switch subcommand {
+	case "publish":
+		if *tier < 0 || *tier > math.MaxUint8 {
+			panic("Must provide the tier of the hint (-tier flag)")
+		}
+		if *uri == "" {
+			panic("Must provide the URI of the hint (-uri flag)")
+		}
+		contentHash, err := hintHash(*file, *hash)
+		if err != nil {
+			panic(err)
+		}
+		s, err := signer.New(conf.Signer)
+		if err != nil {
+			panic(err)
+		}
+		waitConfig := txutil.WaitConfig{Confirmations: *confirmations, OnPending: txutil.PrintProgress}
+		hintSeason := uint64(*season)
+		if *season < 0 {
+			if hintSeason, err = currentSeason(ctx, client, conf.HintsAddr, conf.Puzzle); err != nil {
+				panic(err)
+			}
+		}
+		hint, err := publish(ctx, client, conf.HintsAddr, s, conf.Fees, waitConfig, conf.Puzzle, hintSeason, uint8(*tier), *uri, contentHash)
+		if err != nil {
+			panic(err)
+		}
+		fmt.Printf("Hint #%d of tier %d (puzzle %d, season %d) published on block %d\n",
+			hint.Index, hint.Tier, hint.Puzzle, hint.Season, hint.BlockNumber)
+	case "schedule":
+		if *cataloguePath == "" {
+			panic("Must provide the hint catalogue (-catalogue flag)")
+		}
+		runScheduler(client, conf, *cataloguePath, *site, *statePath, *pollInterval, *confirmations)
+	case "list", "":
+		list, err := listHints(ctx, client, conf.HintsAddr)
+		if err != nil {
+			panic(err)
+		}
+		if *jsonOutput {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(list); err != nil {
+				panic(err)
+			}
+			return
+		}
+		printHints(list)
+	default:
+		panic(fmt.Sprintf("Unknown subcommand %s, use publish, list or schedule", subcommand))
+	}
+}
+
+// runScheduler releases the hints of the catalogue, on chain or to site if it's set, until all of them are released
+// or the process is interrupted
+func runScheduler(
+	client *ethclient.Client,
+	conf *config.Config,
+	cataloguePath string,
+	site string,
+	statePath string,
+	pollInterval time.Duration,
+	confirmations uint64,
+) {
+	c, err := loadCatalogue(cataloguePath)
+	if err != nil {
+		panic(err)
+	}
+	scAddr := conf.ZKOnacciAddr
+	var releaser hintReleaser
+	if site != "" {
+		if scAddr == (common.Address{}) {
+			panic("Must provide the address of the zkOnacci SC (manifest, contracts.zkOnacci of the profile, env var SC_ADDR or -sc-addr flag)")
+		}
+		if err := os.MkdirAll(site, 0755); err != nil {
+			panic(err)
+		}
+		releaser = &siteReleaser{dir: site}
+	} else {
+		hints, err := contracts.NewZKOnacciHintsCaller(conf.HintsAddr, client)
+		if err != nil {
+			panic(err)
+		}
+		// The milestones are read from the game of the hints contract
+		if scAddr, err = hints.ZkOnacci(&bind.CallOpts{}); err != nil {
+			panic(err)
+		}
+		s, err := signer.New(conf.Signer)
+		if err != nil {
+			panic(err)
+		}
+		releaser = &onChainReleaser{
+			backend:    client,
+			hintsAddr:  conf.HintsAddr,
+			signer:     s,
+			feeConfig:  conf.Fees,
+			waitConfig: txutil.WaitConfig{Confirmations: confirmations, OnPending: txutil.PrintProgress},
+		}
+	}
+	zkOnacci, err := contracts.NewZKOnacci(scAddr, client)
+	if err != nil {
+		panic(err)
+	}
+	state, err := loadScheduleState(statePath, scAddr)
+	if err != nil {
+		panic(err)
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	sched, err := newScheduler(ctx, zkOnacci, c, releaser, state, statePath)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("Releasing %d hints, %d already released\n", sched.pending(), len(state.Released))
+	if err := sched.run(ctx, pollInterval); errors.Is(err, context.Canceled) {
+		fmt.Println("Shutting down")
+	} else if err != nil {
+		panic(err)
+	}
+}
+
+// hintHash returns the keccak256 of the content of the hint: the hash of file, or hash if there's no file.
+// The zero hash is published if none is set
+func hintHash(file, hash string) (common.Hash, error) {
+	switch {
+	case file != "" && hash != "":
+		return common.Hash{}, errors.New("use either -file or -hash")
+	case file != "":
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			return common.Hash{}, err
+		}
+		return crypto.Keccak256Hash(content), nil
+	case hash != "":
+		decoded, err := hexutil.Decode(hash)
+		if err != nil || len(decoded) != common.HashLength {
+			return common.Hash{}, fmt.Errorf("invalid hash %s, it must be 32 bytes in hex", hash)
+		}
+		return common.BytesToHash(decoded), nil
+	}
+	return common.Hash{}, nil
+}
@@ -0,0 +1,441 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/arnaubennassar/zkOnacci/contracts"
+	"github.com/arnaubennassar/zkOnacci/game"
+	"github.com/arnaubennassar/zkOnacci/signer"
+	"github.com/arnaubennassar/zkOnacci/txutil"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// siteIndexFile is the name of the file that lists the hints released to a site directory
+const siteIndexFile = "hints.json"
+
+// milestone is the state of the game that releases a hint. Exactly one of TokensMinted and TierSoldOut must be set
+type milestone struct {
+	// Puzzle of zkOnacci the milestone belongs to
+	Puzzle uint64 `json:"puzzle,omitempty"`
+	// Season the milestone belongs to, it's reached as well once a later season starts
+	Season uint64 `json:"season,omitempty"`
+	// TokensMinted is reached once that amount of tokens has been minted
+	TokensMinted uint64 `json:"tokensMinted,omitempty"`
+	// TierSoldOut is reached once the last token of the tier is minted
+	TierSoldOut *int `json:"tierSoldOut,omitempty"`
+}
+
+// triggerIndex returns the position within the season of the token whose mint reaches the milestone,
+// given the tiers of the season
+func (m milestone) triggerIndex(tokenTiers []uint16) (uint64, error) {
+	switch {
+	case m.TokensMinted > 0 && m.TierSoldOut != nil:
+		return 0, errors.New("tokensMinted and tierSoldOut can't be used together")
+	case m.TokensMinted > 0:
+		if m.TokensMinted > uint64(tokenTiers[len(tokenTiers)-1])+1 {
+			return 0, fmt.Errorf("tokensMinted %d is beyond the last token", m.TokensMinted)
+		}
+		return m.TokensMinted - 1, nil
+	case m.TierSoldOut != nil:
+		if *m.TierSoldOut < 0 || *m.TierSoldOut >= len(tokenTiers) {
+			return 0, fmt.Errorf("tierSoldOut %d is not a tier of the game", *m.TierSoldOut)
+		}
+		return uint64(tokenTiers[*m.TierSoldOut]), nil
+	}
+	return 0, errors.New("the milestone needs tokensMinted or tierSoldOut")
+}
+
+// catalogueHint is a hint of the catalogue, released once its milestone is reached
+type catalogueHint struct {
+	// ID identifies the hint across restarts, it must be unique within the catalogue
+	ID        string    `json:"id"`
+	Milestone milestone `json:"milestone"`
+	// Tier is the tier the hint is published for, within the puzzle and the season of the milestone
+	Tier uint8 `json:"tier"`
+	// URI is where the hint can be downloaded, required to release it on chain
+	URI string `json:"uri"`
+	// File is the path of the content of the hint, relative to the catalogue. Required to release it to a site
+	File string `json:"file"`
+}
+
+// catalogue is the content of a hint catalogue file
+type catalogue struct {
+	Hints []catalogueHint `json:"hints"`
+}
+
+// loadCatalogue reads the catalogue of path. The paths of the hint files are resolved from its directory
+func loadCatalogue(path string) (*catalogue, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	c := &catalogue{}
+	if err := json.Unmarshal(content, c); err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", path, err)
+	}
+	ids := map[string]bool{}
+	for i := range c.Hints {
+		hint := &c.Hints[i]
+		if hint.ID == "" {
+			return nil, fmt.Errorf("hint %d of %s has no id", i, path)
+		}
+		if ids[hint.ID] {
+			return nil, fmt.Errorf("duplicated hint id %s in %s", hint.ID, path)
+		}
+		ids[hint.ID] = true
+		if hint.File != "" && !filepath.IsAbs(hint.File) {
+			hint.File = filepath.Join(filepath.Dir(path), hint.File)
+		}
+	}
+	return c, nil
+}
+
+// releasedHint is a hint of the catalogue that has been released
+type releasedHint struct {
+	ID string `json:"id"`
+	// TriggerTokenID is the token whose mint reached the milestone (it encodes the puzzle and the season, see game.TokenID),
+	// on TriggerBlock through TriggerTx
+	TriggerTokenID uint64        `json:"triggerTokenId"`
+	TriggerBlock   uint64        `json:"triggerBlock"`
+	TriggerTx      common.Hash   `json:"triggerTx"`
+	Hint           publishedHint `json:"hint"`
+}
+
+// scheduleState is the progress of the scheduler, persisted across restarts
+type scheduleState struct {
+	Contract common.Address `json:"contract"`
+	Released []releasedHint `json:"released"`
+}
+
+// loadScheduleState reads the state file. If it doesn't exist, an empty state is returned
+func loadScheduleState(path string, scAddr common.Address) (*scheduleState, error) {
+	state := &scheduleState{Contract: scAddr, Released: []releasedHint{}}
+	stateJSON, err := ioutil.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(stateJSON, state); err != nil {
+		return nil, err
+	}
+	if state.Contract != scAddr {
+		return nil, fmt.Errorf("the state file %s belongs to the contract %s", path, state.Contract.Hex())
+	}
+	return state, nil
+}
+
+// save writes the state into path, replacing the file atomically
+func (ss *scheduleState) save(path string) error {
+	return writeJSON(path, ss)
+}
+
+// released returns true if the hint with id has already been released
+func (ss *scheduleState) released(id string) bool {
+	for _, r := range ss.Released {
+		if r.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// writeJSON writes v as indented JSON into path, replacing the file atomically
+func writeJSON(path string, v interface{}) error {
+	content, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(path+".tmp", content, 0644); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+// hintReleaser makes a hint of the catalogue available to the players. Releasing a hint that was already
+// released (e.g. the process stopped before saving the state) must not release it again
+type hintReleaser interface {
+	release(ctx context.Context, hint catalogueHint, contentHash common.Hash, triggerBlock uint64) (*publishedHint, error)
+}
+
+// onChainReleaser publishes the hints on the hints contract
+type onChainReleaser struct {
+	backend    hintsBackend
+	hintsAddr  common.Address
+	signer     signer.Signer
+	feeConfig  txutil.FeeConfig
+	waitConfig txutil.WaitConfig
+}
+
+func (r *onChainReleaser) release(ctx context.Context, hint catalogueHint, contentHash common.Hash, _ uint64) (*publishedHint, error) {
+	if hint.URI == "" {
+		return nil, fmt.Errorf("hint %s has no uri", hint.ID)
+	}
+	hints, err := contracts.NewZKOnacciHintsCaller(r.hintsAddr, r.backend)
+	if err != nil {
+		return nil, err
+	}
+	puzzle, season := hint.Milestone.Puzzle, hint.Milestone.Season
+	list, err := tierHints(&bind.CallOpts{Context: ctx}, hints, puzzle, season, hint.Tier)
+	if err != nil {
+		return nil, err
+	}
+	for _, published := range list {
+		if published.URI == hint.URI && published.ContentHash == contentHash {
+			return &published, nil
+		}
+	}
+	return publish(ctx, r.backend, r.hintsAddr, r.signer, r.feeConfig, r.waitConfig, puzzle, season, hint.Tier, hint.URI, contentHash)
+}
+
+// siteReleaser copies the hints into a static site directory, as <id><extension of the file>,
+// and lists them on its index file
+type siteReleaser struct {
+	dir string
+}
+
+func (r *siteReleaser) release(_ context.Context, hint catalogueHint, contentHash common.Hash, triggerBlock uint64) (*publishedHint, error) {
+	if hint.File == "" {
+		return nil, fmt.Errorf("hint %s has no file", hint.ID)
+	}
+	content, err := ioutil.ReadFile(hint.File)
+	if err != nil {
+		return nil, err
+	}
+	name := hint.ID + filepath.Ext(hint.File)
+	if err := ioutil.WriteFile(filepath.Join(r.dir, name), content, 0644); err != nil {
+		return nil, err
+	}
+	indexPath := filepath.Join(r.dir, siteIndexFile)
+	index := []publishedHint{}
+	indexJSON, err := ioutil.ReadFile(indexPath)
+	if err == nil {
+		if err := json.Unmarshal(indexJSON, &index); err != nil {
+			return nil, fmt.Errorf("error reading %s: %w", indexPath, err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	published := publishedHint{
+		Puzzle:      hint.Milestone.Puzzle,
+		Season:      hint.Milestone.Season,
+		Tier:        int(hint.Tier),
+		URI:         name,
+		ContentHash: contentHash,
+		BlockNumber: triggerBlock,
+	}
+	for _, listed := range index {
+		if listed.URI == name {
+			return &listed, nil
+		}
+		if listed.Puzzle == published.Puzzle && listed.Season == published.Season && listed.Tier == published.Tier {
+			published.Index++
+		}
+	}
+	index = append(index, published)
+	if err := writeJSON(indexPath, index); err != nil {
+		return nil, err
+	}
+	return &published, nil
+}
+
+// seasonKey identifies a season of a puzzle
+type seasonKey struct {
+	puzzle uint64
+	season uint64
+}
+
+// scheduler releases the hints of the catalogue as the milestones are reached
+type scheduler struct {
+	zkOnacci *contracts.ZKOnacci
+	// seasonTiers caches the tiers of the seasons that have started, which don't change
+	seasonTiers map[seasonKey][]uint16
+	catalogue   *catalogue
+	releaser    hintReleaser
+	state       *scheduleState
+	statePath   string
+}
+
+func newScheduler(
+	ctx context.Context,
+	zkOnacci *contracts.ZKOnacci,
+	c *catalogue,
+	releaser hintReleaser,
+	state *scheduleState,
+	statePath string,
+) (*scheduler, error) {
+	s := &scheduler{
+		zkOnacci:    zkOnacci,
+		seasonTiers: map[seasonKey][]uint16{},
+		catalogue:   c,
+		releaser:    releaser,
+		state:       state,
+		statePath:   statePath,
+	}
+	callOpts := &bind.CallOpts{Context: ctx}
+	nPuzzles, err := zkOnacci.NPuzzles(callOpts)
+	if err != nil {
+		return nil, err
+	}
+	// Fail on start rather than when the milestones are reached. The milestones of future seasons
+	// are checked once they start
+	for _, hint := range c.Hints {
+		puzzle, season := hint.Milestone.Puzzle, hint.Milestone.Season
+		if puzzle >= nPuzzles.Uint64() {
+			return nil, fmt.Errorf("hint %s is for puzzle %d, but there are %d puzzles", hint.ID, puzzle, nPuzzles)
+		}
+		currentSeason, err := zkOnacci.CurrentSeason(callOpts, new(big.Int).SetUint64(puzzle))
+		if err != nil {
+			return nil, err
+		}
+		if season > currentSeason.Uint64() {
+			continue
+		}
+		tokenTiers, err := s.tiers(ctx, puzzle, season)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := hint.Milestone.triggerIndex(tokenTiers); err != nil {
+			return nil, fmt.Errorf("invalid milestone of hint %s: %w", hint.ID, err)
+		}
+		if int(hint.Tier) >= len(tokenTiers) {
+			return nil, fmt.Errorf("hint %s is for tier %d, but season %d of puzzle %d has %d tiers",
+				hint.ID, hint.Tier, season, puzzle, len(tokenTiers))
+		}
+	}
+	return s, nil
+}
+
+// tiers returns the last token position of each tier of a season of puzzle
+func (s *scheduler) tiers(ctx context.Context, puzzle, season uint64) ([]uint16, error) {
+	key := seasonKey{puzzle: puzzle, season: season}
+	if tokenTiers, ok := s.seasonTiers[key]; ok {
+		return tokenTiers, nil
+	}
+	settings, err := s.zkOnacci.Season(&bind.CallOpts{Context: ctx}, new(big.Int).SetUint64(puzzle), new(big.Int).SetUint64(season))
+	if err != nil {
+		return nil, err
+	}
+	s.seasonTiers[key] = settings.SeasonTokenTiers
+	return settings.SeasonTokenTiers, nil
+}
+
+// pending returns the amount of hints of the catalogue that haven't been released yet
+func (s *scheduler) pending() int {
+	pending := 0
+	for _, hint := range s.catalogue.Hints {
+		if !s.state.released(hint.ID) {
+			pending++
+		}
+	}
+	return pending
+}
+
+// releaseDue releases, in catalogue order, the hints whose milestone has been reached and saves the state after each one
+func (s *scheduler) releaseDue(ctx context.Context) error {
+	callOpts := &bind.CallOpts{Context: ctx}
+	// The current season and the token counter of each puzzle, read once per check
+	currentSeasons, tokenCounters := map[uint64]uint64{}, map[uint64]uint64{}
+	for _, hint := range s.catalogue.Hints {
+		puzzle, season := hint.Milestone.Puzzle, hint.Milestone.Season
+		if s.state.released(hint.ID) {
+			continue
+		}
+		if _, ok := currentSeasons[puzzle]; !ok {
+			currentSeason, err := s.zkOnacci.CurrentSeason(callOpts, new(big.Int).SetUint64(puzzle))
+			if err != nil {
+				return err
+			}
+			tokenCounter, err := s.zkOnacci.TokenCounter(callOpts, new(big.Int).SetUint64(puzzle))
+			if err != nil {
+				return err
+			}
+			currentSeasons[puzzle], tokenCounters[puzzle] = currentSeason.Uint64(), tokenCounter.Uint64()
+		}
+		if season > currentSeasons[puzzle] {
+			continue
+		}
+		tokenTiers, err := s.tiers(ctx, puzzle, season)
+		if err != nil {
+			return err
+		}
+		triggerIndex, err := hint.Milestone.triggerIndex(tokenTiers)
+		if err != nil {
+			return fmt.Errorf("invalid milestone of hint %s: %w", hint.ID, err)
+		}
+		if season == currentSeasons[puzzle] && tokenCounters[puzzle] <= triggerIndex {
+			continue
+		}
+		triggerTokenID := game.TokenID(puzzle, season, triggerIndex)
+		triggerBlock, triggerTx, err := s.findCapture(ctx, triggerTokenID)
+		if err != nil {
+			return err
+		}
+		contentHash, err := hintHash(hint.File, "")
+		if err != nil {
+			return err
+		}
+		published, err := s.releaser.release(ctx, hint, contentHash, triggerBlock)
+		if err != nil {
+			return fmt.Errorf("error releasing hint %s: %w", hint.ID, err)
+		}
+		fmt.Printf("Hint %s released as hint #%d of tier %d of puzzle %d, season %d (%s), triggered by the mint of token %s on block %d\n",
+			hint.ID, published.Index, published.Tier, puzzle, season, published.URI, triggerTokenID, triggerBlock)
+		s.state.Released = append(s.state.Released, releasedHint{
+			ID:             hint.ID,
+			TriggerTokenID: triggerTokenID.Uint64(),
+			TriggerBlock:   triggerBlock,
+			TriggerTx:      triggerTx,
+			Hint:           *published,
+		})
+		if err := s.state.save(s.statePath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// findCapture returns the block and tx where tokenID was minted
+func (s *scheduler) findCapture(ctx context.Context, tokenID *big.Int) (uint64, common.Hash, error) {
+	it, err := s.zkOnacci.FilterFlagCaptured(&bind.FilterOpts{Context: ctx}, nil, []*big.Int{tokenID}, nil)
+	if err != nil {
+		return 0, common.Hash{}, err
+	}
+	defer it.Close()
+	if !it.Next() {
+		if it.Error() != nil {
+			return 0, common.Hash{}, it.Error()
+		}
+		return 0, common.Hash{}, fmt.Errorf("FlagCaptured event of token %s not found", tokenID)
+	}
+	return it.Event.Raw.BlockNumber, it.Event.Raw.TxHash, nil
+}
+
+// run checks the milestones every pollInterval until all the hints are released or ctx is cancelled
+func (s *scheduler) run(ctx context.Context, pollInterval time.Duration) error {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		if err := s.releaseDue(ctx); err != nil {
+			return err
+		}
+		if s.pending() == 0 {
+			fmt.Println("All the hints of the catalogue have been released")
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/arnaubennassar/zkOnacci/signer"
+	"github.com/arnaubennassar/zkOnacci/testutil"
+	"github.com/arnaubennassar/zkOnacci/txutil"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testCatalogue = `{
+  "hints": [
+    { "id": "first-mint", "milestone": { "tokensMinted": 1 }, "tier": 0, "uri": "ipfs://first", "file": "first.md" },
+    { "id": "tier0-sold-out", "milestone": { "tierSoldOut": 0 }, "tier": 1, "uri": "ipfs://second", "file": "second.md" }
+  ]
+}`
+
+// writeCatalogue writes the test catalogue and its hint files into a temp dir
+func writeCatalogue(t *testing.T) string {
+	dir := t.TempDir()
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "first.md"), []byte("first"), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "second.md"), []byte("second"), 0644))
+	path := filepath.Join(dir, "catalogue.json")
+	require.NoError(t, ioutil.WriteFile(path, []byte(testCatalogue), 0644))
+	return path
+}
+
+func TestMilestones(t *testing.T) {
+	tokenTiers := []uint16{2, 4, 8, 16}
+	tier := func(tier int) *int { return &tier }
+	for _, tc := range []struct {
+		milestone milestone
+		tokenID   uint64
+		err       bool
+	}{
+		{milestone: milestone{TokensMinted: 1}, tokenID: 0},
+		{milestone: milestone{TokensMinted: 17}, tokenID: 16},
+		{milestone: milestone{TierSoldOut: tier(0)}, tokenID: 2},
+		{milestone: milestone{TierSoldOut: tier(3)}, tokenID: 16},
+		{milestone: milestone{TokensMinted: 18}, err: true},
+		{milestone: milestone{TierSoldOut: tier(4)}, err: true},
+		{milestone: milestone{TokensMinted: 1, TierSoldOut: tier(0)}, err: true},
+		{milestone: milestone{}, err: true},
+	} {
+		tokenID, err := tc.milestone.triggerIndex(tokenTiers)
+		if tc.err {
+			assert.Error(t, err)
+			continue
+		}
+		require.NoError(t, err)
+		assert.Equal(t, tc.tokenID, tokenID)
+	}
+}
+
+func TestLoadCatalogue(t *testing.T) {
+	path := writeCatalogue(t)
+	c, err := loadCatalogue(path)
+	require.NoError(t, err)
+	require.Len(t, c.Hints, 2)
+	assert.Equal(t, filepath.Join(filepath.Dir(path), "first.md"), c.Hints[0].File)
+	assert.Equal(t, 0, *c.Hints[1].Milestone.TierSoldOut)
+	// Duplicated ids
+	duplicated := filepath.Join(t.TempDir(), "catalogue.json")
+	require.NoError(t, ioutil.WriteFile(duplicated, []byte(`{"hints": [{"id": "a"}, {"id": "a"}]}`), 0644))
+	_, err = loadCatalogue(duplicated)
+	assert.Error(t, err)
+}
+
+func TestScheduleRelease(t *testing.T) {
+	ctx := context.Background()
+	ownerKey := testutil.NewKey(t)
+	backend := testutil.NewSimulatedBackend(ownerKey)
+	auth := testutil.NewTransactor(t, ownerKey)
+	_, scAddr, zkOnacci := testutil.Deploy(t, backend, auth)
+	hintsAddr, _ := testutil.DeployHints(t, backend, auth, scAddr)
+	c, err := loadCatalogue(writeCatalogue(t))
+	require.NoError(t, err)
+	siteDir := t.TempDir()
+	onChain := &onChainReleaser{
+		backend:   backend,
+		hintsAddr: hintsAddr,
+		signer:    signer.NewKeySigner(ownerKey),
+		waitConfig: txutil.WaitConfig{
+			PollInterval: time.Millisecond * 10,
+			OnPending: func(*types.Receipt, uint64, uint64) {
+				backend.Commit()
+			},
+		},
+	}
+	site := &siteReleaser{dir: siteDir}
+	// newSchedulers returns the on chain and site schedulers, with the state persisted on disk
+	stateDir := t.TempDir()
+	newSchedulers := func() (*scheduler, *scheduler) {
+		schedulers := []*scheduler{}
+		for _, target := range []struct {
+			name     string
+			releaser hintReleaser
+		}{{"onchain.json", onChain}, {"site.json", site}} {
+			statePath := filepath.Join(stateDir, target.name)
+			state, err := loadScheduleState(statePath, scAddr)
+			require.NoError(t, err)
+			s, err := newScheduler(ctx, zkOnacci, c, target.releaser, state, statePath)
+			require.NoError(t, err)
+			schedulers = append(schedulers, s)
+		}
+		return schedulers[0], schedulers[1]
+	}
+	onChainScheduler, siteScheduler := newSchedulers()
+
+	// No milestone has been reached
+	require.NoError(t, onChainScheduler.releaseDue(ctx))
+	require.NoError(t, siteScheduler.releaseDue(ctx))
+	assert.Equal(t, 2, onChainScheduler.pending())
+	list, err := listHints(ctx, backend, hintsAddr)
+	require.NoError(t, err)
+	assert.Empty(t, list)
+
+	// The first mint releases the first hint
+	capture := testutil.ProveFirstCapture(t, auth.From)
+	tx, err := zkOnacci.CaptureTheFlag(auth, big.NewInt(0), capture.ProofA, capture.ProofB, capture.ProofC, capture.NextRoot)
+	require.NoError(t, err)
+	backend.Commit()
+	receipt, err := backend.TransactionReceipt(ctx, tx.Hash())
+	require.NoError(t, err)
+	require.NoError(t, onChainScheduler.releaseDue(ctx))
+	require.NoError(t, siteScheduler.releaseDue(ctx))
+	assert.Equal(t, 1, onChainScheduler.pending())
+	firstHash := crypto.Keccak256Hash([]byte("first"))
+	list, err = listHints(ctx, backend, hintsAddr)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "ipfs://first", list[0].URI)
+	assert.Equal(t, firstHash, list[0].ContentHash)
+	require.Len(t, onChainScheduler.state.Released, 1)
+	assert.Equal(t, releasedHint{
+		ID:             "first-mint",
+		TriggerTokenID: 0,
+		TriggerBlock:   receipt.BlockNumber.Uint64(),
+		TriggerTx:      tx.Hash(),
+		Hint:           list[0],
+	}, onChainScheduler.state.Released[0])
+	content, err := ioutil.ReadFile(filepath.Join(siteDir, "first-mint.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "first", string(content))
+	sitePublished := publishedHint{Tier: 0, URI: "first-mint.md", ContentHash: firstHash, BlockNumber: receipt.BlockNumber.Uint64()}
+	assert.Equal(t, sitePublished, siteScheduler.state.Released[0].Hint)
+
+	// Restarting doesn't release the hints again
+	onChainScheduler, siteScheduler = newSchedulers()
+	assert.Equal(t, 1, onChainScheduler.pending())
+	require.NoError(t, onChainScheduler.releaseDue(ctx))
+	require.NoError(t, siteScheduler.releaseDue(ctx))
+	list, err = listHints(ctx, backend, hintsAddr)
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+
+	// Neither does losing the state after releasing them
+	for _, s := range []*scheduler{onChainScheduler, siteScheduler} {
+		s.statePath = filepath.Join(t.TempDir(), "lost.json")
+		s.state, err = loadScheduleState(s.statePath, scAddr)
+		require.NoError(t, err)
+		require.NoError(t, s.releaseDue(ctx))
+		require.Len(t, s.state.Released, 1)
+	}
+	assert.Equal(t, list[0], onChainScheduler.state.Released[0].Hint)
+	assert.Equal(t, sitePublished, siteScheduler.state.Released[0].Hint)
+	list, err = listHints(ctx, backend, hintsAddr)
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+
+	// The state belongs to the contract
+	_, err = loadScheduleState(filepath.Join(stateDir, "onchain.json"), common.HexToAddress("0x01"))
+	assert.Error(t, err)
+
+	// The hints of puzzles that don't exist are rejected on start
+	c.Hints[0].Milestone.Puzzle = 1
+	_, err = newScheduler(ctx, zkOnacci, c, site, onChainScheduler.state, onChainScheduler.statePath)
+	assert.Error(t, err)
+}
@@ -0,0 +1,126 @@
+package manifest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/arnaubennassar/zkOnacci/contracts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// VerifierBytecodeArtifact is the key of the hash of the verifier bytecode in Manifest.Artifacts
+const VerifierBytecodeArtifact = "verifierBytecode"
+
+// circuitArtifacts are the files of the circom artifacts directory hashed in the manifest (if present)
+var circuitArtifacts = []string{
+	"zkOnacci.circom",
+	"zkOnacci.wasm",
+	"zkOnacci_final.zkey",
+	"verification_key.json",
+}
+
+// Manifest describes a deployment of the contracts
+type Manifest struct {
+	ChainID  uint64         `json:"chainId"`
+	Deployer common.Address `json:"deployer"`
+	// Factory is the CREATE2 factory the contracts were deployed through with Salt (zero address if the contracts
+	// were deployed directly)
+	Factory  common.Address `json:"factory"`
+	Salt     common.Hash    `json:"salt"`
+	Verifier Deployment     `json:"verifier"`
+	ZKOnacci Deployment     `json:"zkOnacci"`
+	// Hints is the contract where the deployer publishes the hints of the game (missing on older manifests)
+	Hints Deployment `json:"hints"`
+	// Artifacts holds the SHA-256 of the verifier bytecode and the circuit files used by the deployment
+	Artifacts map[string]string `json:"artifacts"`
+}
+
+// Deployment describes a deployed contract. While the deployment tx is not mined, BlockNumber is 0
+type Deployment struct {
+	Address     common.Address `json:"address"`
+	TxHash      common.Hash    `json:"txHash"`
+	BlockNumber uint64         `json:"blockNumber"`
+	GasUsed     uint64         `json:"gasUsed"`
+}
+
+// Pending returns true if the deployment tx has been sent but it's not known to be mined
+func (d Deployment) Pending() bool {
+	return d.TxHash != (common.Hash{}) && d.BlockNumber == 0
+}
+
+// NewDeployment describes the contract deployed at address by the tx of receipt
+func NewDeployment(address common.Address, receipt *types.Receipt) Deployment {
+	return Deployment{
+		Address:     address,
+		TxHash:      receipt.TxHash,
+		BlockNumber: receipt.BlockNumber.Uint64(),
+		GasUsed:     receipt.GasUsed,
+	}
+}
+
+// HashArtifacts returns the SHA-256 of the verifier bytecode and of the circuit files found in artifactsPath
+func HashArtifacts(artifactsPath string) (map[string]string, error) {
+	verifierBytecode, err := hex.DecodeString(strings.TrimPrefix(contracts.VerifierBin, "0x"))
+	if err != nil {
+		return nil, err
+	}
+	verifierHash := sha256.Sum256(verifierBytecode)
+	hashes := map[string]string{
+		VerifierBytecodeArtifact: hex.EncodeToString(verifierHash[:]),
+	}
+	for _, name := range circuitArtifacts {
+		content, err := ioutil.ReadFile(filepath.Join(artifactsPath, name))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		hash := sha256.Sum256(content)
+		hashes[name] = hex.EncodeToString(hash[:])
+	}
+	return hashes, nil
+}
+
+// Load reads the manifest of a complete deployment from path
+func Load(path string) (*Manifest, error) {
+	m, err := Read(path)
+	if err != nil {
+		return nil, err
+	}
+	if m.ZKOnacci.Address == (common.Address{}) {
+		return nil, errors.New("the manifest doesn't have the zkOnacci address")
+	}
+	if m.ZKOnacci.Pending() {
+		return nil, errors.New("the zkOnacci deployment of the manifest is not complete")
+	}
+	return m, nil
+}
+
+// Read reads the manifest of path, which may describe a deployment in progress
+func Read(path string) (*Manifest, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	m := &Manifest{}
+	if err := json.Unmarshal(content, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Save writes the manifest to path
+func (m *Manifest) Save(path string) error {
+	content, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(content, '\n'), 0644)
+}
@@ -0,0 +1,82 @@
+package manifest
+
+import (
+	"context"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/arnaubennassar/zkOnacci/contracts"
+	"github.com/arnaubennassar/zkOnacci/game"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashArtifacts(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "zkOnacci.circom"), []byte("circuit"), 0644))
+	hashes, err := HashArtifacts(dir)
+	require.NoError(t, err)
+	assert.Len(t, hashes, 2)
+	assert.Contains(t, hashes, VerifierBytecodeArtifact)
+	// sha256("circuit")
+	assert.Equal(t, "4666a3f66bc600ad9f11fa871e13e32f1c0fe8ba9ad25265a449b0c983c589f1", hashes["zkOnacci.circom"])
+}
+
+func TestManifestFromDeployment(t *testing.T) {
+	ctx := context.Background()
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, big.NewInt(1337))
+	require.NoError(t, err)
+	balance, _ := new(big.Int).SetString("10000000000000000000", 10) // 10 ETH in wei
+	backend := backends.NewSimulatedBackend(map[common.Address]core.GenesisAccount{
+		auth.From: {Balance: balance},
+	}, 30000000)
+	verifierAddr, verifierTx, _, err := contracts.DeployVerifier(auth, backend)
+	require.NoError(t, err)
+	backend.Commit()
+	def, err := game.Load("../NFTs/game.json", 6)
+	require.NoError(t, err)
+	scAddr, zkOnacciTx, _, err := contracts.DeployZKOnacci(auth, backend, verifierAddr, def.GenesisRoot, def.BaseURI, def.TokenTiers(), def.TokenURIs(), auth.From)
+	require.NoError(t, err)
+	backend.Commit()
+	verifierReceipt, err := backend.TransactionReceipt(ctx, verifierTx.Hash())
+	require.NoError(t, err)
+	zkOnacciReceipt, err := backend.TransactionReceipt(ctx, zkOnacciTx.Hash())
+	require.NoError(t, err)
+	artifacts, err := HashArtifacts(t.TempDir())
+	require.NoError(t, err)
+	m := &Manifest{
+		ChainID:   1337,
+		Deployer:  auth.From,
+		Verifier:  NewDeployment(verifierAddr, verifierReceipt),
+		ZKOnacci:  NewDeployment(scAddr, zkOnacciReceipt),
+		Artifacts: artifacts,
+	}
+	assert.Equal(t, uint64(1), m.Verifier.BlockNumber)
+	assert.Equal(t, uint64(2), m.ZKOnacci.BlockNumber)
+	assert.Equal(t, zkOnacciTx.Hash(), m.ZKOnacci.TxHash)
+	assert.NotZero(t, m.ZKOnacci.GasUsed)
+	// Save and use the addresses of the loaded manifest
+	path := filepath.Join(t.TempDir(), "deployment.json")
+	require.NoError(t, m.Save(path))
+	loaded, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, m, loaded)
+	zkOnacci, err := contracts.NewZKOnacci(loaded.ZKOnacci.Address, backend)
+	require.NoError(t, err)
+	tokenCounter, err := zkOnacci.TokenCounter(&bind.CallOpts{}, big.NewInt(0))
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), tokenCounter.Int64())
+	// Manifest without addresses
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{"chainId": 1337}`), 0644))
+	_, err = Load(path)
+	assert.Error(t, err)
+}
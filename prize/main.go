@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/arnaubennassar/zkOnacci/config"
+	"github.com/arnaubennassar/zkOnacci/signer"
+	"github.com/arnaubennassar/zkOnacci/txutil"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+func main() {
+	configFlags := config.RegisterFlags(flag.CommandLine)
+	amount := flag.String("amount", "", "amount to deposit into the prize pool, in wei (fund)")
+	player := flag.String("player", "", "address of the player whose rewards are shown, defaults to the signer address (rewards)")
+	confirmations := flag.Uint64("confirmations", 1, "blocks (including the one that mines the tx) to wait for (fund and withdraw)")
+	jsonOutput := flag.Bool("json", false, "print the rewards as JSON (rewards)")
+	flag.Parse()
+	conf, err := configFlags.Load()
+	if err != nil {
+		panic(err)
+	}
+	if conf.Web3URL == "" {
+		panic("Must provide the web3 URL (web3URL of the profile, env var WEB3_URL or -web3-url flag)")
+	}
+	if conf.ZKOnacciAddr == (common.Address{}) {
+		panic("Must provide the address of the zkOnacci SC (manifest, contracts.zkOnacci of the profile, env var SC_ADDR or -sc-addr flag)")
+	}
+	client, err := ethclient.Dial(conf.Web3URL)
+	if err != nil {
+		panic(err)
+	}
+	newSigner := func() signer.Signer {
+		s, err := signer.New(conf.Signer)
+		if err != nil {
+			panic(err)
+		}
+		return s
+	}
+	ctx := context.Background()
+	waitConfig := txutil.WaitConfig{Confirmations: *confirmations, OnPending: txutil.PrintProgress}
+	switch subcommand := flag.Arg(0); subcommand {
+	case "fund":
+		value, ok := new(big.Int).SetString(*amount, 10)
+		if !ok {
+			panic("Must provide the amount to deposit in wei (-amount flag)")
+		}
+		deposited, err := fund(ctx, client, conf.ZKOnacciAddr, newSigner(), conf.Fees, waitConfig, value)
+		if err != nil {
+			panic(err)
+		}
+		fmt.Println(deposited.Amount, "wei deposited into the prize pool by", deposited.Sponsor.Hex())
+	case "withdraw":
+		withdrawn, err := withdraw(ctx, client, conf.ZKOnacciAddr, newSigner(), conf.Fees, waitConfig)
+		if err != nil {
+			panic(err)
+		}
+		fmt.Println(withdrawn.Amount, "wei of rewards withdrawn by", withdrawn.Player.Hex())
+	case "rewards", "":
+		var playerAddr common.Address
+		if *player != "" {
+			if !common.IsHexAddress(*player) {
+				panic(fmt.Sprintf("Invalid player address: %s", *player))
+			}
+			playerAddr = common.HexToAddress(*player)
+		} else {
+			playerAddr = newSigner().Address()
+		}
+		r, err := readRewards(ctx, client, conf.ZKOnacciAddr, conf.Puzzle, playerAddr)
+		if err != nil {
+			panic(err)
+		}
+		if *jsonOutput {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(r); err != nil {
+				panic(err)
+			}
+			return
+		}
+		r.print()
+	default:
+		panic(fmt.Sprintf("Unknown subcommand %s, use fund, rewards or withdraw", subcommand))
+	}
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/arnaubennassar/zkOnacci/contracts"
+	"github.com/arnaubennassar/zkOnacci/signer"
+	"github.com/arnaubennassar/zkOnacci/txutil"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// prizeBackend is the node the deposits and withdrawals are sent through
+type prizeBackend interface {
+	txutil.Backend
+	txutil.WaitBackend
+}
+
+// rewardsBackend is the functionality needed to read the prize pool and the rewards
+type rewardsBackend interface {
+	bind.ContractCaller
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+}
+
+// fund deposits amount (in wei) into the prize pool of the zkOnacci at scAddr
+func fund(
+	ctx context.Context,
+	backend prizeBackend,
+	scAddr common.Address,
+	s signer.Signer,
+	feeConfig txutil.FeeConfig,
+	waitConfig txutil.WaitConfig,
+	amount *big.Int,
+) (*contracts.ZKOnacciPrizeDeposited, error) {
+	if amount.Sign() <= 0 {
+		return nil, errors.New("the amount to deposit must be greater than 0")
+	}
+	zkOnacci, err := contracts.NewZKOnacci(scAddr, backend)
+	if err != nil {
+		return nil, err
+	}
+	receipt, err := sendAndWait(ctx, backend, s, feeConfig, waitConfig, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		opts.Value = amount
+		return zkOnacci.Deposit(opts)
+	})
+	if err != nil {
+		return nil, err
+	}
+	log, err := findEvent(receipt, scAddr, "PrizeDeposited")
+	if err != nil {
+		return nil, err
+	}
+	return zkOnacci.ParsePrizeDeposited(*log)
+}
+
+// withdraw sends the rewards credited to the signer on the zkOnacci at scAddr
+func withdraw(
+	ctx context.Context,
+	backend prizeBackend,
+	scAddr common.Address,
+	s signer.Signer,
+	feeConfig txutil.FeeConfig,
+	waitConfig txutil.WaitConfig,
+) (*contracts.ZKOnacciRewardWithdrawn, error) {
+	zkOnacci, err := contracts.NewZKOnacci(scAddr, backend)
+	if err != nil {
+		return nil, err
+	}
+	// Fail early instead of sending a tx that reverts
+	credited, err := zkOnacci.Rewards(&bind.CallOpts{Context: ctx}, s.Address())
+	if err != nil {
+		return nil, err
+	}
+	if credited.Sign() == 0 {
+		return nil, fmt.Errorf("%s has no rewards to withdraw", s.Address().Hex())
+	}
+	receipt, err := sendAndWait(ctx, backend, s, feeConfig, waitConfig, zkOnacci.Withdraw)
+	if err != nil {
+		return nil, err
+	}
+	log, err := findEvent(receipt, scAddr, "RewardWithdrawn")
+	if err != nil {
+		return nil, err
+	}
+	return zkOnacci.ParseRewardWithdrawn(*log)
+}
+
+// sendAndWait sends the tx built by send, signed by s, and waits for it to be mined
+func sendAndWait(
+	ctx context.Context,
+	backend prizeBackend,
+	s signer.Signer,
+	feeConfig txutil.FeeConfig,
+	waitConfig txutil.WaitConfig,
+	send func(*bind.TransactOpts) (*types.Transaction, error),
+) (*types.Receipt, error) {
+	auth, err := txutil.NewTransactOpts(ctx, backend, s, feeConfig)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := txutil.Send(auth, feeConfig, send)
+	if err != nil {
+		return nil, err
+	}
+	fmt.Println("Tx sent to the blockchain. Tx Hash:", tx.Hash().Hex())
+	return txutil.WaitMined(ctx, backend, tx, waitConfig)
+}
+
+// findEvent returns the first log of receipt emitted by scAddr with the event name of zkOnacci
+func findEvent(receipt *types.Receipt, scAddr common.Address, name string) (*types.Log, error) {
+	zkOnacciABI, err := abi.JSON(strings.NewReader(contracts.ZKOnacciABI))
+	if err != nil {
+		return nil, err
+	}
+	eventID := zkOnacciABI.Events[name].ID
+	for _, log := range receipt.Logs {
+		if log.Address == scAddr && len(log.Topics) > 0 && log.Topics[0] == eventID {
+			return log, nil
+		}
+	}
+	return nil, fmt.Errorf("%s event not found in the tx receipt", name)
+}
+
+// rewardsStatus holds the prize pool of the game, the rewards of the current season of a puzzle and the rewards
+// credited to a player. Amounts are decimal strings in wei
+type rewardsStatus struct {
+	Contract common.Address `json:"contract"`
+	// PrizePool is the amount that hasn't been credited to any player yet
+	PrizePool string `json:"prizePool"`
+	// Balance of the contract: the prize pool plus the rewards that haven't been withdrawn
+	Balance     string         `json:"balance"`
+	Puzzle      uint64         `json:"puzzle"`
+	TierRewards []string       `json:"tierRewards"`
+	Player      common.Address `json:"player"`
+	Rewards     string         `json:"rewards"`
+}
+
+// readRewards reads the prize pool of the zkOnacci at scAddr, the tier rewards of the puzzle and the rewards
+// credited to player
+func readRewards(ctx context.Context, backend rewardsBackend, scAddr common.Address, puzzle uint64, player common.Address) (*rewardsStatus, error) {
+	zkOnacci, err := contracts.NewZKOnacciCaller(scAddr, backend)
+	if err != nil {
+		return nil, err
+	}
+	callOpts := &bind.CallOpts{Context: ctx}
+	prizePool, err := zkOnacci.PrizePool(callOpts)
+	if err != nil {
+		return nil, err
+	}
+	balance, err := backend.BalanceAt(ctx, scAddr, nil)
+	if err != nil {
+		return nil, err
+	}
+	tierRewards, err := zkOnacci.TierRewards(callOpts, new(big.Int).SetUint64(puzzle))
+	if err != nil {
+		return nil, err
+	}
+	credited, err := zkOnacci.Rewards(callOpts, player)
+	if err != nil {
+		return nil, err
+	}
+	r := &rewardsStatus{
+		Contract:    scAddr,
+		PrizePool:   prizePool.String(),
+		Balance:     balance.String(),
+		Puzzle:      puzzle,
+		TierRewards: make([]string, len(tierRewards)),
+		Player:      player,
+		Rewards:     credited.String(),
+	}
+	for i, reward := range tierRewards {
+		r.TierRewards[i] = reward.String()
+	}
+	return r, nil
+}
+
+// print writes the rewards in a human readable format to stdout
+func (r *rewardsStatus) print() {
+	fmt.Println("Contract:", r.Contract.Hex())
+	fmt.Println("Prize pool:", r.PrizePool, "wei (contract balance:", r.Balance, "wei)")
+	if len(r.TierRewards) == 0 {
+		fmt.Println("The current season of the puzzle", r.Puzzle, "has no rewards")
+	} else {
+		fmt.Println("Rewards of the current season of the puzzle", r.Puzzle, ":")
+		for tier, reward := range r.TierRewards {
+			fmt.Printf("  tier %d: %s wei\n", tier, reward)
+		}
+	}
+	fmt.Println("Rewards of", r.Player.Hex(), "to withdraw:", r.Rewards, "wei")
+}
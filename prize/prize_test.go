@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/arnaubennassar/zkOnacci/contracts"
+	"github.com/arnaubennassar/zkOnacci/signer"
+	"github.com/arnaubennassar/zkOnacci/testutil"
+	"github.com/arnaubennassar/zkOnacci/txutil"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// prizeEnv is a game where the tokens of the first tier are rewarded with 1000 wei
+type prizeEnv struct {
+	backend    testutil.SimulatedBackend
+	owner      signer.Signer
+	ownerAuth  *bind.TransactOpts
+	scAddr     common.Address
+	zkOnacci   *contracts.ZKOnacci
+	waitConfig txutil.WaitConfig
+}
+
+func newPrizeEnv(t *testing.T, keys ...*ecdsa.PrivateKey) *prizeEnv {
+	ownerKey := testutil.NewKey(t)
+	backend := testutil.NewSimulatedBackend(append(keys, ownerKey)...)
+	ownerAuth := testutil.NewTransactor(t, ownerKey)
+	_, scAddr, zkOnacci := testutil.Deploy(t, backend, ownerAuth)
+	_, err := zkOnacci.SetTierRewards(ownerAuth, big.NewInt(0), []*big.Int{big.NewInt(1000), big.NewInt(0), big.NewInt(0), big.NewInt(0)})
+	require.NoError(t, err)
+	backend.Commit()
+	return &prizeEnv{
+		backend:   backend,
+		owner:     signer.NewKeySigner(ownerKey),
+		ownerAuth: ownerAuth,
+		scAddr:    scAddr,
+		zkOnacci:  zkOnacci,
+		waitConfig: txutil.WaitConfig{
+			PollInterval: time.Millisecond * 10,
+			OnPending: func(*types.Receipt, uint64, uint64) {
+				backend.Commit()
+			},
+		},
+	}
+}
+
+func (env *prizeEnv) fund(t *testing.T, s signer.Signer, amount int64) {
+	deposited, err := fund(context.Background(), env.backend, env.scAddr, s, txutil.FeeConfig{}, env.waitConfig, big.NewInt(amount))
+	require.NoError(t, err)
+	assert.Equal(t, s.Address(), deposited.Sponsor)
+	assert.Equal(t, amount, deposited.Amount.Int64())
+}
+
+func (env *prizeEnv) readRewards(t *testing.T, player common.Address) *rewardsStatus {
+	r, err := readRewards(context.Background(), env.backend, env.scAddr, 0, player)
+	require.NoError(t, err)
+	return r
+}
+
+func TestFundAndWithdraw(t *testing.T) {
+	ctx := context.Background()
+	sponsorKey, playerKey := testutil.NewKey(t), testutil.NewKey(t)
+	env := newPrizeEnv(t, sponsorKey, playerKey)
+	sponsor, player := signer.NewKeySigner(sponsorKey), signer.NewKeySigner(playerKey)
+
+	// Sponsors fund the prize pool
+	env.fund(t, sponsor, 1500)
+	_, err := fund(ctx, env.backend, env.scAddr, sponsor, txutil.FeeConfig{}, env.waitConfig, big.NewInt(0))
+	require.Error(t, err)
+	assert.Equal(t, &rewardsStatus{
+		Contract:    env.scAddr,
+		PrizePool:   "1500",
+		Balance:     "1500",
+		Puzzle:      0,
+		TierRewards: []string{"1000", "0", "0", "0"},
+		Player:      player.Address(),
+		Rewards:     "0",
+	}, env.readRewards(t, player.Address()))
+	_, err = withdraw(ctx, env.backend, env.scAddr, player, txutil.FeeConfig{}, env.waitConfig)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no rewards")
+
+	// The capture of a token of the first tier credits its reward
+	capture := testutil.ProveFirstCapture(t, player.Address())
+	_, err = env.zkOnacci.CaptureTheFlag(testutil.NewTransactor(t, playerKey), big.NewInt(0), capture.ProofA, capture.ProofB, capture.ProofC, capture.NextRoot)
+	require.NoError(t, err)
+	env.backend.Commit()
+	r := env.readRewards(t, player.Address())
+	assert.Equal(t, "500", r.PrizePool)
+	assert.Equal(t, "1500", r.Balance)
+	assert.Equal(t, "1000", r.Rewards)
+
+	// The player withdraws them
+	withdrawn, err := withdraw(ctx, env.backend, env.scAddr, player, txutil.FeeConfig{}, env.waitConfig)
+	require.NoError(t, err)
+	assert.Equal(t, player.Address(), withdrawn.Player)
+	assert.Equal(t, int64(1000), withdrawn.Amount.Int64())
+	r = env.readRewards(t, player.Address())
+	assert.Equal(t, "500", r.Balance)
+	assert.Equal(t, "0", r.Rewards)
+	_, err = withdraw(ctx, env.backend, env.scAddr, player, txutil.FeeConfig{}, env.waitConfig)
+	require.Error(t, err)
+}
+
+// The attacker contract (testdata/attacker.sol, bindings generated into attacker_test.go) reenters withdraw
+// when it receives its rewards
+func TestWithdrawReentrancy(t *testing.T) {
+	ctx := context.Background()
+	env := newPrizeEnv(t)
+	env.fund(t, env.owner, 3000)
+	attackerAddr, _, attacker, err := DeployPrizeAttacker(env.ownerAuth, env.backend, env.scAddr)
+	require.NoError(t, err)
+	env.backend.Commit()
+	capture := testutil.ProveFirstCapture(t, attackerAddr)
+	_, err = attacker.Capture(env.ownerAuth, big.NewInt(0), capture.ProofA, capture.ProofB, capture.ProofC, capture.NextRoot)
+	require.NoError(t, err)
+	env.backend.Commit()
+	assert.Equal(t, "1000", env.readRewards(t, attackerAddr).Rewards)
+	opts := *env.ownerAuth
+	opts.GasLimit = 0
+
+	// A failed transfer keeps the rewards
+	_, err = attacker.SetRejectPayments(&opts, true)
+	require.NoError(t, err)
+	env.backend.Commit()
+	_, err = attacker.Withdraw(&opts)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "TRANSFER_FAILED")
+	assert.Equal(t, "1000", env.readRewards(t, attackerAddr).Rewards)
+
+	// Reentering withdraw doesn't pay the rewards twice
+	_, err = attacker.SetRejectPayments(&opts, false)
+	require.NoError(t, err)
+	env.backend.Commit()
+	tx, err := attacker.Withdraw(&opts)
+	require.NoError(t, err)
+	env.backend.Commit()
+	receipt, err := env.backend.TransactionReceipt(ctx, tx.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	reentries, err := attacker.Reentries(&bind.CallOpts{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), reentries.Int64())
+	reentriesSucceeded, err := attacker.ReentriesSucceeded(&bind.CallOpts{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), reentriesSucceeded.Int64())
+	attackerBalance, err := env.backend.BalanceAt(ctx, attackerAddr, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000), attackerBalance.Int64())
+	r := env.readRewards(t, attackerAddr)
+	assert.Equal(t, "0", r.Rewards)
+	assert.Equal(t, "2000", r.PrizePool)
+	assert.Equal(t, "2000", r.Balance)
+}
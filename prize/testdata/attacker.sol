@@ -0,0 +1,47 @@
+pragma solidity ^0.8.6;
+
+interface IZKOnacci {
+    function captureTheFlag(uint256 puzzleId, uint[2] memory proofA, uint[2][2] memory proofB, uint[2] memory proofC, uint256 nextRoot)
+        external returns (uint256);
+    function withdraw() external;
+}
+
+// Bindings of the tests: abigen -sol prize/testdata/attacker.sol -pkg main -out prize/attacker_test.go
+
+// Player that tries to withdraw its rewards twice by reentering zkOnacci when it receives them,
+// or rejects them if rejectPayments is set
+contract PrizeAttacker {
+    IZKOnacci zkOnacci;
+    bool public rejectPayments;
+    uint256 public reentries;
+    uint256 public reentriesSucceeded;
+
+    constructor(address zkOnacciAddr) {
+        zkOnacci = IZKOnacci(zkOnacciAddr);
+    }
+
+    function setRejectPayments(bool reject) public {
+        rejectPayments = reject;
+    }
+
+    function capture(uint256 puzzleId, uint[2] memory proofA, uint[2][2] memory proofB, uint[2] memory proofC, uint256 nextRoot) public {
+        zkOnacci.captureTheFlag(puzzleId, proofA, proofB, proofC, nextRoot);
+    }
+
+    function withdraw() public {
+        zkOnacci.withdraw();
+    }
+
+    receive() external payable {
+        require(!rejectPayments, "PrizeAttacker: REJECTED");
+        reentries++;
+        try zkOnacci.withdraw() {
+            reentriesSucceeded++;
+        } catch {}
+    }
+
+    // The NFT is minted with _safeMint
+    function onERC721Received(address, address, uint256, bytes memory) public pure returns (bytes4) {
+        return this.onERC721Received.selector;
+    }
+}
@@ -0,0 +1,139 @@
+package recurrence
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+)
+
+// MaxOrder is the maximum amount of previous numbers used by a recurrence, each of them adds a Merkle proof to the circuit
+const MaxOrder = 8
+
+// Recurrence is a puzzle variant: the sequence F(n) = c[0]*F(n-1) + ... + c[order-1]*F(n-order) (in the field of
+// the circuit), that starts with the seeds F(0) ... F(order-1)
+type Recurrence struct {
+	// Name identifies the variant, its circom artifacts are in the directory with this name of the artifacts path
+	Name         string  `json:"name"`
+	Coefficients []int64 `json:"coefficients"`
+	Seeds        []int64 `json:"seeds"`
+}
+
+var builtins = map[string]Recurrence{
+	"fibonacci":  {Name: "fibonacci", Coefficients: []int64{1, 1}, Seeds: []int64{0, 1}},
+	"lucas":      {Name: "lucas", Coefficients: []int64{1, 1}, Seeds: []int64{2, 1}},
+	"pell":       {Name: "pell", Coefficients: []int64{2, 1}, Seeds: []int64{0, 1}},
+	"tribonacci": {Name: "tribonacci", Coefficients: []int64{1, 1, 1}, Seeds: []int64{0, 0, 1}},
+}
+
+// Builtins returns the names of the built-in variants
+func Builtins() []string {
+	names := make([]string, 0, len(builtins))
+	for name := range builtins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Load returns the built-in variant called nameOrPath, or reads the variant of the JSON file nameOrPath
+func Load(nameOrPath string) (*Recurrence, error) {
+	if r, ok := builtins[nameOrPath]; ok {
+		return &r, nil
+	}
+	if !strings.HasSuffix(nameOrPath, ".json") {
+		return nil, fmt.Errorf("unknown recurrence %s, use one of %s or a JSON file", nameOrPath, strings.Join(Builtins(), ", "))
+	}
+	content, err := ioutil.ReadFile(nameOrPath)
+	if err != nil {
+		return nil, err
+	}
+	r := &Recurrence{}
+	if err := json.Unmarshal(content, r); err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", nameOrPath, err)
+	}
+	if err := r.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid recurrence %s: %w", nameOrPath, err)
+	}
+	return r, nil
+}
+
+var validName = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// Validate checks that the recurrence can be turned into a circuit
+func (r *Recurrence) Validate() error {
+	if !validName.MatchString(r.Name) {
+		return fmt.Errorf("invalid name %q, it must be lower case letters, digits and underscores", r.Name)
+	}
+	if len(r.Coefficients) == 0 || len(r.Coefficients) > MaxOrder {
+		return fmt.Errorf("the order must be between 1 and %d, got %d coefficients", MaxOrder, len(r.Coefficients))
+	}
+	if len(r.Seeds) != len(r.Coefficients) {
+		return fmt.Errorf("%d seeds are needed for %d coefficients, got %d", len(r.Coefficients), len(r.Coefficients), len(r.Seeds))
+	}
+	if r.Coefficients[len(r.Coefficients)-1] == 0 {
+		return errors.New("the last coefficient can't be 0, use a lower order instead")
+	}
+	return nil
+}
+
+// Order returns the amount of previous numbers needed to get the next one
+func (r *Recurrence) Order() int {
+	return len(r.Coefficients)
+}
+
+// Next returns the number that follows prev, where prev[i] is F(n-1-i)
+func (r *Recurrence) Next(prev []*big.Int) *big.Int {
+	next := new(big.Int)
+	for i, c := range r.Coefficients {
+		next.Add(next, new(big.Int).Mul(big.NewInt(c), prev[i]))
+	}
+	return next.Mod(next, bn256.Order)
+}
+
+// Value returns F(n)
+func (r *Recurrence) Value(n int) *big.Int {
+	if n < r.Order() {
+		return new(big.Int).Mod(big.NewInt(r.Seeds[n]), bn256.Order)
+	}
+	prev := r.seeds()
+	for i := r.Order(); i < n; i++ {
+		prev = append([]*big.Int{r.Next(prev)}, prev[:len(prev)-1]...)
+	}
+	return r.Next(prev)
+}
+
+// seeds returns the seeds as field elements, from the last one to the first one (the order of Next)
+func (r *Recurrence) seeds() []*big.Int {
+	prev := make([]*big.Int, r.Order())
+	for i, seed := range r.Seeds {
+		prev[r.Order()-1-i] = new(big.Int).Mod(big.NewInt(seed), bn256.Order)
+	}
+	return prev
+}
+
+// ArtifactsPath returns the directory of the circom artifacts of the variant
+func (r *Recurrence) ArtifactsPath(artifactsPath string) string {
+	return filepath.Join(artifactsPath, r.Name)
+}
+
+// Circuit returns the main circuit of the variant, to be saved in its artifacts directory
+func (r *Recurrence) Circuit(nLevels int) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "// Generated from the recurrence %s: coefficients %v, seeds %v\n", r.Name, r.Coefficients, r.Seeds)
+	b.WriteString("include \"../recurrence.circom\";\n\n")
+	b.WriteString("function coefficient(i) {\n")
+	for i, c := range r.Coefficients {
+		fmt.Fprintf(&b, "    if (i == %d) return %d;\n", i, c)
+	}
+	b.WriteString("    return 0;\n}\n\n")
+	fmt.Fprintf(&b, "component main = Recurrence(%d, %d);\n", nLevels, r.Order())
+	return b.String()
+}
@@ -0,0 +1,72 @@
+package recurrence
+
+import (
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValue(t *testing.T) {
+	for name, expected := range map[string][]int64{
+		"fibonacci":  {0, 1, 1, 2, 3, 5, 8, 13, 21},
+		"lucas":      {2, 1, 3, 4, 7, 11, 18, 29, 47},
+		"pell":       {0, 1, 2, 5, 12, 29, 70, 169, 408},
+		"tribonacci": {0, 0, 1, 1, 2, 4, 7, 13, 24},
+	} {
+		r, err := Load(name)
+		require.NoError(t, err)
+		for n, value := range expected {
+			assert.Equal(t, big.NewInt(value), r.Value(n), "%s(%d)", name, n)
+		}
+	}
+	// Values are reduced to the field of the circuit
+	negative := &Recurrence{Name: "negative", Coefficients: []int64{-1}, Seeds: []int64{1}}
+	require.NoError(t, negative.Validate())
+	assert.Equal(t, 1, negative.Value(1).Cmp(big.NewInt(0)))
+	assert.Equal(t, big.NewInt(1), negative.Value(2))
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+	r, err := Load(write("padovan.json", `{"name": "padovan", "coefficients": [0, 1, 1], "seeds": [1, 1, 1]}`))
+	require.NoError(t, err)
+	assert.Equal(t, 3, r.Order())
+	assert.Equal(t, big.NewInt(12), r.Value(10))
+	for _, invalid := range []string{
+		`{"name": "Padovan", "coefficients": [0, 1, 1], "seeds": [1, 1, 1]}`,
+		`{"name": "padovan", "coefficients": [], "seeds": []}`,
+		`{"name": "padovan", "coefficients": [0, 1, 1], "seeds": [1, 1]}`,
+		`{"name": "padovan", "coefficients": [1, 1, 0], "seeds": [1, 1, 1]}`,
+		`{"name": "long", "coefficients": [1, 1, 1, 1, 1, 1, 1, 1, 1], "seeds": [1, 1, 1, 1, 1, 1, 1, 1, 1]}`,
+	} {
+		_, err := Load(write("invalid.json", invalid))
+		assert.Error(t, err, invalid)
+	}
+	_, err = Load("fibonaci")
+	assert.Error(t, err)
+}
+
+func TestCircuit(t *testing.T) {
+	r, err := Load("pell")
+	require.NoError(t, err)
+	assert.Equal(t, `// Generated from the recurrence pell: coefficients [2 1], seeds [0 1]
+include "../recurrence.circom";
+
+function coefficient(i) {
+    if (i == 0) return 2;
+    if (i == 1) return 1;
+    return 0;
+}
+
+component main = Recurrence(6, 2);
+`, r.Circuit(6))
+}
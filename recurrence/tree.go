@@ -0,0 +1,123 @@
+package recurrence
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/iden3/go-merkletree"
+	"github.com/iden3/go-merkletree/db/memory"
+)
+
+// Input holds the inputs of the Recurrence circuit
+type Input struct {
+	Sender     common.Address     `json:"senderInput"`
+	Root       *merkletree.Hash   `json:"stateRoot"`
+	N          int                `json:"n"`
+	Fn         string             `json:"Fn"`
+	SiblingsFn []*merkletree.Hash `json:"siblingsFn"`
+	OldKeyFn   *merkletree.Hash   `json:"oldKeyFn"`
+	OldValueFn *merkletree.Hash   `json:"oldValueFn"`
+	IsOld0Fn   bool               `json:"isOld0Fn"`
+	// Fprev[i] is F(n-1-i), as a decimal string so big numbers are not rounded by snarkjs
+	Fprev         []string             `json:"Fprev"`
+	SiblingsFprev [][]*merkletree.Hash `json:"siblingsFprev"`
+}
+
+// Tree keeps a local copy of the MT that represents the state of the game: the leaf i holds F(i)
+type Tree struct {
+	recurrence *Recurrence
+	merkleTree *merkletree.MerkleTree
+	n          int        // next position of the sequence to be added to the tree
+	prev       []*big.Int // prev[i] is F(n-1-i)
+}
+
+// NewTree returns the tree with nLevels that holds the seeds of r
+func NewTree(r *Recurrence, nLevels int) (*Tree, error) {
+	merkleTree, err := merkletree.NewMerkleTree(memory.NewMemoryStorage(), nLevels)
+	if err != nil {
+		return nil, err
+	}
+	prev := r.seeds()
+	for i := 0; i < r.Order(); i++ {
+		if err := merkleTree.Add(big.NewInt(int64(i)), prev[r.Order()-1-i]); err != nil {
+			return nil, err
+		}
+	}
+	return &Tree{recurrence: r, merkleTree: merkleTree, n: r.Order(), prev: prev}, nil
+}
+
+// GenesisRoot returns the root of the tree with nLevels that holds the seeds of r
+func (r *Recurrence) GenesisRoot(nLevels int) (*big.Int, error) {
+	tree, err := NewTree(r, nLevels)
+	if err != nil {
+		return nil, err
+	}
+	return tree.Root().BigInt(), nil
+}
+
+// Root returns the current root of the tree
+func (t *Tree) Root() *merkletree.Hash {
+	return t.merkleTree.Root()
+}
+
+// N returns the next position of the sequence to be added to the tree
+func (t *Tree) N() int {
+	return t.n
+}
+
+// Advance adds the numbers of the sequence to the tree until the next position to be added is n
+func (t *Tree) Advance(n int) error {
+	if n < t.n {
+		return fmt.Errorf("can't go back to position %d, the tree already has %d numbers", n, t.n)
+	}
+	for t.n < n {
+		Fn := t.recurrence.Next(t.prev)
+		if err := t.merkleTree.Add(big.NewInt(int64(t.n)), Fn); err != nil {
+			return err
+		}
+		t.next(Fn)
+	}
+	return nil
+}
+
+// next moves the sequence one position forward, once Fn has been added to the tree
+func (t *Tree) next(Fn *big.Int) {
+	t.prev = append([]*big.Int{Fn}, t.prev[:len(t.prev)-1]...)
+	t.n++
+}
+
+// NextInput adds the next number of the sequence to the tree and returns the inputs of the circuit that
+// prove it, along with the roots before and after adding it
+func (t *Tree) NextInput(sender common.Address) (input Input, currentRoot, nextRoot *merkletree.Hash, err error) {
+	// Existence proofs for Fn-1 ... Fn-order BEFORE processing Fn
+	currentRoot = t.merkleTree.Root()
+	input = Input{
+		Sender:        sender,
+		Root:          currentRoot,
+		N:             t.n,
+		Fprev:         make([]string, len(t.prev)),
+		SiblingsFprev: make([][]*merkletree.Hash, len(t.prev)),
+	}
+	for i, value := range t.prev {
+		mtp, err := t.merkleTree.GenerateCircomVerifierProof(big.NewInt(int64(t.n-1-i)), nil)
+		if err != nil {
+			return Input{}, nil, nil, err
+		}
+		input.Fprev[i] = value.String()
+		input.SiblingsFprev[i] = mtp.Siblings
+	}
+	// Add Fn and get processing proof
+	Fn := t.recurrence.Next(t.prev)
+	mtpN, err := t.merkleTree.AddAndGetCircomProof(big.NewInt(int64(t.n)), Fn)
+	if err != nil {
+		return Input{}, nil, nil, err
+	}
+	input.Fn = Fn.String()
+	input.SiblingsFn = mtpN.Siblings
+	input.OldKeyFn = mtpN.OldKey
+	input.OldValueFn = mtpN.OldValue
+	input.IsOld0Fn = mtpN.IsOld0
+	t.next(Fn)
+	return input, currentRoot, t.merkleTree.Root(), nil
+}
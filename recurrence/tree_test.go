@@ -0,0 +1,78 @@
+package recurrence
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"testing"
+
+	"github.com/arnaubennassar/zkOnacci/game"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const nLevels = 6
+
+func TestGenesisRoot(t *testing.T) {
+	// The Fibonacci variant starts from the same tree as the zkOnacci circuit
+	fibonacci, err := Load("fibonacci")
+	require.NoError(t, err)
+	root, err := fibonacci.GenesisRoot(nLevels)
+	require.NoError(t, err)
+	expected, err := game.GenesisRoot(nLevels)
+	require.NoError(t, err)
+	assert.Equal(t, expected, root)
+	lucas, err := Load("lucas")
+	require.NoError(t, err)
+	lucasRoot, err := lucas.GenesisRoot(nLevels)
+	require.NoError(t, err)
+	assert.NotEqual(t, expected, lucasRoot)
+}
+
+func TestNextInput(t *testing.T) {
+	r, err := Load("tribonacci")
+	require.NoError(t, err)
+	tree, err := NewTree(r, nLevels)
+	require.NoError(t, err)
+	assert.Equal(t, 3, tree.N())
+	require.NoError(t, tree.Advance(5))
+	sender := common.HexToAddress("0x01")
+	genesisRoot := tree.Root()
+	input, currentRoot, nextRoot, err := tree.NextInput(sender)
+	require.NoError(t, err)
+	assert.Equal(t, genesisRoot, currentRoot)
+	assert.Equal(t, nextRoot, tree.Root())
+	assert.Equal(t, 6, tree.N())
+	assert.Equal(t, 5, input.N)
+	assert.Equal(t, r.Value(5).String(), input.Fn)
+	assert.Equal(t, []string{r.Value(4).String(), r.Value(3).String(), r.Value(2).String()}, input.Fprev)
+	require.Len(t, input.SiblingsFprev, 3)
+	assert.Len(t, input.SiblingsFprev[0], nLevels+1)
+	// The same tree is built by advancing another one
+	other, err := NewTree(r, nLevels)
+	require.NoError(t, err)
+	require.NoError(t, other.Advance(6))
+	assert.Equal(t, nextRoot, other.Root())
+	assert.Error(t, other.Advance(5))
+
+	// The inputs match the signals of the circuit template
+	circuit, err := ioutil.ReadFile("../circuits/recurrence.circom")
+	require.NoError(t, err)
+	signals := []string{}
+	for _, match := range regexp.MustCompile(`signal private input (\w+)`).FindAllSubmatch(circuit, -1) {
+		signals = append(signals, string(match[1]))
+	}
+	inputJSON, err := json.Marshal(input)
+	require.NoError(t, err)
+	fields := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(inputJSON, &fields))
+	keys := []string{}
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(signals)
+	sort.Strings(keys)
+	assert.Equal(t, signals, keys)
+}
@@ -0,0 +1,229 @@
+package recurrence
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+
+	"github.com/arnaubennassar/zkOnacci/contracts"
+	"github.com/arnaubennassar/zkOnacci/contracts/zkinputs"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+)
+
+// VerificationKeyFile is the name of the verification key exported by snarkjs in the artifacts directory of each variant
+const VerificationKeyFile = "verification_key.json"
+
+// VerifyingKey holds the constructor params of RecurrenceVerifier
+type VerifyingKey struct {
+	Alpha1 [2]*big.Int
+	Beta2  [2][2]*big.Int
+	Gamma2 [2][2]*big.Int
+	Delta2 [2][2]*big.Int
+	IC     [4][2]*big.Int
+}
+
+// verificationKeyFile is the verification key of a groth16 circuit exported by snarkjs. G1 points are [x, y, z]
+// and G2 points [[x0, x1], [y0, y1], [z0, z1]] (affine, so z = 1), encoded as decimal strings
+type verificationKeyFile struct {
+	Protocol string     `json:"protocol"`
+	NPublic  int        `json:"nPublic"`
+	Alpha1   []string   `json:"vk_alpha_1"`
+	Beta2    [][]string `json:"vk_beta_2"`
+	Gamma2   [][]string `json:"vk_gamma_2"`
+	Delta2   [][]string `json:"vk_delta_2"`
+	IC       [][]string `json:"IC"`
+}
+
+// LoadVerifyingKey reads the verification key of the variant from its artifacts directory
+func (r *Recurrence) LoadVerifyingKey(artifactsPath string) (*VerifyingKey, error) {
+	return LoadVerifyingKeyFile(filepath.Join(r.ArtifactsPath(artifactsPath), VerificationKeyFile))
+}
+
+// LoadVerifyingKeyFile reads a verification key exported by snarkjs, e.g. the one of the zkOnacci circuit
+func LoadVerifyingKeyFile(path string) (*VerifyingKey, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	file := verificationKeyFile{}
+	if err := json.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", path, err)
+	}
+	vk, err := file.verifyingKey()
+	if err != nil {
+		return nil, fmt.Errorf("invalid verification key %s: %w", path, err)
+	}
+	return vk, nil
+}
+
+// Verify checks a groth16 proof of the public inputs, in the format of the verifier contracts, against vk
+func (vk *VerifyingKey) Verify(proofA [2]*big.Int, proofB [2][2]*big.Int, proofC [2]*big.Int, public [3]*big.Int) bool {
+	a, errA := unmarshalG1(proofA)
+	b, errB := unmarshalG2(proofB)
+	c, errC := unmarshalG1(proofC)
+	if errA != nil || errB != nil || errC != nil {
+		return false
+	}
+	alpha, errAlpha := unmarshalG1(vk.Alpha1)
+	beta, errBeta := unmarshalG2(vk.Beta2)
+	gamma, errGamma := unmarshalG2(vk.Gamma2)
+	delta, errDelta := unmarshalG2(vk.Delta2)
+	if errAlpha != nil || errBeta != nil || errGamma != nil || errDelta != nil {
+		return false
+	}
+	// x = IC[0] + public[0] * IC[1] + ... (the inputs must be field elements, as in the verifier contracts)
+	x, err := unmarshalG1(vk.IC[0])
+	if err != nil {
+		return false
+	}
+	for i, input := range public {
+		if input.Sign() < 0 || input.Cmp(bn256.Order) >= 0 {
+			return false
+		}
+		ic, err := unmarshalG1(vk.IC[i+1])
+		if err != nil {
+			return false
+		}
+		x.Add(x, new(bn256.G1).ScalarMult(ic, input))
+	}
+	// e(-A, B) * e(alpha, beta) * e(x, gamma) * e(C, delta) = 1
+	return bn256.PairingCheck(
+		[]*bn256.G1{new(bn256.G1).Neg(a), alpha, x, c},
+		[]*bn256.G2{b, beta, gamma, delta},
+	)
+}
+
+// unmarshalG1 returns the G1 point [x, y]
+func unmarshalG1(point [2]*big.Int) (*bn256.G1, error) {
+	encoded, err := encodeCoordinates(point[0], point[1])
+	if err != nil {
+		return nil, err
+	}
+	p := new(bn256.G1)
+	if _, err := p.Unmarshal(encoded); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// unmarshalG2 returns the G2 point [[x1, x0], [y1, y0]], which is the encoding of bn256 (imaginary part first)
+func unmarshalG2(point [2][2]*big.Int) (*bn256.G2, error) {
+	encoded, err := encodeCoordinates(point[0][0], point[0][1], point[1][0], point[1][1])
+	if err != nil {
+		return nil, err
+	}
+	p := new(bn256.G2)
+	if _, err := p.Unmarshal(encoded); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// encodeCoordinates concatenates the coordinates as 32 bytes big endian numbers
+func encodeCoordinates(coordinates ...*big.Int) ([]byte, error) {
+	encoded := make([]byte, 0, 32*len(coordinates))
+	for _, c := range coordinates {
+		if c == nil || c.Sign() < 0 || c.BitLen() > 256 {
+			return nil, errors.New("invalid coordinate")
+		}
+		encoded = append(encoded, common.LeftPadBytes(c.Bytes(), 32)...)
+	}
+	return encoded, nil
+}
+
+// verifyingKey converts the points of the file to the format of RecurrenceVerifier
+func (f *verificationKeyFile) verifyingKey() (*VerifyingKey, error) {
+	if f.Protocol != "groth16" {
+		return nil, fmt.Errorf("the protocol must be groth16, got %q", f.Protocol)
+	}
+	if f.NPublic != 3 || len(f.IC) != 4 {
+		return nil, fmt.Errorf("3 public inputs are expected, got %d (%d IC points)", f.NPublic, len(f.IC))
+	}
+	vk := &VerifyingKey{}
+	var err error
+	if vk.Alpha1, err = g1Point(f.Alpha1); err != nil {
+		return nil, fmt.Errorf("vk_alpha_1: %w", err)
+	}
+	for _, g2 := range []struct {
+		name  string
+		point [][]string
+		dst   *[2][2]*big.Int
+	}{{"vk_beta_2", f.Beta2, &vk.Beta2}, {"vk_gamma_2", f.Gamma2, &vk.Gamma2}, {"vk_delta_2", f.Delta2, &vk.Delta2}} {
+		if *g2.dst, err = g2Point(g2.point); err != nil {
+			return nil, fmt.Errorf("%s: %w", g2.name, err)
+		}
+	}
+	for i, point := range f.IC {
+		if vk.IC[i], err = g1Point(point); err != nil {
+			return nil, fmt.Errorf("IC %d: %w", i, err)
+		}
+	}
+	return vk, nil
+}
+
+// g1Point returns [x, y] of the G1 point [x, y, 1]
+func g1Point(point []string) ([2]*big.Int, error) {
+	if len(point) != 3 || point[2] != "1" {
+		return [2]*big.Int{}, errors.New("an affine G1 point [x, y, 1] is expected")
+	}
+	x, err := parseCoordinate(point[0])
+	if err != nil {
+		return [2]*big.Int{}, err
+	}
+	y, err := parseCoordinate(point[1])
+	if err != nil {
+		return [2]*big.Int{}, err
+	}
+	return [2]*big.Int{x, y}, nil
+}
+
+// g2Point returns [[x1, x0], [y1, y0]] (the order of the pairing precompile) of the G2 point [[x0, x1], [y0, y1], [1, 0]]
+func g2Point(point [][]string) ([2][2]*big.Int, error) {
+	if len(point) != 3 || len(point[2]) != 2 || point[2][0] != "1" || point[2][1] != "0" {
+		return [2][2]*big.Int{}, errors.New("an affine G2 point [[x0, x1], [y0, y1], [1, 0]] is expected")
+	}
+	g2 := [2][2]*big.Int{}
+	for i := 0; i < 2; i++ {
+		if len(point[i]) != 2 {
+			return g2, errors.New("a G2 coordinate has 2 elements")
+		}
+		for j := 0; j < 2; j++ {
+			c, err := parseCoordinate(point[i][1-j])
+			if err != nil {
+				return g2, err
+			}
+			g2[i][j] = c
+		}
+	}
+	return g2, nil
+}
+
+func parseCoordinate(c string) (*big.Int, error) {
+	v, ok := new(big.Int).SetString(c, 10)
+	if !ok || v.Sign() < 0 {
+		return nil, fmt.Errorf("invalid coordinate %q", c)
+	}
+	return v, nil
+}
+
+// DeployVerifier deploys a RecurrenceVerifier with the verification key vk
+func DeployVerifier(auth *bind.TransactOpts, backend bind.ContractBackend, vk *VerifyingKey) (common.Address, *types.Transaction, error) {
+	addr, tx, _, err := contracts.DeployRecurrenceVerifier(auth, backend, vk.Alpha1, vk.Beta2, vk.Gamma2, vk.Delta2, vk.IC)
+	return addr, tx, err
+}
+
+// GenerateProof proves input with the circom artifacts of the variant
+func (r *Recurrence) GenerateProof(input Input, artifactsPath string) (
+	proofA [2]*big.Int,
+	proofB [2][2]*big.Int,
+	proofC [2]*big.Int,
+	err error,
+) {
+	return zkinputs.GenerateCircuitProof(input, r.ArtifactsPath(artifactsPath), r.Name)
+}
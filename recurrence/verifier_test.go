@@ -0,0 +1,202 @@
+package recurrence
+
+import (
+	"context"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/arnaubennassar/zkOnacci/contracts"
+	"github.com/arnaubennassar/zkOnacci/game"
+	"github.com/arnaubennassar/zkOnacci/testutil"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeVerificationKey writes the verification key of trapdoor as the one of r in a temp artifacts dir, which is returned
+func writeVerificationKey(t *testing.T, r *Recurrence, trapdoor *testutil.Groth16Trapdoor) string {
+	artifactsPath := t.TempDir()
+	require.NoError(t, os.MkdirAll(r.ArtifactsPath(artifactsPath), 0755))
+	path := filepath.Join(r.ArtifactsPath(artifactsPath), VerificationKeyFile)
+	require.NoError(t, ioutil.WriteFile(path, trapdoor.VerificationKeyJSON(t), 0644))
+	return artifactsPath
+}
+
+func TestLoadVerifyingKey(t *testing.T) {
+	r, err := Load("lucas")
+	require.NoError(t, err)
+	_, err = r.LoadVerifyingKey(t.TempDir())
+	assert.Error(t, err)
+	artifactsPath := writeVerificationKey(t, r, testutil.NewGroth16Trapdoor(t))
+	vk, err := r.LoadVerifyingKey(artifactsPath)
+	require.NoError(t, err)
+	for _, p := range vk.IC {
+		assert.NotNil(t, p[1])
+	}
+	// Only the verification keys of circuits with 3 public inputs are accepted
+	path := filepath.Join(r.ArtifactsPath(artifactsPath), VerificationKeyFile)
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{"protocol": "groth16", "nPublic": 2, "IC": [[], [], []]}`), 0644))
+	_, err = r.LoadVerifyingKey(artifactsPath)
+	assert.Error(t, err)
+}
+
+func TestVerify(t *testing.T) {
+	r, err := Load("fibonacci")
+	require.NoError(t, err)
+	trapdoor := testutil.NewGroth16Trapdoor(t)
+	vk, err := r.LoadVerifyingKey(writeVerificationKey(t, r, trapdoor))
+	require.NoError(t, err)
+	public := [3]*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)}
+	proofA, proofB, proofC := trapdoor.Prove(public)
+	assert.True(t, vk.Verify(proofA, proofB, proofC, public))
+	// Other public inputs
+	assert.False(t, vk.Verify(proofA, proofB, proofC, [3]*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(4)}))
+	// Inputs out of the field aren't reduced
+	outOfField := new(big.Int).Add(big.NewInt(3), bn256.Order)
+	assert.False(t, vk.Verify(proofA, proofB, proofC, [3]*big.Int{big.NewInt(1), big.NewInt(2), outOfField}))
+	// Other verification key
+	otherVK, err := r.LoadVerifyingKey(writeVerificationKey(t, r, testutil.NewGroth16Trapdoor(t)))
+	require.NoError(t, err)
+	assert.False(t, otherVK.Verify(proofA, proofB, proofC, public))
+	// Points that aren't on the curve
+	proofA[1] = new(big.Int).Add(proofA[1], big.NewInt(1))
+	assert.False(t, vk.Verify(proofA, proofB, proofC, public))
+}
+
+// variantEnv is a zkOnacci where a variant is played as the puzzle 1, verified by a RecurrenceVerifier
+type variantEnv struct {
+	backend      testutil.SimulatedBackend
+	auth         *bind.TransactOpts
+	verifierAddr common.Address
+	zkOnacci     *contracts.ZKOnacci
+	puzzle       *big.Int
+}
+
+// newVariantEnv deploys a RecurrenceVerifier with vk and adds r as a puzzle of zkOnacci with its genesis root and order
+func newVariantEnv(t *testing.T, r *Recurrence, vk *VerifyingKey) *variantEnv {
+	key := testutil.NewKey(t)
+	backend := testutil.NewSimulatedBackend(key)
+	auth := testutil.NewTransactor(t, key)
+	verifierAddr, _, err := DeployVerifier(auth, backend, vk)
+	require.NoError(t, err)
+	genesisRoot, err := r.GenesisRoot(nLevels)
+	require.NoError(t, err)
+	def := testutil.LoadGame(t)
+	_, _, zkOnacci := testutil.Deploy(t, backend, auth)
+	_, err = zkOnacci.AddPuzzle(auth, verifierAddr, uint8(r.Order()), genesisRoot, def.BaseURI, def.TokenTiers(), def.TokenURIs())
+	require.NoError(t, err)
+	backend.Commit()
+	return &variantEnv{backend: backend, auth: auth, verifierAddr: verifierAddr, zkOnacci: zkOnacci, puzzle: big.NewInt(1)}
+}
+
+// capture mines the capture of the next flag and returns its FlagCaptured event
+func (env *variantEnv) capture(
+	t *testing.T,
+	proofA [2]*big.Int,
+	proofB [2][2]*big.Int,
+	proofC [2]*big.Int,
+	nextRoot *big.Int,
+) *contracts.ZKOnacciFlagCaptured {
+	tx, err := env.zkOnacci.CaptureTheFlag(env.auth, env.puzzle, proofA, proofB, proofC, nextRoot)
+	require.NoError(t, err)
+	env.backend.Commit()
+	receipt, err := env.backend.TransactionReceipt(context.Background(), tx.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	captured, err := env.zkOnacci.ParseFlagCaptured(*receipt.Logs[0])
+	require.NoError(t, err)
+	root, err := env.zkOnacci.Root(&bind.CallOpts{}, env.puzzle)
+	require.NoError(t, err)
+	assert.Equal(t, nextRoot, root)
+	return captured
+}
+
+// TestVariants plays every built-in variant end to end: a RecurrenceVerifier is deployed with the verification key
+// of the variant and added as a puzzle of zkOnacci with its genesis root and order, then the flags are captured with
+// the inputs of the tree.
+// The proofs are forged with the trapdoor of the verification key, so no circuit artifacts are needed
+func TestVariants(t *testing.T) {
+	for _, name := range Builtins() {
+		t.Run(name, func(t *testing.T) {
+			r, err := Load(name)
+			require.NoError(t, err)
+			trapdoor := testutil.NewGroth16Trapdoor(t)
+			vk, err := r.LoadVerifyingKey(writeVerificationKey(t, r, trapdoor))
+			require.NoError(t, err)
+			env := newVariantEnv(t, r, vk)
+
+			tree, err := NewTree(r, nLevels)
+			require.NoError(t, err)
+			for i := 0; i < 3; i++ {
+				input, currentRoot, nextRoot, err := tree.NextInput(env.auth.From)
+				require.NoError(t, err)
+				assert.Equal(t, r.Order()+i, input.N)
+				sender := new(big.Int).SetBytes(env.auth.From.Bytes())
+				// A proof bound to another sender is rejected
+				otherA, otherB, otherC := trapdoor.Prove([3]*big.Int{big.NewInt(1), currentRoot.BigInt(), nextRoot.BigInt()})
+				_, err = env.zkOnacci.CaptureTheFlag(env.auth, env.puzzle, otherA, otherB, otherC, nextRoot.BigInt())
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "INVALID_ZK_PROOF")
+				// The proof of the next number is accepted
+				proofA, proofB, proofC := trapdoor.Prove([3]*big.Int{sender, currentRoot.BigInt(), nextRoot.BigInt()})
+				captured := env.capture(t, proofA, proofB, proofC, nextRoot.BigInt())
+				// The emitted n is the position of the proven number, after the seeds of the genesis tree
+				assert.Equal(t, int64(r.Order()+i), captured.N.Int64())
+			}
+			owner, err := env.zkOnacci.OwnerOf(&bind.CallOpts{}, game.TokenID(1, 0, 2))
+			require.NoError(t, err)
+			assert.Equal(t, env.auth.From, owner)
+			// The verifier rejects the proofs of other public inputs
+			verifier, err := contracts.NewRecurrenceVerifier(env.verifierAddr, env.backend)
+			require.NoError(t, err)
+			proofA, proofB, proofC := trapdoor.Prove([3]*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)})
+			ok, err := verifier.VerifyProof(&bind.CallOpts{}, proofA, proofB, proofC, [3]*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)})
+			require.NoError(t, err)
+			assert.True(t, ok)
+			ok, err = verifier.VerifyProof(&bind.CallOpts{}, proofA, proofB, proofC, [3]*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(4)})
+			require.NoError(t, err)
+			assert.False(t, ok)
+		})
+	}
+}
+
+// TestVariantsWithArtifacts plays every built-in variant with the circom artifacts built by circuitgen -build:
+// the proofs are generated with snarkjs, checked with the verification key and captured on chain.
+// The variants whose artifacts haven't been built are skipped
+func TestVariantsWithArtifacts(t *testing.T) {
+	for _, name := range Builtins() {
+		t.Run(name, func(t *testing.T) {
+			r, err := Load(name)
+			require.NoError(t, err)
+			artifactsDir := r.ArtifactsPath(testutil.ArtifactsPath)
+			for _, file := range []string{r.Name + ".wasm", r.Name + "_final.zkey", VerificationKeyFile} {
+				if _, err := os.Stat(filepath.Join(artifactsDir, file)); err != nil {
+					t.Skipf("the artifacts of %s are not built, run npm run build-recurrences: %s", name, err)
+				}
+			}
+			vk, err := r.LoadVerifyingKey(testutil.ArtifactsPath)
+			require.NoError(t, err)
+			env := newVariantEnv(t, r, vk)
+
+			tree, err := NewTree(r, nLevels)
+			require.NoError(t, err)
+			sender := new(big.Int).SetBytes(env.auth.From.Bytes())
+			for i := 0; i < 2; i++ {
+				input, currentRoot, nextRoot, err := tree.NextInput(env.auth.From)
+				require.NoError(t, err)
+				proofA, proofB, proofC, err := r.GenerateProof(input, testutil.ArtifactsPath)
+				require.NoError(t, err)
+				assert.True(t, vk.Verify(proofA, proofB, proofC, [3]*big.Int{sender, currentRoot.BigInt(), nextRoot.BigInt()}))
+				captured := env.capture(t, proofA, proofB, proofC, nextRoot.BigInt())
+				assert.Equal(t, int64(r.Order()+i), captured.N.Int64())
+				assert.Equal(t, game.TokenID(1, 0, uint64(i)), captured.TokenId)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package recurrence
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/arnaubennassar/zkOnacci/contracts/zkinputs"
+)
+
+// ZKOnacciInput converts the inputs of the Fibonacci recurrence to the inputs of the zkOnacci circuit
+func ZKOnacciInput(input Input) (zkinputs.ZKInput, error) {
+	values := make([]int, 0, 3)
+	for _, value := range append([]string{input.Fn}, input.Fprev...) {
+		v, ok := new(big.Int).SetString(value, 10)
+		if !ok || !v.IsInt64() {
+			return zkinputs.ZKInput{}, fmt.Errorf("the number %s doesn't fit the inputs of the zkOnacci circuit", value)
+		}
+		values = append(values, int(v.Int64()))
+	}
+	return zkinputs.ZKInput{
+		Sender:           input.Sender,
+		Root:             input.Root,
+		N:                input.N,
+		Fn:               values[0],
+		SiblingsFn:       input.SiblingsFn,
+		OldKeyFn:         input.OldKeyFn,
+		OldValueFn:       input.OldValueFn,
+		IsOld0Fn:         input.IsOld0Fn,
+		FnMinOne:         values[1],
+		SiblingsFnMinOne: input.SiblingsFprev[0],
+		FnMinTwo:         values[2],
+		SiblingsFnMinTwo: input.SiblingsFprev[1],
+	}, nil
+}
+
+// GenerateZKOnacciProof proves the inputs of the Fibonacci recurrence with the circom artifacts of the zkOnacci circuit
+func GenerateZKOnacciProof(input Input, artifactsPath string) (
+	proofA [2]*big.Int,
+	proofB [2][2]*big.Int,
+	proofC [2]*big.Int,
+	err error,
+) {
+	zkInput, err := ZKOnacciInput(input)
+	if err != nil {
+		return
+	}
+	return zkinputs.GenerateProof(zkInput, artifactsPath)
+}
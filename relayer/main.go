@@ -0,0 +1,65 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/arnaubennassar/zkOnacci/config"
+	"github.com/arnaubennassar/zkOnacci/recurrence"
+	"github.com/arnaubennassar/zkOnacci/signer"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+func main() {
+	configFlags := config.RegisterFlags(flag.CommandLine)
+	flag.Parse()
+	conf, err := configFlags.Load()
+	if err != nil {
+		panic(err)
+	}
+	// Set up client
+	if conf.Web3URL == "" {
+		panic("Must provide the web3 URL (web3URL of the profile, env var WEB3_URL or -web3-url flag)")
+	}
+	client, err := ethclient.Dial(conf.Web3URL)
+	if err != nil {
+		panic(err)
+	}
+	s, err := signer.New(conf.Signer)
+	if err != nil {
+		panic(err)
+	}
+	// The proofs are verified locally with the verification key of the circuit of the puzzle
+	vkPath := filepath.Join(conf.ArtifactsPath, recurrence.VerificationKeyFile)
+	if conf.Recurrence != "" {
+		variant, err := recurrence.Load(conf.Recurrence)
+		if err != nil {
+			panic(err)
+		}
+		vkPath = filepath.Join(variant.ArtifactsPath(conf.ArtifactsPath), recurrence.VerificationKeyFile)
+	}
+	vk, err := recurrence.LoadVerifyingKeyFile(vkPath)
+	if err != nil {
+		panic(err)
+	}
+	r, err := newRelayer(client, conf.ZKOnacciAddr, s, conf.Fees, conf.Puzzle, vk)
+	if err != nil {
+		panic(err)
+	}
+	listenAddr := os.Getenv("LISTEN_ADDR")
+	if listenAddr == "" {
+		listenAddr = ":8080"
+	}
+	server := &http.Server{
+		Addr:         listenAddr,
+		Handler:      r.handler(),
+		ReadTimeout:  time.Second * 10,
+		WriteTimeout: time.Minute,
+	}
+	fmt.Println("Relaying captures of the puzzle", conf.Puzzle, "from", s.Address().Hex(), "on", listenAddr)
+	panic(server.ListenAndServe())
+}
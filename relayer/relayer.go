@@ -0,0 +1,260 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/arnaubennassar/zkOnacci/contracts"
+	"github.com/arnaubennassar/zkOnacci/recurrence"
+	"github.com/arnaubennassar/zkOnacci/signer"
+	"github.com/arnaubennassar/zkOnacci/txutil"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// maxRequestSize is the max size of the body of a capture request
+const maxRequestSize = 1 << 14
+
+// errRejected is returned when a capture request is not relayed because it would fail on chain
+var errRejected = errors.New("capture rejected")
+
+// captureRequest is the body of POST /capture. The proof must have been generated with recipient as senderInput.
+// Numbers can be encoded as decimal or 0x prefixed hex strings. Puzzle defaults to 0
+type captureRequest struct {
+	Recipient common.Address              `json:"recipient"`
+	Puzzle    uint64                      `json:"puzzle"`
+	ProofA    [2]*math.HexOrDecimal256    `json:"proofA"`
+	ProofB    [2][2]*math.HexOrDecimal256 `json:"proofB"`
+	ProofC    [2]*math.HexOrDecimal256    `json:"proofC"`
+	NextRoot  *math.HexOrDecimal256       `json:"nextRoot"`
+}
+
+// captureResponse is the body returned by POST /capture
+type captureResponse struct {
+	TxHash common.Hash `json:"txHash,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+// args converts the request into the arguments of captureTheFlagFor
+func (r captureRequest) args() (puzzle *big.Int, proofA [2]*big.Int, proofB [2][2]*big.Int, proofC [2]*big.Int, nextRoot *big.Int, err error) {
+	toBigInt := func(n *math.HexOrDecimal256) *big.Int {
+		if n == nil {
+			err = fmt.Errorf("%w: missing proof values", errRejected)
+			return nil
+		}
+		return (*big.Int)(n)
+	}
+	for i := 0; i < 2; i++ {
+		proofA[i] = toBigInt(r.ProofA[i])
+		proofC[i] = toBigInt(r.ProofC[i])
+		for j := 0; j < 2; j++ {
+			proofB[i][j] = toBigInt(r.ProofB[i][j])
+		}
+	}
+	nextRoot = toBigInt(r.NextRoot)
+	puzzle = new(big.Int).SetUint64(r.Puzzle)
+	if r.Recipient == (common.Address{}) {
+		err = fmt.Errorf("%w: missing recipient", errRejected)
+	}
+	return
+}
+
+// relayerBackend is the functionality needed to send captureTheFlagFor txs and follow them
+type relayerBackend interface {
+	txutil.Backend
+	txutil.WaitBackend
+	PendingCallContract(ctx context.Context, call ethereum.CallMsg) ([]byte, error)
+}
+
+// relayedTx is a captureTheFlagFor tx sent by the relayer
+type relayedTx struct {
+	tx     *types.Transaction
+	puzzle uint64
+	// root is the root of the puzzle that the proof of the tx was checked against
+	root *big.Int
+}
+
+// relayer sends captureTheFlagFor txs paid by its own account on behalf of the players
+type relayer struct {
+	backend   relayerBackend
+	scAddr    common.Address
+	zkABI     abi.ABI
+	zkOnacci  *contracts.ZKOnacci
+	signer    signer.Signer
+	feeConfig txutil.FeeConfig
+	// puzzle is the puzzle whose captures are relayed, and vk the verification key of its circuit
+	puzzle uint64
+	vk     *recurrence.VerifyingKey
+	// mu serializes the txs sent by the relayer account, so they don't reuse nonces
+	mu sync.Mutex
+	// last is the last tx sent by the relayer
+	last *relayedTx
+}
+
+func newRelayer(
+	backend relayerBackend,
+	scAddr common.Address,
+	s signer.Signer,
+	feeConfig txutil.FeeConfig,
+	puzzle uint64,
+	vk *recurrence.VerifyingKey,
+) (*relayer, error) {
+	zkOnacci, err := contracts.NewZKOnacci(scAddr, backend)
+	if err != nil {
+		return nil, err
+	}
+	zkABI, err := abi.JSON(strings.NewReader(contracts.ZKOnacciABI))
+	if err != nil {
+		return nil, err
+	}
+	return &relayer{
+		backend:   backend,
+		scAddr:    scAddr,
+		zkABI:     zkABI,
+		zkOnacci:  zkOnacci,
+		signer:    s,
+		feeConfig: feeConfig,
+		puzzle:    puzzle,
+		vk:        vk,
+	}, nil
+}
+
+// check verifies the proof locally against root, and simulates the captureTheFlagFor call from the relayer account
+// against the pending state, so invalid proofs (or proofs of an outdated root) are rejected before spending any gas
+func (r *relayer) check(ctx context.Context, req captureRequest, root *big.Int) error {
+	puzzle, proofA, proofB, proofC, nextRoot, err := req.args()
+	if err != nil {
+		return err
+	}
+	recipient := new(big.Int).SetBytes(req.Recipient.Bytes())
+	if !r.vk.Verify(proofA, proofB, proofC, [3]*big.Int{recipient, root, nextRoot}) {
+		return fmt.Errorf("%w: invalid proof for the recipient and the current root", errRejected)
+	}
+	data, err := r.zkABI.Pack("captureTheFlagFor", req.Recipient, puzzle, proofA, proofB, proofC, nextRoot)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errRejected, err)
+	}
+	// The pending call is sent directly, as bind drops the errors of pending calls (go-ethereum v1.10.6)
+	msg := ethereum.CallMsg{From: r.signer.Address(), To: &r.scAddr, Data: data}
+	if _, err := r.backend.PendingCallContract(ctx, msg); err != nil {
+		return fmt.Errorf("%w: %s", errRejected, err)
+	}
+	return nil
+}
+
+// checkPending rejects new captures while the last tx of the relayer is pending and was checked against root of
+// the same puzzle, as any other capture of the same flag would revert once it's mined (even if the pending state
+// of the node lags)
+func (r *relayer) checkPending(ctx context.Context, puzzle uint64, root *big.Int) error {
+	if r.last == nil || r.last.puzzle != puzzle || r.last.root.Cmp(root) != 0 {
+		return nil
+	}
+	receipt, err := r.backend.TransactionReceipt(ctx, r.last.tx.Hash())
+	if err != nil && !errors.Is(err, ethereum.NotFound) {
+		return err
+	}
+	if receipt != nil {
+		// Mined, but it didn't move the root (reverted)
+		r.last = nil
+		return nil
+	}
+	if _, _, err := r.backend.TransactionByHash(ctx, r.last.tx.Hash()); errors.Is(err, ethereum.NotFound) {
+		// Dropped from the mempool
+		r.last = nil
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return fmt.Errorf("%w: the capture of the current flag is already pending on tx %s", errRejected, r.last.tx.Hash().Hex())
+}
+
+// relay checks the capture request and sends it to the SC
+func (r *relayer) relay(ctx context.Context, req captureRequest) (*types.Transaction, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	puzzle, proofA, proofB, proofC, nextRoot, err := req.args()
+	if err != nil {
+		return nil, err
+	}
+	callOpts := &bind.CallOpts{Context: ctx}
+	nPuzzles, err := r.zkOnacci.NPuzzles(callOpts)
+	if err != nil {
+		return nil, err
+	}
+	if puzzle.Cmp(nPuzzles) >= 0 {
+		return nil, fmt.Errorf("%w: unknown puzzle %d", errRejected, req.Puzzle)
+	}
+	if req.Puzzle != r.puzzle {
+		return nil, fmt.Errorf("%w: only the captures of the puzzle %d are relayed", errRejected, r.puzzle)
+	}
+	root, err := r.zkOnacci.Root(callOpts, puzzle)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.checkPending(ctx, req.Puzzle, root); err != nil {
+		return nil, err
+	}
+	if err := r.check(ctx, req, root); err != nil {
+		return nil, err
+	}
+	auth, err := txutil.NewTransactOpts(ctx, r.backend, r.signer, r.feeConfig)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := txutil.Send(auth, r.feeConfig, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return r.zkOnacci.CaptureTheFlagFor(opts, req.Recipient, puzzle, proofA, proofB, proofC, nextRoot)
+	})
+	if err != nil {
+		return nil, err
+	}
+	r.last = &relayedTx{tx: tx, puzzle: req.Puzzle, root: root}
+	return tx, nil
+}
+
+// handler returns the HTTP API of the relayer
+func (r *relayer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/capture", r.handleCapture)
+	return mux
+}
+
+func (r *relayer) handleCapture(w http.ResponseWriter, httpReq *http.Request) {
+	if httpReq.Method != http.MethodPost {
+		writeResponse(w, http.StatusMethodNotAllowed, captureResponse{Error: "method not allowed"})
+		return
+	}
+	var req captureRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, httpReq.Body, maxRequestSize)).Decode(&req); err != nil {
+		writeResponse(w, http.StatusBadRequest, captureResponse{Error: err.Error()})
+		return
+	}
+	tx, err := r.relay(httpReq.Context(), req)
+	if errors.Is(err, errRejected) {
+		writeResponse(w, http.StatusBadRequest, captureResponse{Error: err.Error()})
+		return
+	} else if err != nil {
+		fmt.Println("Error relaying capture for", req.Recipient.Hex(), ":", err)
+		writeResponse(w, http.StatusInternalServerError, captureResponse{Error: "error sending the tx"})
+		return
+	}
+	fmt.Println("Capture relayed for", req.Recipient.Hex(), ", tx hash:", tx.Hash())
+	writeResponse(w, http.StatusOK, captureResponse{TxHash: tx.Hash()})
+}
+
+func writeResponse(w http.ResponseWriter, status int, resp captureResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		fmt.Println("Error writing response:", err)
+	}
+}
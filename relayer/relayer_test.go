@@ -0,0 +1,250 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/arnaubennassar/zkOnacci/contracts"
+	"github.com/arnaubennassar/zkOnacci/recurrence"
+	"github.com/arnaubennassar/zkOnacci/signer"
+	"github.com/arnaubennassar/zkOnacci/testutil"
+	"github.com/arnaubennassar/zkOnacci/txutil"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testingEnv struct {
+	backend    testutil.SimulatedBackend
+	relayerKey *ecdsa.PrivateKey
+	zkOnacci   *contracts.ZKOnacci
+	relayer    *relayer
+}
+
+// newTestingEnv deploys the contracts with a funded relayer account
+func newTestingEnv(t *testing.T) testingEnv {
+	relayerKey := testutil.NewKey(t)
+	backend := testutil.NewSimulatedBackend(relayerKey)
+	_, scAddr, zkOnacci := testutil.Deploy(t, backend, testutil.NewTransactor(t, relayerKey))
+	vk, err := recurrence.LoadVerifyingKeyFile(filepath.Join(testutil.ArtifactsPath, recurrence.VerificationKeyFile))
+	require.NoError(t, err)
+	r, err := newRelayer(backend, scAddr, signer.NewKeySigner(relayerKey), txutil.FeeConfig{GasMargin: txutil.DefaultGasMargin}, 0, vk)
+	require.NoError(t, err)
+	return testingEnv{
+		backend:    backend,
+		relayerKey: relayerKey,
+		zkOnacci:   zkOnacci,
+		relayer:    r,
+	}
+}
+
+// proveFirstCapture returns the request to capture the first flag (n = 2) for recipient
+func proveFirstCapture(t *testing.T, recipient common.Address) captureRequest {
+	capture := testutil.ProveFirstCapture(t, recipient)
+	req := captureRequest{
+		Recipient: recipient,
+		NextRoot:  (*math.HexOrDecimal256)(capture.NextRoot),
+	}
+	for i := 0; i < 2; i++ {
+		req.ProofA[i] = (*math.HexOrDecimal256)(capture.ProofA[i])
+		req.ProofC[i] = (*math.HexOrDecimal256)(capture.ProofC[i])
+		for j := 0; j < 2; j++ {
+			req.ProofB[i][j] = (*math.HexOrDecimal256)(capture.ProofB[i][j])
+		}
+	}
+	return req
+}
+
+func postCapture(t *testing.T, url string, req captureRequest) (int, captureResponse) {
+	body, err := json.Marshal(req)
+	require.NoError(t, err)
+	httpResp, err := http.Post(url+"/capture", "application/json", bytes.NewReader(body))
+	require.NoError(t, err)
+	defer httpResp.Body.Close()
+	var resp captureResponse
+	require.NoError(t, json.NewDecoder(httpResp.Body).Decode(&resp))
+	return httpResp.StatusCode, resp
+}
+
+func TestRelayCapture(t *testing.T) {
+	env := newTestingEnv(t)
+	server := httptest.NewServer(env.relayer.handler())
+	defer server.Close()
+	ctx := context.Background()
+	// The player doesn't have any ETH
+	playerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	player := crypto.PubkeyToAddress(playerKey.PublicKey)
+	status, resp := postCapture(t, server.URL, proveFirstCapture(t, player))
+	require.Equal(t, http.StatusOK, status, resp.Error)
+	env.backend.Commit()
+	receipt, err := env.backend.TransactionReceipt(ctx, resp.TxHash)
+	require.NoError(t, err)
+	assert.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	owner, err := env.zkOnacci.OwnerOf(&bind.CallOpts{}, big.NewInt(0))
+	require.NoError(t, err)
+	assert.Equal(t, player, owner)
+	balance, err := env.backend.BalanceAt(ctx, player, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), balance.Int64())
+	// The same proof can't be relayed twice
+	status, resp = postCapture(t, server.URL, proveFirstCapture(t, player))
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, resp.Error, "invalid proof")
+	// Only the puzzles of the contract are relayed
+	otherPuzzle := proveFirstCapture(t, player)
+	otherPuzzle.Puzzle = 1
+	status, resp = postCapture(t, server.URL, otherPuzzle)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, resp.Error, "unknown puzzle 1")
+}
+
+func TestRelayerCannotRedirect(t *testing.T) {
+	env := newTestingEnv(t)
+	server := httptest.NewServer(env.relayer.handler())
+	defer server.Close()
+	ctx := context.Background()
+	relayerAddr := crypto.PubkeyToAddress(env.relayerKey.PublicKey)
+	playerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	player := crypto.PubkeyToAddress(playerKey.PublicKey)
+	req := proveFirstCapture(t, player)
+	puzzle, proofA, proofB, proofC, nextRoot, err := req.args()
+	require.NoError(t, err)
+	// Rejected off-chain when asking the relayer to send the NFT somewhere else
+	redirected := req
+	redirected.Recipient = relayerAddr
+	status, resp := postCapture(t, server.URL, redirected)
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, resp.Error, "invalid proof")
+	// Reverted on chain when the relayer sends the proof to itself
+	auth := testutil.NewTransactor(t, env.relayerKey)
+	auth.GasLimit = 1000000 // skip the estimation, which would fail
+	txFor, err := env.zkOnacci.CaptureTheFlagFor(auth, relayerAddr, puzzle, proofA, proofB, proofC, nextRoot)
+	require.NoError(t, err)
+	tx, err := env.zkOnacci.CaptureTheFlag(auth, puzzle, proofA, proofB, proofC, nextRoot)
+	require.NoError(t, err)
+	env.backend.Commit()
+	for _, tx := range []*types.Transaction{txFor, tx} {
+		receipt, err := env.backend.TransactionReceipt(ctx, tx.Hash())
+		require.NoError(t, err)
+		assert.Equal(t, types.ReceiptStatusFailed, receipt.Status)
+	}
+	tokenCounter, err := env.zkOnacci.TokenCounter(&bind.CallOpts{}, big.NewInt(0))
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), tokenCounter.Int64())
+	// The player still gets the flag
+	status, resp = postCapture(t, server.URL, req)
+	require.Equal(t, http.StatusOK, status, resp.Error)
+	env.backend.Commit()
+	owner, err := env.zkOnacci.OwnerOf(&bind.CallOpts{}, big.NewInt(0))
+	require.NoError(t, err)
+	assert.Equal(t, player, owner)
+}
+
+func TestRelayTwoProofsOfTheSameRoot(t *testing.T) {
+	env := newTestingEnv(t)
+	server := httptest.NewServer(env.relayer.handler())
+	defer server.Close()
+	players := make([]common.Address, 2)
+	reqs := make([]captureRequest, 2)
+	for i := range players {
+		playerKey, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		players[i] = crypto.PubkeyToAddress(playerKey.PublicKey)
+		reqs[i] = proveFirstCapture(t, players[i])
+	}
+	status, resp := postCapture(t, server.URL, reqs[0])
+	require.Equal(t, http.StatusOK, status, resp.Error)
+	// Rejected while the first capture is pending
+	status, resp = postCapture(t, server.URL, reqs[1])
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, resp.Error, "already pending")
+	// Also rejected by the simulation, which runs against the pending state
+	env.relayer.last = nil
+	status, resp = postCapture(t, server.URL, reqs[1])
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Contains(t, resp.Error, "INVALID_ZK_PROOF")
+	// Only the first capture is mined
+	env.backend.Commit()
+	tokenCounter, err := env.zkOnacci.TokenCounter(&bind.CallOpts{}, big.NewInt(0))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), tokenCounter.Int64())
+	owner, err := env.zkOnacci.OwnerOf(&bind.CallOpts{}, big.NewInt(0))
+	require.NoError(t, err)
+	assert.Equal(t, players[0], owner)
+}
+
+func TestRelayerVerifiesProofsLocally(t *testing.T) {
+	env := newTestingEnv(t)
+	ctx := context.Background()
+	relayerAuth := testutil.NewTransactor(t, env.relayerKey)
+	// A puzzle whose verifier accepts the proofs forged with trapdoor
+	r, err := recurrence.Load("fibonacci")
+	require.NoError(t, err)
+	trapdoor := testutil.NewGroth16Trapdoor(t)
+	vkPath := filepath.Join(t.TempDir(), recurrence.VerificationKeyFile)
+	require.NoError(t, ioutil.WriteFile(vkPath, trapdoor.VerificationKeyJSON(t), 0644))
+	vk, err := recurrence.LoadVerifyingKeyFile(vkPath)
+	require.NoError(t, err)
+	verifierAddr, _, err := recurrence.DeployVerifier(relayerAuth, env.backend, vk)
+	require.NoError(t, err)
+	genesisRoot, err := r.GenesisRoot(testutil.NLevels)
+	require.NoError(t, err)
+	def := testutil.LoadGame(t)
+	_, err = env.zkOnacci.AddPuzzle(relayerAuth, verifierAddr, uint8(r.Order()), genesisRoot, def.BaseURI, def.TokenTiers(), def.TokenURIs())
+	require.NoError(t, err)
+	env.backend.Commit()
+	playerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	player := crypto.PubkeyToAddress(playerKey.PublicKey)
+	nextRoot := big.NewInt(42)
+	proofA, proofB, proofC := trapdoor.Prove([3]*big.Int{new(big.Int).SetBytes(player.Bytes()), genesisRoot, nextRoot})
+	req := captureRequest{Recipient: player, Puzzle: 1, NextRoot: (*math.HexOrDecimal256)(nextRoot)}
+	for i := 0; i < 2; i++ {
+		req.ProofA[i] = (*math.HexOrDecimal256)(proofA[i])
+		req.ProofC[i] = (*math.HexOrDecimal256)(proofC[i])
+		for j := 0; j < 2; j++ {
+			req.ProofB[i][j] = (*math.HexOrDecimal256)(proofB[i][j])
+		}
+	}
+	newRelayerOf := func(vk *recurrence.VerifyingKey) *relayer {
+		r, err := newRelayer(env.backend, env.relayer.scAddr, env.relayer.signer, env.relayer.feeConfig, 1, vk)
+		require.NoError(t, err)
+		return r
+	}
+	// The proof is valid on chain, but not for the verification key of the relayer
+	otherVKPath := filepath.Join(t.TempDir(), recurrence.VerificationKeyFile)
+	require.NoError(t, ioutil.WriteFile(otherVKPath, testutil.NewGroth16Trapdoor(t).VerificationKeyJSON(t), 0644))
+	otherVK, err := recurrence.LoadVerifyingKeyFile(otherVKPath)
+	require.NoError(t, err)
+	_, err = newRelayerOf(otherVK).relay(ctx, req)
+	require.ErrorIs(t, err, errRejected)
+	assert.Contains(t, err.Error(), "invalid proof")
+	// Only the puzzle of the relayer is relayed
+	_, err = env.relayer.relay(ctx, req)
+	require.ErrorIs(t, err, errRejected)
+	assert.Contains(t, err.Error(), "only the captures of the puzzle 0")
+	// Relayed with the verification key of the puzzle
+	tx, err := newRelayerOf(vk).relay(ctx, req)
+	require.NoError(t, err)
+	env.backend.Commit()
+	receipt, err := env.backend.TransactionReceipt(ctx, tx.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	tokenCounter, err := env.zkOnacci.TokenCounter(&bind.CallOpts{}, big.NewInt(1))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), tokenCounter.Int64())
+}
@@ -0,0 +1,226 @@
+package signer
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/console/prompt"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core"
+)
+
+// ErrPrivateKeyNotAllowed is returned when a raw private key is provided without opting in
+var ErrPrivateKeyNotAllowed = errors.New("raw private keys are disabled, use a keystore or an external signer (or explicitly allow the PRIVATE_KEY env var)")
+
+// Signer signs transactions on behalf of an Ethereum account
+type Signer interface {
+	// Address of the account that signs the transactions
+	Address() common.Address
+	// SignTx signs tx for the given chain ID
+	SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+}
+
+// Config sets where the signing key comes from. The sources are used with the following precedence:
+// external signer, keystore and raw private key
+type Config struct {
+	// ExternalSigner is the endpoint of a Clef compatible signer
+	ExternalSigner string
+	// Address selects the account of the external signer (zero address = first account)
+	Address common.Address
+	// KeystorePath is the path of an encrypted go-ethereum keystore file
+	KeystorePath string
+	// PasswordFile is the path of the file that holds the passphrase of the keystore (empty = prompt)
+	PasswordFile string
+	// PrivateKey is a raw hex encoded private key (without the 0x)
+	PrivateKey string
+	// AllowPrivateKey has to be set in order to use PrivateKey
+	AllowPrivateKey bool
+}
+
+// ConfigFromEnv reads the signer configuration from the env vars SIGNER_URL, SIGNER_ADDRESS,
+// KEYSTORE_PATH, KEYSTORE_PASSWORD_FILE and PRIVATE_KEY
+func ConfigFromEnv() Config {
+	return Config{}.WithEnv()
+}
+
+// WithEnv returns cfg with the values overridden by the env vars that are set (see ConfigFromEnv)
+func (cfg Config) WithEnv() Config {
+	if url := os.Getenv("SIGNER_URL"); url != "" {
+		cfg.ExternalSigner = url
+	}
+	if addr := os.Getenv("SIGNER_ADDRESS"); addr != "" {
+		cfg.Address = common.HexToAddress(addr)
+	}
+	if keystorePath := os.Getenv("KEYSTORE_PATH"); keystorePath != "" {
+		cfg.KeystorePath = keystorePath
+	}
+	if passwordFile := os.Getenv("KEYSTORE_PASSWORD_FILE"); passwordFile != "" {
+		cfg.PasswordFile = passwordFile
+	}
+	if privateKey := os.Getenv("PRIVATE_KEY"); privateKey != "" {
+		cfg.PrivateKey = privateKey
+	}
+	return cfg
+}
+
+// New returns the signer described by cfg
+func New(cfg Config) (Signer, error) {
+	switch {
+	case cfg.ExternalSigner != "":
+		return NewExternalSigner(cfg.ExternalSigner, cfg.Address)
+	case cfg.KeystorePath != "":
+		var passphrase string
+		if cfg.PasswordFile != "" {
+			passphraseBytes, err := ioutil.ReadFile(cfg.PasswordFile)
+			if err != nil {
+				return nil, err
+			}
+			passphrase = strings.TrimRight(string(passphraseBytes), "\r\n")
+		} else {
+			var err error
+			passphrase, err = prompt.Stdin.PromptPassword("Keystore passphrase: ")
+			if err != nil {
+				return nil, err
+			}
+		}
+		return NewKeystoreSigner(cfg.KeystorePath, passphrase)
+	case cfg.PrivateKey != "":
+		if !cfg.AllowPrivateKey {
+			return nil, ErrPrivateKeyNotAllowed
+		}
+		privateKey, err := crypto.HexToECDSA(cfg.PrivateKey)
+		if err != nil {
+			return nil, err
+		}
+		return NewKeySigner(privateKey), nil
+	default:
+		return nil, errors.New("no signer provided: set SIGNER_URL or KEYSTORE_PATH")
+	}
+}
+
+// keySigner signs with a private key held in memory
+type keySigner struct {
+	privateKey *ecdsa.PrivateKey
+}
+
+// NewKeySigner returns a signer that uses privateKey
+func NewKeySigner(privateKey *ecdsa.PrivateKey) Signer {
+	return &keySigner{privateKey: privateKey}
+}
+
+// NewKeystoreSigner decrypts the go-ethereum keystore file found at path and returns a signer that uses its key
+func NewKeystoreSigner(path, passphrase string) (Signer, error) {
+	keyJSON, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := keystore.DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting keystore %s: %w", path, err)
+	}
+	return NewKeySigner(key.PrivateKey), nil
+}
+
+func (s *keySigner) Address() common.Address {
+	return crypto.PubkeyToAddress(s.privateKey.PublicKey)
+}
+
+func (s *keySigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return types.SignTx(tx, types.LatestSignerForChainID(chainID), s.privateKey)
+}
+
+// externalSigner delegates the signatures to a Clef compatible signer through JSON-RPC
+type externalSigner struct {
+	client  *rpc.Client
+	address common.Address
+}
+
+// NewExternalSigner connects to the Clef compatible signer at endpoint and returns a signer for the account
+// identified by address. If address is the zero address, the first account of the external signer is used
+func NewExternalSigner(endpoint string, address common.Address) (Signer, error) {
+	client, err := rpc.Dial(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	var accs []common.Address
+	if err := client.Call(&accs, "account_list"); err != nil {
+		return nil, err
+	}
+	if len(accs) == 0 {
+		return nil, fmt.Errorf("the external signer %s has no accounts", endpoint)
+	}
+	if address == (common.Address{}) {
+		return &externalSigner{client: client, address: accs[0]}, nil
+	}
+	for _, acc := range accs {
+		if acc == address {
+			return &externalSigner{client: client, address: acc}, nil
+		}
+	}
+	return nil, fmt.Errorf("the external signer %s doesn't manage the account %s", endpoint, address.Hex())
+}
+
+func (s *externalSigner) Address() common.Address {
+	return s.address
+}
+
+// SignTx builds the account_signTransaction args itself, as the ExternalSigner of go-ethereum sends
+// maxFeePerGas even for legacy txs. The tx returned by the external signer is checked against the requested one
+func (s *externalSigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	data := hexutil.Bytes(tx.Data())
+	args := &core.SendTxArgs{
+		From:    common.NewMixedcaseAddress(s.address),
+		Gas:     hexutil.Uint64(tx.Gas()),
+		Value:   hexutil.Big(*tx.Value()),
+		Nonce:   hexutil.Uint64(tx.Nonce()),
+		Data:    &data,
+		ChainID: (*hexutil.Big)(chainID),
+	}
+	if tx.To() != nil {
+		to := common.NewMixedcaseAddress(*tx.To())
+		args.To = &to
+	}
+	switch tx.Type() {
+	case types.LegacyTxType:
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	case types.DynamicFeeTxType:
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+		accessList := tx.AccessList()
+		args.AccessList = &accessList
+	default:
+		return nil, fmt.Errorf("tx type %d not supported by the external signer", tx.Type())
+	}
+	var res struct {
+		Raw hexutil.Bytes      `json:"raw"`
+		Tx  *types.Transaction `json:"tx"`
+	}
+	if err := s.client.Call(&res, "account_signTransaction", args); err != nil {
+		return nil, err
+	}
+	if res.Tx == nil {
+		return nil, errors.New("the external signer didn't return the signed tx")
+	}
+	ethSigner := types.LatestSignerForChainID(chainID)
+	if res.Tx.Type() != tx.Type() || ethSigner.Hash(res.Tx) != ethSigner.Hash(tx) {
+		return nil, errors.New("the tx signed by the external signer doesn't match the requested one")
+	}
+	sender, err := types.Sender(ethSigner, res.Tx)
+	if err != nil {
+		return nil, err
+	}
+	if sender != s.address {
+		return nil, fmt.Errorf("the tx has been signed by %s instead of %s", sender.Hex(), s.address.Hex())
+	}
+	return res.Tx, nil
+}
@@ -0,0 +1,203 @@
+package signer
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var chainID = big.NewInt(1337)
+
+var testTo = common.HexToAddress("0x36E9CA815e61d1C7a171E638Af5681e4aB8ACc65")
+
+// testTx returns an unsigned dynamic fee tx
+func testTx() *types.Transaction {
+	to := testTo
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     3,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(1000000000),
+		Gas:       100000,
+		To:        &to,
+		Value:     big.NewInt(0),
+		Data:      []byte{0xde, 0xad, 0xbe, 0xef},
+	})
+}
+
+// testLegacyTx returns an unsigned legacy tx
+func testLegacyTx() *types.Transaction {
+	to := testTo
+	return types.NewTx(&types.LegacyTx{
+		Nonce:    3,
+		GasPrice: big.NewInt(1000000000),
+		Gas:      100000,
+		To:       &to,
+		Value:    big.NewInt(0),
+		Data:     []byte{0xde, 0xad, 0xbe, 0xef},
+	})
+}
+
+// assertSignedBy checks that tx has been signed by address and that it's equal to unsigned
+func assertSignedBy(t *testing.T, address common.Address, unsigned, tx *types.Transaction) {
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), tx)
+	require.NoError(t, err)
+	assert.Equal(t, address, sender)
+	signer := types.LatestSignerForChainID(chainID)
+	assert.Equal(t, signer.Hash(unsigned), signer.Hash(tx))
+}
+
+func TestKeystoreSigner(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(privateKey.PublicKey)
+	// Write encrypted keystore and passphrase files
+	dir := t.TempDir()
+	keyJSON, err := keystore.EncryptKey(&keystore.Key{
+		Id:         uuid.New(),
+		Address:    address,
+		PrivateKey: privateKey,
+	}, "s3cr3t", keystore.LightScryptN, keystore.LightScryptP)
+	require.NoError(t, err)
+	keystorePath := filepath.Join(dir, "key.json")
+	require.NoError(t, ioutil.WriteFile(keystorePath, keyJSON, 0600))
+	passwordPath := filepath.Join(dir, "password")
+	require.NoError(t, ioutil.WriteFile(passwordPath, []byte("s3cr3t\n"), 0600))
+	// Sign
+	s, err := New(Config{KeystorePath: keystorePath, PasswordFile: passwordPath})
+	require.NoError(t, err)
+	assert.Equal(t, address, s.Address())
+	tx, err := s.SignTx(testTx(), chainID)
+	require.NoError(t, err)
+	assertSignedBy(t, address, testTx(), tx)
+	// Wrong passphrase
+	require.NoError(t, ioutil.WriteFile(passwordPath, []byte("wrong"), 0600))
+	_, err = New(Config{KeystorePath: keystorePath, PasswordFile: passwordPath})
+	assert.Error(t, err)
+}
+
+func TestPrivateKeyRequiresOptIn(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	privKeyHex := hexutil.Encode(crypto.FromECDSA(privateKey))[2:]
+	_, err = New(Config{PrivateKey: privKeyHex})
+	assert.Equal(t, ErrPrivateKeyNotAllowed, err)
+	s, err := New(Config{PrivateKey: privKeyHex, AllowPrivateKey: true})
+	require.NoError(t, err)
+	assert.Equal(t, crypto.PubkeyToAddress(privateKey.PublicKey), s.Address())
+}
+
+// clefStandIn implements the subset of the Clef JSON-RPC API used by the external signer.
+// Like Clef, it signs a legacy tx if gasPrice is set and a dynamic fee tx otherwise
+type clefStandIn struct {
+	keys map[common.Address]*ecdsa.PrivateKey
+	list []common.Address
+	// tamper makes the stand-in sign a tx with another nonce
+	tamper bool
+}
+
+func (c *clefStandIn) Version() string {
+	return "6.0.0"
+}
+
+func (c *clefStandIn) List() []common.Address {
+	return c.list
+}
+
+func (c *clefStandIn) SignTransaction(args core.SendTxArgs) (map[string]interface{}, error) {
+	to := args.To.Address()
+	nonce := uint64(args.Nonce)
+	if c.tamper {
+		nonce++
+	}
+	var tx *types.Transaction
+	switch {
+	case args.GasPrice != nil && (args.MaxFeePerGas != nil || args.MaxPriorityFeePerGas != nil):
+		return nil, errors.New("both gasPrice and (maxFeePerGas or maxPriorityFeePerGas) specified")
+	case args.GasPrice != nil:
+		tx = types.NewTx(&types.LegacyTx{
+			Nonce:    nonce,
+			GasPrice: (*big.Int)(args.GasPrice),
+			Gas:      uint64(args.Gas),
+			To:       &to,
+			Value:    args.Value.ToInt(),
+			Data:     *args.Data,
+		})
+	default:
+		tx = types.NewTx(&types.DynamicFeeTx{
+			ChainID:   (*big.Int)(args.ChainID),
+			Nonce:     nonce,
+			GasTipCap: (*big.Int)(args.MaxPriorityFeePerGas),
+			GasFeeCap: (*big.Int)(args.MaxFeePerGas),
+			Gas:       uint64(args.Gas),
+			To:        &to,
+			Value:     args.Value.ToInt(),
+			Data:      *args.Data,
+		})
+	}
+	signed, err := types.SignTx(tx, types.LatestSignerForChainID((*big.Int)(args.ChainID)), c.keys[args.From.Address()])
+	if err != nil {
+		return nil, err
+	}
+	raw, err := signed.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"raw": hexutil.Bytes(raw), "tx": signed}, nil
+}
+
+func TestExternalSigner(t *testing.T) {
+	clef := &clefStandIn{keys: map[common.Address]*ecdsa.PrivateKey{}}
+	for i := 0; i < 2; i++ {
+		privateKey, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		address := crypto.PubkeyToAddress(privateKey.PublicKey)
+		clef.keys[address] = privateKey
+		clef.list = append(clef.list, address)
+	}
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("account", clef))
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+	// Default account
+	s, err := New(Config{ExternalSigner: httpServer.URL})
+	require.NoError(t, err)
+	assert.Equal(t, clef.list[0], s.Address())
+	// Selected account
+	s, err = New(Config{ExternalSigner: httpServer.URL, Address: clef.list[1]})
+	require.NoError(t, err)
+	assert.Equal(t, clef.list[1], s.Address())
+	tx, err := s.SignTx(testTx(), chainID)
+	require.NoError(t, err)
+	assert.Equal(t, uint8(types.DynamicFeeTxType), tx.Type())
+	assertSignedBy(t, clef.list[1], testTx(), tx)
+	// Legacy tx
+	tx, err = s.SignTx(testLegacyTx(), chainID)
+	require.NoError(t, err)
+	assert.Equal(t, uint8(types.LegacyTxType), tx.Type())
+	assert.Equal(t, chainID, tx.ChainId())
+	assertSignedBy(t, clef.list[1], testLegacyTx(), tx)
+	// The signed tx doesn't match the requested one
+	clef.tamper = true
+	_, err = s.SignTx(testTx(), chainID)
+	assert.Error(t, err)
+	clef.tamper = false
+	// Unknown account
+	_, err = New(Config{ExternalSigner: httpServer.URL, Address: common.HexToAddress("0x01")})
+	assert.Error(t, err)
+}
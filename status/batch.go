@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// maxBatchSize is the max amount of calls sent in a single JSON-RPC batch
+const maxBatchSize = 100
+
+// batchCaller sends JSON-RPC requests in batches (implemented by *rpc.Client)
+type batchCaller interface {
+	BatchCallContext(ctx context.Context, b []rpc.BatchElem) error
+}
+
+// viewCall is a call to a view function of the SC. Once executed, results holds the unpacked outputs
+type viewCall struct {
+	method  string
+	args    []interface{}
+	results []interface{}
+}
+
+// batchViewCalls executes the calls to the SC at scAddr on top of the state of blockNumber,
+// sending them in batches of eth_call requests instead of one request per call
+func batchViewCalls(
+	ctx context.Context,
+	caller batchCaller,
+	contractABI abi.ABI,
+	scAddr common.Address,
+	blockNumber *big.Int,
+	calls []*viewCall,
+) error {
+	for start := 0; start < len(calls); start += maxBatchSize {
+		end := start + maxBatchSize
+		if end > len(calls) {
+			end = len(calls)
+		}
+		chunk := calls[start:end]
+		batch := make([]rpc.BatchElem, len(chunk))
+		for i, call := range chunk {
+			data, err := contractABI.Pack(call.method, call.args...)
+			if err != nil {
+				return err
+			}
+			batch[i] = rpc.BatchElem{
+				Method: "eth_call",
+				Args: []interface{}{
+					map[string]interface{}{"to": scAddr, "data": hexutil.Bytes(data)},
+					hexutil.EncodeBig(blockNumber),
+				},
+				Result: new(hexutil.Bytes),
+			}
+		}
+		if err := caller.BatchCallContext(ctx, batch); err != nil {
+			return err
+		}
+		for i, call := range chunk {
+			if batch[i].Error != nil {
+				return fmt.Errorf("%s: %w", call.method, batch[i].Error)
+			}
+			results, err := contractABI.Unpack(call.method, *batch[i].Result.(*hexutil.Bytes))
+			if err != nil {
+				return fmt.Errorf("%s: %w", call.method, err)
+			}
+			call.results = results
+		}
+	}
+	return nil
+}
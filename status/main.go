@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+
+	"github.com/arnaubennassar/zkOnacci/config"
+	"github.com/arnaubennassar/zkOnacci/contracts"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+func main() {
+	configFlags := config.RegisterFlags(flag.CommandLine)
+	jsonOutput := flag.Bool("json", false, "print the status as JSON")
+	flag.Parse()
+	conf, err := configFlags.Load()
+	if err != nil {
+		panic(err)
+	}
+	// Set up client
+	if conf.Web3URL == "" {
+		panic("Must provide the web3 URL (web3URL of the profile, env var WEB3_URL or -web3-url flag)")
+	}
+	ctx := context.Background()
+	rpcClient, err := rpc.DialContext(ctx, conf.Web3URL)
+	if err != nil {
+		panic(err)
+	}
+	client := ethclient.NewClient(rpcClient)
+	scAddr := conf.ZKOnacciAddr
+	zkOnacci, err := contracts.NewZKOnacciCaller(scAddr, client)
+	if err != nil {
+		panic(err)
+	}
+	// Read everything from the same block, so the status is consistent
+	head, err := client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		panic(err)
+	}
+	status, err := readStatus(ctx, rpcClient, zkOnacci, scAddr, head.Number)
+	if err != nil {
+		panic(err)
+	}
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(status); err != nil {
+			panic(err)
+		}
+		return
+	}
+	status.print()
+}
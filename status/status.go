@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/arnaubennassar/zkOnacci/contracts"
+	"github.com/arnaubennassar/zkOnacci/game"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// gameStatus is a snapshot of the state of the game at a given block
+type gameStatus struct {
+	Contract    common.Address  `json:"contract"`
+	BlockNumber uint64          `json:"blockNumber"`
+	Puzzles     []*puzzleStatus `json:"puzzles"`
+}
+
+// puzzleStatus is the progress of a puzzle on its current season
+type puzzleStatus struct {
+	Puzzle   uint64         `json:"puzzle"`
+	Verifier common.Address `json:"verifier"`
+	Season   uint64         `json:"season"`
+	// TokenCounter is the amount of tokens minted on the current season
+	TokenCounter uint64 `json:"tokenCounter"`
+	// NextN is the position of the sequence that must be proven to capture the next flag
+	NextN     uint64       `json:"nextN"`
+	Root      string       `json:"root"`
+	AllMinted bool         `json:"allMinted"`
+	Tiers     []tierStatus `json:"tiers"`
+	// Owners of the tokens of the current season
+	Owners []tokenOwner `json:"owners"`
+	// calls are the batched calls of the puzzle: tokenTiers and tokenURIs of each tier, followed by ownerOf of each token
+	calls   []*viewCall
+	baseURI string
+}
+
+// tierStatus describes the positions within the season of the tokens of a tier (FirstID and LastID included)
+type tierStatus struct {
+	Tier      int    `json:"tier"`
+	FirstID   uint64 `json:"firstId"`
+	LastID    uint64 `json:"lastId"`
+	URI       string `json:"uri"`
+	Remaining uint64 `json:"remaining"`
+}
+
+type tokenOwner struct {
+	// TokenID encodes the puzzle and the season, see game.TokenID
+	TokenID uint64         `json:"tokenId"`
+	Owner   common.Address `json:"owner"`
+}
+
+// readStatus reads the state of all the puzzles of the game at blockNumber. The scalar values are read through
+// the ZKOnacciCaller, the per tier and per token values of all the puzzles are batched
+func readStatus(
+	ctx context.Context,
+	caller batchCaller,
+	zkOnacci *contracts.ZKOnacciCaller,
+	scAddr common.Address,
+	blockNumber *big.Int,
+) (*gameStatus, error) {
+	callOpts := &bind.CallOpts{Context: ctx, BlockNumber: blockNumber}
+	nPuzzles, err := zkOnacci.NPuzzles(callOpts)
+	if err != nil {
+		return nil, err
+	}
+	status := &gameStatus{
+		Contract:    scAddr,
+		BlockNumber: blockNumber.Uint64(),
+		Puzzles:     []*puzzleStatus{},
+	}
+	calls := []*viewCall{}
+	for i := uint64(0); i < nPuzzles.Uint64(); i++ {
+		puzzle, err := readPuzzle(callOpts, zkOnacci, i)
+		if err != nil {
+			return nil, fmt.Errorf("error reading puzzle %d: %w", i, err)
+		}
+		status.Puzzles = append(status.Puzzles, puzzle)
+		calls = append(calls, puzzle.calls...)
+	}
+	zkOnacciABI, err := abi.JSON(strings.NewReader(contracts.ZKOnacciABI))
+	if err != nil {
+		return nil, err
+	}
+	if err := batchViewCalls(ctx, caller, zkOnacciABI, scAddr, blockNumber, calls); err != nil {
+		return nil, err
+	}
+	for _, puzzle := range status.Puzzles {
+		puzzle.setBatchResults()
+	}
+	return status, nil
+}
+
+// readPuzzle reads the scalar values of a puzzle and prepares the calls to be batched
+func readPuzzle(callOpts *bind.CallOpts, zkOnacci *contracts.ZKOnacciCaller, id uint64) (*puzzleStatus, error) {
+	puzzleID := new(big.Int).SetUint64(id)
+	tokenCounter, err := zkOnacci.TokenCounter(callOpts, puzzleID)
+	if err != nil {
+		return nil, err
+	}
+	season, err := zkOnacci.CurrentSeason(callOpts, puzzleID)
+	if err != nil {
+		return nil, err
+	}
+	root, err := zkOnacci.Root(callOpts, puzzleID)
+	if err != nil {
+		return nil, err
+	}
+	verifierAddr, err := zkOnacci.Verifier(callOpts, puzzleID)
+	if err != nil {
+		return nil, err
+	}
+	nTiers, err := zkOnacci.NTiers(callOpts, puzzleID)
+	if err != nil {
+		return nil, err
+	}
+	baseURI, err := zkOnacci.BaseURI(callOpts, puzzleID)
+	if err != nil {
+		return nil, err
+	}
+	// The first order numbers of the sequence are in the genesis tree
+	order, err := zkOnacci.Order(callOpts, puzzleID)
+	if err != nil {
+		return nil, err
+	}
+	puzzle := &puzzleStatus{
+		Puzzle:       id,
+		Verifier:     verifierAddr,
+		Season:       season.Uint64(),
+		TokenCounter: tokenCounter.Uint64(),
+		NextN:        tokenCounter.Uint64() + uint64(order),
+		Root:         root.String(),
+		Owners:       []tokenOwner{},
+		Tiers:        []tierStatus{},
+		baseURI:      baseURI,
+	}
+	for i := 0; i < int(nTiers); i++ {
+		puzzle.calls = append(puzzle.calls,
+			&viewCall{method: "tokenTiers", args: []interface{}{puzzleID, big.NewInt(int64(i))}},
+			&viewCall{method: "tokenURIs", args: []interface{}{puzzleID, big.NewInt(int64(i))}},
+		)
+	}
+	for i := uint64(0); i < puzzle.TokenCounter; i++ {
+		puzzle.calls = append(puzzle.calls, &viewCall{method: "ownerOf", args: []interface{}{game.TokenID(id, puzzle.Season, i)}})
+	}
+	return puzzle, nil
+}
+
+// setBatchResults fills the tiers and owners of the puzzle with the results of its batched calls
+func (p *puzzleStatus) setBatchResults() {
+	nTiers := (len(p.calls) - int(p.TokenCounter)) / 2
+	tokenTiers := make([]uint16, nTiers)
+	tokenURIs := make([]string, nTiers)
+	for i := range tokenTiers {
+		tokenTiers[i] = *abi.ConvertType(p.calls[2*i].results[0], new(uint16)).(*uint16)
+		tokenURIs[i] = *abi.ConvertType(p.calls[2*i+1].results[0], new(string)).(*string)
+	}
+	p.Tiers = tierStatuses(p.TokenCounter, tokenTiers, tokenURIs, p.baseURI)
+	if len(tokenTiers) > 0 {
+		p.AllMinted = p.TokenCounter > uint64(tokenTiers[len(tokenTiers)-1])
+	}
+	for i, call := range p.calls[2*nTiers:] {
+		p.Owners = append(p.Owners, tokenOwner{
+			TokenID: game.TokenID(p.Puzzle, p.Season, uint64(i)).Uint64(),
+			Owner:   *abi.ConvertType(call.results[0], new(common.Address)).(*common.Address),
+		})
+	}
+}
+
+// tierStatuses returns the ID range of each tier and how many of its tokens are left, given the amount of minted tokens.
+// The tier i holds the IDs (tokenTiers[i-1], tokenTiers[i]], the first tier starts at 0
+func tierStatuses(tokenCounter uint64, tokenTiers []uint16, tokenURIs []string, baseURI string) []tierStatus {
+	tiers := []tierStatus{}
+	var firstID uint64
+	for i, lastID := range tokenTiers {
+		tier := tierStatus{
+			Tier:    i,
+			FirstID: firstID,
+			LastID:  uint64(lastID),
+			URI:     baseURI + tokenURIs[i],
+		}
+		switch {
+		case tokenCounter <= tier.FirstID:
+			tier.Remaining = tier.LastID - tier.FirstID + 1
+		case tokenCounter <= tier.LastID:
+			tier.Remaining = tier.LastID - tokenCounter + 1
+		}
+		tiers = append(tiers, tier)
+		firstID = uint64(lastID) + 1
+	}
+	return tiers
+}
+
+// print writes the status in a human readable format
+func (s *gameStatus) print() {
+	fmt.Printf("Contract: %s (block %d)\n", s.Contract.Hex(), s.BlockNumber)
+	for _, p := range s.Puzzles {
+		fmt.Printf("Puzzle %d (verifier %s)\n", p.Puzzle, p.Verifier.Hex())
+		p.print()
+	}
+}
+
+func (p *puzzleStatus) print() {
+	fmt.Println("  Season:", p.Season)
+	fmt.Println("  Minted tokens:", p.TokenCounter)
+	if p.AllMinted {
+		fmt.Println("  All the tokens have been minted")
+	} else {
+		fmt.Println("  Next n to prove:", p.NextN)
+	}
+	fmt.Println("  Root:", p.Root)
+	fmt.Println("  Tiers:")
+	for _, tier := range p.Tiers {
+		fmt.Printf("    #%d: IDs %s-%s, %d remaining, URI: %s\n", tier.Tier,
+			game.TokenID(p.Puzzle, p.Season, tier.FirstID), game.TokenID(p.Puzzle, p.Season, tier.LastID), tier.Remaining, tier.URI)
+	}
+	fmt.Println("  Owners:")
+	for _, owner := range p.Owners {
+		fmt.Printf("    #%d: %s\n", owner.TokenID, owner.Owner.Hex())
+	}
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/arnaubennassar/zkOnacci/game"
+	"github.com/arnaubennassar/zkOnacci/testutil"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// simulatedBatchCaller serves batches of eth_call requests with the simulated backend
+type simulatedBatchCaller struct {
+	backend *backends.SimulatedBackend
+	batches int
+}
+
+func (c *simulatedBatchCaller) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	c.batches++
+	for i := range b {
+		msg := b[i].Args[0].(map[string]interface{})
+		to := msg["to"].(common.Address)
+		blockNumber, err := hexutil.DecodeBig(b[i].Args[1].(string))
+		if err != nil {
+			return err
+		}
+		out, err := c.backend.CallContract(ctx, ethereum.CallMsg{To: &to, Data: msg["data"].(hexutil.Bytes)}, blockNumber)
+		if err != nil {
+			b[i].Error = err
+			continue
+		}
+		*b[i].Result.(*hexutil.Bytes) = out
+	}
+	return nil
+}
+
+func TestTierStatuses(t *testing.T) {
+	tokenTiers := []uint16{2, 4, 8, 16}
+	tokenURIs := []string{"a", "b", "c", "d"}
+	tiers := tierStatuses(4, tokenTiers, tokenURIs, "ipfs://")
+	assert.Equal(t, []tierStatus{
+		{Tier: 0, FirstID: 0, LastID: 2, URI: "ipfs://a", Remaining: 0},
+		{Tier: 1, FirstID: 3, LastID: 4, URI: "ipfs://b", Remaining: 1},
+		{Tier: 2, FirstID: 5, LastID: 8, URI: "ipfs://c", Remaining: 4},
+		{Tier: 3, FirstID: 9, LastID: 16, URI: "ipfs://d", Remaining: 8},
+	}, tiers)
+	tiers = tierStatuses(17, tokenTiers, tokenURIs, "ipfs://")
+	for _, tier := range tiers {
+		assert.Equal(t, uint64(0), tier.Remaining)
+	}
+}
+
+func TestReadStatus(t *testing.T) {
+	ctx := context.Background()
+	privateKey := testutil.NewKey(t)
+	backend := testutil.NewSimulatedBackend(privateKey)
+	auth := testutil.NewTransactor(t, privateKey)
+	verifierAddr, scAddr, zkOnacci := testutil.Deploy(t, backend, auth)
+	// A second puzzle with the same circuit and a single token
+	def := testutil.LoadGame(t)
+	_, err := zkOnacci.AddPuzzle(auth, verifierAddr, game.Order, def.GenesisRoot, "ipfs://", []uint16{0}, []string{"single"})
+	require.NoError(t, err)
+	backend.Commit()
+	// A third puzzle of a sequence of order 3, without captures (its verifier is not used)
+	_, err = zkOnacci.AddPuzzle(auth, verifierAddr, 3, def.GenesisRoot, "ipfs://", []uint16{0}, []string{"single"})
+	require.NoError(t, err)
+	backend.Commit()
+	// Capture the first flag of the first two puzzles: they start from the same root, so the same proof is valid for both
+	capture := testutil.ProveFirstCapture(t, auth.From)
+	for _, puzzle := range []int64{0, 1} {
+		_, err = zkOnacci.CaptureTheFlag(auth, big.NewInt(puzzle), capture.ProofA, capture.ProofB, capture.ProofC, capture.NextRoot)
+		require.NoError(t, err)
+		backend.Commit()
+	}
+	// Read status
+	head, err := backend.HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+	caller := &simulatedBatchCaller{backend: backend.SimulatedBackend}
+	status, err := readStatus(ctx, caller, &zkOnacci.ZKOnacciCaller, scAddr, head.Number)
+	require.NoError(t, err)
+	assert.Equal(t, 1, caller.batches)
+	require.Len(t, status.Puzzles, 3)
+	first := status.Puzzles[0]
+	assert.Equal(t, uint64(0), first.Puzzle)
+	assert.Equal(t, verifierAddr, first.Verifier)
+	assert.Equal(t, uint64(0), first.Season)
+	assert.Equal(t, uint64(1), first.TokenCounter)
+	assert.Equal(t, uint64(3), first.NextN)
+	assert.Equal(t, capture.NextRoot.String(), first.Root)
+	assert.False(t, first.AllMinted)
+	require.Len(t, first.Tiers, 4)
+	assert.Equal(t, uint64(2), first.Tiers[0].Remaining)
+	assert.Equal(t, "https://ipfs.io/ipfs/bafkreignwngx3twej6cdn26hyaet3gg7scrtpgafkqjnkqtv37a2r6qf4u", first.Tiers[0].URI)
+	assert.Equal(t, uint64(8), first.Tiers[3].Remaining)
+	assert.Equal(t, []tokenOwner{{TokenID: 0, Owner: auth.From}}, first.Owners)
+	second := status.Puzzles[1]
+	assert.Equal(t, uint64(1), second.Puzzle)
+	assert.Equal(t, uint64(1), second.TokenCounter)
+	assert.True(t, second.AllMinted)
+	assert.Equal(t, []tierStatus{{Tier: 0, FirstID: 0, LastID: 0, URI: "ipfs://single", Remaining: 0}}, second.Tiers)
+	assert.Equal(t, []tokenOwner{{TokenID: 1 << 32, Owner: auth.From}}, second.Owners)
+	assert.Equal(t, uint64(3), second.NextN)
+	// The next n depends on the order of the puzzle
+	third := status.Puzzles[2]
+	assert.Equal(t, uint64(0), third.TokenCounter)
+	assert.Equal(t, uint64(3), third.NextN)
+}
@@ -0,0 +1,215 @@
+package testutil
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/arnaubennassar/zkOnacci/contracts"
+	"github.com/arnaubennassar/zkOnacci/contracts/zkinputs"
+	"github.com/arnaubennassar/zkOnacci/game"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/crypto"
+	bn256 "github.com/ethereum/go-ethereum/crypto/bn256/cloudflare"
+	"github.com/iden3/go-merkletree"
+	"github.com/iden3/go-merkletree/db/memory"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// ChainID is the chain ID used by the simulated backend
+	ChainID = 1337
+	// NLevels is the amount of levels of the MT of the circuit
+	NLevels = 6
+	// ArtifactsPath is the path of the circom artifacts, relative to the directory of the package under test
+	ArtifactsPath = "../circuits"
+	// GamePath is the path of the game definition of the repo, relative to the directory of the package under test
+	GamePath = "../NFTs/game.json"
+)
+
+// SimulatedBackend adds ChainID to the simulated backend
+type SimulatedBackend struct {
+	*backends.SimulatedBackend
+}
+
+// ChainID returns the chain ID used by the simulated backend
+func (b SimulatedBackend) ChainID(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(ChainID), nil
+}
+
+// NewKey generates a private key
+func NewKey(t *testing.T) *ecdsa.PrivateKey {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	return privateKey
+}
+
+// NewSimulatedBackend returns a simulated backend where the accounts of keys have 10 ETH each
+func NewSimulatedBackend(keys ...*ecdsa.PrivateKey) SimulatedBackend {
+	balance, _ := new(big.Int).SetString("10000000000000000000", 10) // 10 ETH in wei
+	return NewFundedSimulatedBackend(balance, keys...)
+}
+
+// NewFundedSimulatedBackend returns a simulated backend where the accounts of keys have balance wei each
+func NewFundedSimulatedBackend(balance *big.Int, keys ...*ecdsa.PrivateKey) SimulatedBackend {
+	genesisAlloc := map[common.Address]core.GenesisAccount{}
+	for _, key := range keys {
+		genesisAlloc[crypto.PubkeyToAddress(key.PublicKey)] = core.GenesisAccount{Balance: balance}
+	}
+	return SimulatedBackend{backends.NewSimulatedBackend(genesisAlloc, 30000000)}
+}
+
+// NewTransactor returns transaction options signed by privateKey for the simulated backend
+func NewTransactor(t *testing.T, privateKey *ecdsa.PrivateKey) *bind.TransactOpts {
+	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, big.NewInt(ChainID))
+	require.NoError(t, err)
+	return auth
+}
+
+// LoadGame loads the game definition of the repo
+func LoadGame(t *testing.T) *game.Definition {
+	def, err := game.Load(GamePath, NLevels)
+	require.NoError(t, err)
+	return def
+}
+
+// Deploy deploys the verifier and zkOnacci (with the game definition of the repo, owned by the sender of auth) with auth and mines them
+func Deploy(t *testing.T, backend SimulatedBackend, auth *bind.TransactOpts) (common.Address, common.Address, *contracts.ZKOnacci) {
+	verifierAddr, _, _, err := contracts.DeployVerifier(auth, backend)
+	require.NoError(t, err)
+	def := LoadGame(t)
+	scAddr, _, zkOnacci, err := contracts.DeployZKOnacci(auth, backend, verifierAddr, def.GenesisRoot, def.BaseURI, def.TokenTiers(), def.TokenURIs(), auth.From)
+	require.NoError(t, err)
+	backend.Commit()
+	return verifierAddr, scAddr, zkOnacci
+}
+
+// DeployHints deploys the hints contract of the zkOnacci at scAddr, owned by the sender of auth
+func DeployHints(t *testing.T, backend SimulatedBackend, auth *bind.TransactOpts, scAddr common.Address) (common.Address, *contracts.ZKOnacciHints) {
+	hintsAddr, _, hints, err := contracts.DeployZKOnacciHints(auth, backend, scAddr, auth.From)
+	require.NoError(t, err)
+	backend.Commit()
+	return hintsAddr, hints
+}
+
+// Capture holds the arguments of captureTheFlag
+type Capture struct {
+	ProofA   [2]*big.Int
+	ProofB   [2][2]*big.Int
+	ProofC   [2]*big.Int
+	NextRoot *big.Int
+}
+
+// ProveFirstCapture proves the first flag (n = 2) for sender
+func ProveFirstCapture(t *testing.T, sender common.Address) Capture {
+	merkleTree, err := merkletree.NewMerkleTree(memory.NewMemoryStorage(), NLevels)
+	require.NoError(t, err)
+	require.NoError(t, merkleTree.Add(big.NewInt(0), big.NewInt(0)))
+	require.NoError(t, merkleTree.Add(big.NewInt(1), big.NewInt(1)))
+	oldRoot := merkleTree.Root()
+	mtpNMinOne, err := merkleTree.GenerateCircomVerifierProof(big.NewInt(1), nil)
+	require.NoError(t, err)
+	mtpNMinTwo, err := merkleTree.GenerateCircomVerifierProof(big.NewInt(0), nil)
+	require.NoError(t, err)
+	mtpN, err := merkleTree.AddAndGetCircomProof(big.NewInt(2), big.NewInt(1))
+	require.NoError(t, err)
+	proofA, proofB, proofC, err := zkinputs.GenerateProof(zkinputs.ZKInput{
+		Sender:           sender,
+		Root:             oldRoot,
+		N:                2,
+		Fn:               1,
+		SiblingsFn:       mtpN.Siblings,
+		OldKeyFn:         mtpN.OldKey,
+		OldValueFn:       mtpN.OldValue,
+		IsOld0Fn:         mtpN.IsOld0,
+		FnMinOne:         1,
+		SiblingsFnMinOne: mtpNMinOne.Siblings,
+		FnMinTwo:         0,
+		SiblingsFnMinTwo: mtpNMinTwo.Siblings,
+	}, ArtifactsPath)
+	require.NoError(t, err)
+	return Capture{ProofA: proofA, ProofB: proofB, ProofC: proofC, NextRoot: merkleTree.Root().BigInt()}
+}
+
+// Groth16Trapdoor is a groth16 verification key made of known multiples of the generators, so proofs that pass the
+// pairing check can be forged for any public inputs. It's used to test the verifier contracts without circuit artifacts
+type Groth16Trapdoor struct {
+	alpha, beta, gamma, delta *big.Int
+	ic                        [4]*big.Int
+}
+
+// NewGroth16Trapdoor returns a trapdoor with random scalars
+func NewGroth16Trapdoor(t *testing.T) *Groth16Trapdoor {
+	randScalar := func() *big.Int {
+		k, err := rand.Int(rand.Reader, bn256.Order)
+		require.NoError(t, err)
+		return k
+	}
+	trapdoor := &Groth16Trapdoor{alpha: randScalar(), beta: randScalar(), gamma: randScalar(), delta: randScalar()}
+	for i := range trapdoor.ic {
+		trapdoor.ic[i] = randScalar()
+	}
+	return trapdoor
+}
+
+// VerificationKeyJSON returns the verification key in the format exported by snarkjs
+func (td *Groth16Trapdoor) VerificationKeyJSON(t *testing.T) []byte {
+	g1 := func(k *big.Int) []string {
+		p := new(bn256.G1).ScalarBaseMult(k).Marshal()
+		return []string{new(big.Int).SetBytes(p[:32]).String(), new(big.Int).SetBytes(p[32:]).String(), "1"}
+	}
+	g2 := func(k *big.Int) [][]string {
+		// Marshal encodes the imaginary part of each coordinate first
+		p := new(bn256.G2).ScalarBaseMult(k).Marshal()
+		coordinate := func(i int) *big.Int { return new(big.Int).SetBytes(p[i*32 : (i+1)*32]) }
+		return [][]string{
+			{coordinate(1).String(), coordinate(0).String()},
+			{coordinate(3).String(), coordinate(2).String()},
+			{"1", "0"},
+		}
+	}
+	ic := make([][]string, len(td.ic))
+	for i, k := range td.ic {
+		ic[i] = g1(k)
+	}
+	vk, err := json.Marshal(map[string]interface{}{
+		"protocol":   "groth16",
+		"curve":      "bn128",
+		"nPublic":    len(td.ic) - 1,
+		"vk_alpha_1": g1(td.alpha),
+		"vk_beta_2":  g2(td.beta),
+		"vk_gamma_2": g2(td.gamma),
+		"vk_delta_2": g2(td.delta),
+		"IC":         ic,
+	})
+	require.NoError(t, err)
+	return vk
+}
+
+// Prove forges a proof of the public inputs, in the format of captureTheFlag
+func (td *Groth16Trapdoor) Prove(public [3]*big.Int) (proofA [2]*big.Int, proofB [2][2]*big.Int, proofC [2]*big.Int) {
+	// e(-A, B) * e(alpha, beta) * e(X, gamma) * e(C, delta) = 1 <=> a*b = alpha*beta + x*gamma + c*delta
+	x := new(big.Int).Set(td.ic[0])
+	for i, input := range public {
+		x.Add(x, new(big.Int).Mul(input, td.ic[i+1]))
+	}
+	a, b := big.NewInt(7), big.NewInt(11)
+	c := new(big.Int).Mul(a, b)
+	c.Sub(c, new(big.Int).Mul(td.alpha, td.beta))
+	c.Sub(c, new(big.Int).Mul(x, td.gamma))
+	c.Mul(c, new(big.Int).ModInverse(td.delta, bn256.Order))
+	c.Mod(c, bn256.Order)
+	pointA := new(bn256.G1).ScalarBaseMult(a).Marshal()
+	pointB := new(bn256.G2).ScalarBaseMult(b).Marshal()
+	pointC := new(bn256.G1).ScalarBaseMult(c).Marshal()
+	coordinate := func(p []byte, i int) *big.Int { return new(big.Int).SetBytes(p[i*32 : (i+1)*32]) }
+	return [2]*big.Int{coordinate(pointA, 0), coordinate(pointA, 1)},
+		[2][2]*big.Int{{coordinate(pointB, 0), coordinate(pointB, 1)}, {coordinate(pointB, 2), coordinate(pointB, 3)}},
+		[2]*big.Int{coordinate(pointC, 0), coordinate(pointC, 1)}
+}
@@ -0,0 +1,173 @@
+package txutil
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+
+	"github.com/arnaubennassar/zkOnacci/signer"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// DefaultGasMargin is the percentage added on top of the gas estimation if nothing else is configured
+const DefaultGasMargin = 20
+
+// Backend is the functionality needed to build and send transactions
+type Backend interface {
+	bind.ContractBackend
+	ChainID(ctx context.Context) (*big.Int, error)
+}
+
+// FeeConfig sets how gas limits and fees are calculated
+type FeeConfig struct {
+	// GasMargin is the percentage added on top of the gas estimation
+	GasMargin uint64
+	// MaxFeePerGas caps the max fee per gas of dynamic fee txs, or the gas price of legacy txs (nil = no cap)
+	MaxFeePerGas *big.Int
+	// MaxPriorityFeePerGas caps the priority fee per gas of dynamic fee txs (nil = no cap)
+	MaxPriorityFeePerGas *big.Int
+}
+
+// FeeConfigFromEnv reads the fee configuration from the env vars GAS_MARGIN (percentage),
+// MAX_FEE_PER_GAS and MAX_PRIORITY_FEE_PER_GAS (in wei). All of them are optional
+func FeeConfigFromEnv() (FeeConfig, error) {
+	return FeeConfig{GasMargin: DefaultGasMargin}.WithEnv()
+}
+
+// WithEnv returns cfg with the values overridden by the env vars that are set (see FeeConfigFromEnv)
+func (cfg FeeConfig) WithEnv() (FeeConfig, error) {
+	if gasMarginStr := os.Getenv("GAS_MARGIN"); gasMarginStr != "" {
+		gasMargin, err := strconv.ParseUint(gasMarginStr, 10, 64)
+		if err != nil {
+			return FeeConfig{}, fmt.Errorf("invalid GAS_MARGIN: %w", err)
+		}
+		cfg.GasMargin = gasMargin
+	}
+	if maxFeeStr := os.Getenv("MAX_FEE_PER_GAS"); maxFeeStr != "" {
+		maxFee, ok := new(big.Int).SetString(maxFeeStr, 10)
+		if !ok {
+			return FeeConfig{}, fmt.Errorf("invalid MAX_FEE_PER_GAS: %s", maxFeeStr)
+		}
+		cfg.MaxFeePerGas = maxFee
+	}
+	if maxPriorityFeeStr := os.Getenv("MAX_PRIORITY_FEE_PER_GAS"); maxPriorityFeeStr != "" {
+		maxPriorityFee, ok := new(big.Int).SetString(maxPriorityFeeStr, 10)
+		if !ok {
+			return FeeConfig{}, fmt.Errorf("invalid MAX_PRIORITY_FEE_PER_GAS: %s", maxPriorityFeeStr)
+		}
+		cfg.MaxPriorityFeePerGas = maxPriorityFee
+	}
+	return cfg, nil
+}
+
+// NewTransactOpts returns transaction options signed by s for the chain ID of the backend (EIP-155).
+// If the chain supports EIP-1559, the options will produce dynamic fee txs, otherwise legacy txs.
+// Fees are suggested by the backend and capped according to cfg. The gas limit is left unset,
+// use Send to estimate it with a safety margin
+func NewTransactOpts(ctx context.Context, backend Backend, s signer.Signer, cfg FeeConfig) (*bind.TransactOpts, error) {
+	chainID, err := backend.ChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	from := s.Address()
+	auth := &bind.TransactOpts{
+		From: from,
+		Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != from {
+				return nil, bind.ErrNotAuthorized
+			}
+			return s.SignTx(tx, chainID)
+		},
+		Context: ctx,
+		Value:   big.NewInt(0), // in wei
+	}
+	if err := SetFees(ctx, backend, auth, cfg); err != nil {
+		return nil, err
+	}
+	return auth, nil
+}
+
+// SetFees updates the fees of opts with the current suggestions of the backend, capped according to cfg
+func SetFees(ctx context.Context, backend bind.ContractTransactor, opts *bind.TransactOpts, cfg FeeConfig) error {
+	head, err := backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if head.BaseFee == nil {
+		// Legacy tx
+		gasPrice, err := backend.SuggestGasPrice(ctx)
+		if err != nil {
+			return err
+		}
+		opts.GasPrice = capFee(gasPrice, cfg.MaxFeePerGas)
+		opts.GasFeeCap = nil
+		opts.GasTipCap = nil
+		return nil
+	}
+	// Dynamic fee tx
+	gasTipCap, err := backend.SuggestGasTipCap(ctx)
+	if err != nil {
+		return err
+	}
+	gasTipCap = capFee(gasTipCap, cfg.MaxPriorityFeePerGas)
+	// Leave room for the base fee to double
+	gasFeeCap := new(big.Int).Add(gasTipCap, new(big.Int).Mul(head.BaseFee, big.NewInt(2)))
+	gasFeeCap = capFee(gasFeeCap, cfg.MaxFeePerGas)
+	if gasFeeCap.Cmp(gasTipCap) < 0 {
+		gasTipCap = new(big.Int).Set(gasFeeCap)
+	}
+	opts.GasPrice = nil
+	opts.GasFeeCap = gasFeeCap
+	opts.GasTipCap = gasTipCap
+	return nil
+}
+
+// BumpFees increases the fees of opts so a tx sent with them can replace tx (nodes require an increase
+// of at least 10% on every fee). The result is not capped by cfg, as a replacement below the caps may be impossible
+func BumpFees(opts *bind.TransactOpts, tx *types.Transaction) {
+	bump := func(current, previous *big.Int) *big.Int {
+		min := new(big.Int).Div(new(big.Int).Mul(previous, big.NewInt(110)), big.NewInt(100))
+		min.Add(min, big.NewInt(1))
+		if current == nil || current.Cmp(min) < 0 {
+			return min
+		}
+		return current
+	}
+	if opts.GasPrice != nil {
+		opts.GasPrice = bump(opts.GasPrice, tx.GasPrice())
+		return
+	}
+	opts.GasFeeCap = bump(opts.GasFeeCap, tx.GasFeeCap())
+	opts.GasTipCap = bump(opts.GasTipCap, tx.GasTipCap())
+}
+
+// Send sends the tx built by send. If opts doesn't have a gas limit, the gas is estimated first
+// and cfg.GasMargin is added on top of the estimation. The estimation is done by the backend on the packed
+// calldata, without signing nor sending any tx (so external signers are only asked to sign once)
+func Send(opts *bind.TransactOpts, cfg FeeConfig, send func(*bind.TransactOpts) (*types.Transaction, error)) (*types.Transaction, error) {
+	sendOpts := *opts
+	if sendOpts.GasLimit == 0 {
+		estimateOpts := *opts
+		estimateOpts.NoSend = true
+		estimateOpts.Signer = func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			return tx, nil
+		}
+		tx, err := send(&estimateOpts)
+		if err != nil {
+			return nil, err
+		}
+		sendOpts.GasLimit = tx.Gas() * (100 + cfg.GasMargin) / 100
+	}
+	return send(&sendOpts)
+}
+
+func capFee(fee, max *big.Int) *big.Int {
+	if max != nil && fee.Cmp(max) > 0 {
+		return new(big.Int).Set(max)
+	}
+	return fee
+}
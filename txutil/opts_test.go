@@ -0,0 +1,125 @@
+package txutil
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/arnaubennassar/zkOnacci/contracts"
+	"github.com/arnaubennassar/zkOnacci/signer"
+	"github.com/arnaubennassar/zkOnacci/testutil"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingSigner counts the txs signed by the wrapped signer
+type countingSigner struct {
+	signer.Signer
+	signed int
+}
+
+func (s *countingSigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	s.signed++
+	return s.Signer.SignTx(tx, chainID)
+}
+
+// legacyGasPrice is the gas price suggested by legacyBackend
+var legacyGasPrice = big.NewInt(2000000000)
+
+// legacyBackend hides the base fee of the simulated backend, like a chain without EIP-1559
+type legacyBackend struct {
+	testutil.SimulatedBackend
+}
+
+func (b legacyBackend) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return new(big.Int).Set(legacyGasPrice), nil
+}
+
+func (b legacyBackend) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	head, err := b.SimulatedBackend.HeaderByNumber(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	legacyHead := types.CopyHeader(head)
+	legacyHead.BaseFee = nil
+	return legacyHead, nil
+}
+
+func TestSendDynamicFeeTx(t *testing.T) {
+	privateKey := testutil.NewKey(t)
+	backend := testutil.NewSimulatedBackend(privateKey)
+	ctx := context.Background()
+	feeConfig := FeeConfig{
+		GasMargin:            20,
+		MaxFeePerGas:         big.NewInt(3000000000),
+		MaxPriorityFeePerGas: big.NewInt(1),
+	}
+	s := &countingSigner{Signer: signer.NewKeySigner(privateKey)}
+	auth, err := NewTransactOpts(ctx, backend, s, feeConfig)
+	require.NoError(t, err)
+	assert.Nil(t, auth.GasPrice)
+	assert.Equal(t, big.NewInt(1), auth.GasTipCap)
+	assert.True(t, auth.GasFeeCap.Cmp(feeConfig.MaxFeePerGas) <= 0)
+	// Estimation without margin
+	estimateOpts := *auth
+	estimateOpts.NoSend = true
+	_, estimateTx, _, err := contracts.DeployVerifier(&estimateOpts, backend)
+	require.NoError(t, err)
+	// Send with margin, the estimation doesn't sign any tx
+	s.signed = 0
+	tx, err := Send(auth, feeConfig, func(opts *bind.TransactOpts) (tx *types.Transaction, err error) {
+		_, tx, _, err = contracts.DeployVerifier(opts, backend)
+		return
+	})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), auth.GasLimit)
+	assert.Equal(t, estimateTx.Gas()*120/100, tx.Gas())
+	assert.Equal(t, 1, s.signed)
+	assert.Equal(t, uint8(types.DynamicFeeTxType), tx.Type())
+	assert.Equal(t, big.NewInt(1337), tx.ChainId())
+	backend.Commit()
+	receipt, err := backend.TransactionReceipt(ctx, tx.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+}
+
+func TestSetFeesLegacy(t *testing.T) {
+	privateKey := testutil.NewKey(t)
+	backend := legacyBackend{testutil.NewSimulatedBackend(privateKey)}
+	ctx := context.Background()
+	suggested := legacyGasPrice
+	// Without cap, the suggested gas price is used
+	auth, err := NewTransactOpts(ctx, backend, signer.NewKeySigner(privateKey), FeeConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, suggested, auth.GasPrice)
+	assert.Nil(t, auth.GasFeeCap)
+	assert.Nil(t, auth.GasTipCap)
+	// MaxFeePerGas caps the gas price, MaxPriorityFeePerGas is ignored
+	maxFee := new(big.Int).Sub(suggested, big.NewInt(1))
+	require.NoError(t, SetFees(ctx, backend, auth, FeeConfig{MaxFeePerGas: maxFee, MaxPriorityFeePerGas: big.NewInt(1)}))
+	assert.Equal(t, maxFee, auth.GasPrice)
+	assert.Nil(t, auth.GasFeeCap)
+	assert.Nil(t, auth.GasTipCap)
+	// Dynamic fees set previously are cleared
+	auth.GasFeeCap, auth.GasTipCap = big.NewInt(2), big.NewInt(1)
+	require.NoError(t, SetFees(ctx, backend, auth, FeeConfig{}))
+	assert.Equal(t, suggested, auth.GasPrice)
+	assert.Nil(t, auth.GasFeeCap)
+	assert.Nil(t, auth.GasTipCap)
+}
+
+func TestBumpFees(t *testing.T) {
+	privateKey := testutil.NewKey(t)
+	backend := testutil.NewSimulatedBackend(privateKey)
+	auth, err := NewTransactOpts(context.Background(), backend, signer.NewKeySigner(privateKey), FeeConfig{})
+	require.NoError(t, err)
+	tx := types.NewTx(&types.DynamicFeeTx{
+		GasTipCap: big.NewInt(100),
+		GasFeeCap: big.NewInt(1000000000000),
+	})
+	BumpFees(auth, tx)
+	assert.Equal(t, big.NewInt(111), auth.GasTipCap)
+	assert.Equal(t, big.NewInt(1100000000001), auth.GasFeeCap)
+}
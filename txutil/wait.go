@@ -0,0 +1,176 @@
+package txutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// DefaultPollInterval is the time waited between receipt checks if nothing else is configured
+const DefaultPollInterval = time.Second * 15
+
+// droppedChecks is the amount of consecutive checks a tx has to be missing (neither mined nor pending)
+// before it's considered dropped, so a node that hasn't seen the tx yet doesn't cause false positives
+const droppedChecks = 3
+
+var (
+	// ErrTxDropped is returned when a tx is neither mined nor pending anymore and its nonce hasn't been used
+	ErrTxDropped = errors.New("tx dropped from the mempool")
+	// ErrTxReplaced is returned when another tx with the same sender and nonce has been mined instead
+	ErrTxReplaced = errors.New("tx replaced by another tx with the same nonce")
+	// ErrTxReorged is returned when a tx that had already been mined is removed from the canonical chain by a reorg
+	// and it doesn't go back to the mempool
+	ErrTxReorged = errors.New("tx has been removed from the canonical chain by a reorg")
+)
+
+// TxFailedError is returned when a tx is mined but reverts
+type TxFailedError struct {
+	Receipt *types.Receipt
+	// Reason is the revert reason obtained by replaying the tx
+	Reason string
+}
+
+func (e *TxFailedError) Error() string {
+	return fmt.Sprintf("tx %s reverted on block %s, reason: %s", e.Receipt.TxHash.Hex(), e.Receipt.BlockNumber, e.Reason)
+}
+
+// WaitBackend is the functionality needed to wait for transactions
+type WaitBackend interface {
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+	TransactionByHash(ctx context.Context, txHash common.Hash) (tx *types.Transaction, isPending bool, err error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+	CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+}
+
+// WaitConfig sets how transactions are waited for
+type WaitConfig struct {
+	// Confirmations is the amount of blocks needed on top of the tx, including its own block (0 = 1)
+	Confirmations uint64
+	// PollInterval is the time waited between checks (0 = DefaultPollInterval)
+	PollInterval time.Duration
+	// OnPending is called after each check that doesn't finish the wait (optional), e.g. PrintProgress.
+	// The receipt is nil while the tx is not mined, confirmations is the amount of confirmations it has so far
+	OnPending func(receipt *types.Receipt, confirmations, required uint64)
+}
+
+// WaitMined waits until tx is mined and has the configured confirmations, or ctx is done.
+// If the tx reverts, the receipt is returned along with a *TxFailedError.
+// ErrTxDropped, ErrTxReplaced and ErrTxReorged are returned if the tx won't be mined (or stay mined) anymore
+func WaitMined(ctx context.Context, backend WaitBackend, tx *types.Transaction, cfg WaitConfig) (*types.Receipt, error) {
+	if cfg.Confirmations == 0 {
+		cfg.Confirmations = 1
+	}
+	if cfg.PollInterval == 0 {
+		cfg.PollInterval = DefaultPollInterval
+	}
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		mined   *types.Receipt
+		missing int
+	)
+	for {
+		// The nonce is read before the receipt: if the nonce is used and the receipt is not found afterwards,
+		// the nonce has been used by another tx
+		nonce, err := backend.NonceAt(ctx, from, nil)
+		if err != nil {
+			return nil, err
+		}
+		receipt, err := backend.TransactionReceipt(ctx, tx.Hash())
+		switch {
+		case errors.Is(err, ethereum.NotFound), err == nil && receipt == nil:
+			// Some backends (e.g. the simulated one) return a nil receipt instead of NotFound
+			if mined != nil {
+				// The tx was mined but now it can't be found: its block is not canonical anymore.
+				// Reorged txs usually go back to the mempool, so it's only reported if the node doesn't know it anymore
+				if _, _, err := backend.TransactionByHash(ctx, tx.Hash()); errors.Is(err, ethereum.NotFound) {
+					return nil, ErrTxReorged
+				} else if err != nil {
+					return nil, err
+				}
+				mined = nil
+			}
+			if nonce > tx.Nonce() {
+				return nil, ErrTxReplaced
+			}
+			if _, _, err := backend.TransactionByHash(ctx, tx.Hash()); errors.Is(err, ethereum.NotFound) {
+				if missing++; missing >= droppedChecks {
+					return nil, ErrTxDropped
+				}
+			} else if err != nil {
+				return nil, err
+			} else {
+				missing = 0
+			}
+			if cfg.OnPending != nil {
+				cfg.OnPending(nil, 0, cfg.Confirmations)
+			}
+		case err != nil:
+			return nil, err
+		default:
+			mined = receipt
+			head, err := backend.HeaderByNumber(ctx, nil)
+			if err != nil {
+				return nil, err
+			}
+			confirmations := head.Number.Uint64() + 1 - receipt.BlockNumber.Uint64()
+			if confirmations >= cfg.Confirmations {
+				if receipt.Status != types.ReceiptStatusSuccessful {
+					return receipt, &TxFailedError{Receipt: receipt, Reason: RevertReason(ctx, backend, tx, receipt)}
+				}
+				return receipt, nil
+			}
+			if cfg.OnPending != nil {
+				cfg.OnPending(receipt, confirmations, cfg.Confirmations)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(cfg.PollInterval):
+		}
+	}
+}
+
+// PrintProgress prints the progress of WaitMined, to be used as WaitConfig.OnPending
+func PrintProgress(receipt *types.Receipt, confirmations, required uint64) {
+	if receipt == nil {
+		fmt.Println("tx not mined yet")
+		return
+	}
+	fmt.Printf("tx mined on block %d, waiting for confirmations (%d/%d)\n", receipt.BlockNumber.Uint64(), confirmations, required)
+}
+
+// RevertReason replays a failed tx on top of the state of the previous block in order to get the revert reason.
+// The txs mined before it in the same block are not applied (eth_call can't do it), so if the tx failed because of
+// one of them, the replay may succeed or revert for another reason
+func RevertReason(ctx context.Context, backend WaitBackend, tx *types.Transaction, receipt *types.Receipt) string {
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return err.Error()
+	}
+	msg := ethereum.CallMsg{
+		From:  from,
+		To:    tx.To(),
+		Gas:   tx.Gas(),
+		Value: tx.Value(),
+		Data:  tx.Data(),
+	}
+	blockNumber := new(big.Int).Sub(receipt.BlockNumber, big.NewInt(1))
+	if _, err := backend.CallContract(ctx, msg, blockNumber); err != nil {
+		return err.Error()
+	}
+	if receipt.GasUsed == tx.Gas() {
+		return "out of gas"
+	}
+	return "unknown, the replay succeeds (the tx may have failed because of an earlier tx of its block)"
+}
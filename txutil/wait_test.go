@@ -0,0 +1,180 @@
+package txutil
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/arnaubennassar/zkOnacci/contracts"
+	"github.com/arnaubennassar/zkOnacci/signer"
+	"github.com/arnaubennassar/zkOnacci/testutil"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPollInterval = time.Millisecond * 10
+
+// latestStateBackend replays calls on top of the latest state, since the simulated backend
+// doesn't support calls on past blocks. It's only equivalent to replaying on top of the previous block
+// if nothing has been mined afterwards and the tx is the only one of its block
+type latestStateBackend struct {
+	testutil.SimulatedBackend
+}
+
+func (b latestStateBackend) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return b.SimulatedBackend.SimulatedBackend.CallContract(ctx, call, nil)
+}
+
+// sendTransfer sends a transfer of value wei with the given nonce
+func sendTransfer(t *testing.T, backend testutil.SimulatedBackend, privateKey *ecdsa.PrivateKey, nonce uint64, value int64) *types.Transaction {
+	tx, err := types.SignNewTx(privateKey, types.NewLondonSigner(big.NewInt(1337)), &types.DynamicFeeTx{
+		ChainID:   big.NewInt(1337),
+		Nonce:     nonce,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(10000000000),
+		Gas:       21000,
+		To:        &common.Address{1},
+		Value:     big.NewInt(value),
+	})
+	require.NoError(t, err)
+	require.NoError(t, backend.SendTransaction(context.Background(), tx))
+	return tx
+}
+
+func TestWaitMinedConfirmations(t *testing.T) {
+	privateKey := testutil.NewKey(t)
+	backend := testutil.NewSimulatedBackend(privateKey)
+	tx := sendTransfer(t, backend, privateKey, 0, 1)
+	var progress []uint64
+	receipt, err := WaitMined(context.Background(), backend, tx, WaitConfig{
+		Confirmations: 3,
+		PollInterval:  testPollInterval,
+		OnPending: func(receipt *types.Receipt, confirmations, required uint64) {
+			assert.Equal(t, uint64(3), required)
+			progress = append(progress, confirmations)
+			// Mine a block on each check
+			backend.Commit()
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	assert.Equal(t, uint64(1), receipt.BlockNumber.Uint64())
+	// Pending, mined on block 1, block 2 on top of it
+	assert.Equal(t, []uint64{0, 1, 2}, progress)
+}
+
+func TestWaitMinedFailedTx(t *testing.T) {
+	privateKey := testutil.NewKey(t)
+	backend := testutil.NewSimulatedBackend(privateKey)
+	ctx := context.Background()
+	auth, err := NewTransactOpts(ctx, backend, signer.NewKeySigner(privateKey), FeeConfig{})
+	require.NoError(t, err)
+	verifierAddr, _, _, err := contracts.DeployVerifier(auth, backend)
+	require.NoError(t, err)
+	backend.Commit()
+	def := testutil.LoadGame(t)
+	_, _, zkOnacci, err := contracts.DeployZKOnacci(auth, backend, verifierAddr, def.GenesisRoot, def.BaseURI, def.TokenTiers(), def.TokenURIs(), auth.From)
+	require.NoError(t, err)
+	backend.Commit()
+	// Transfer a token that doesn't exist, the gas limit is set to skip the estimation
+	auth.GasLimit = 100000
+	tx, err := zkOnacci.TransferFrom(auth, auth.From, common.Address{1}, big.NewInt(1))
+	require.NoError(t, err)
+	backend.Commit()
+	receipt, err := WaitMined(ctx, latestStateBackend{backend}, tx, WaitConfig{PollInterval: testPollInterval})
+	var failed *TxFailedError
+	require.True(t, errors.As(err, &failed))
+	assert.Equal(t, types.ReceiptStatusFailed, receipt.Status)
+	assert.Equal(t, receipt, failed.Receipt)
+	assert.Contains(t, failed.Reason, "nonexistent token")
+}
+
+func TestWaitMinedDroppedTx(t *testing.T) {
+	privateKey := testutil.NewKey(t)
+	backend := testutil.NewSimulatedBackend(privateKey)
+	tx := sendTransfer(t, backend, privateKey, 0, 1)
+	// Discard the pending tx
+	backend.Rollback()
+	_, err := WaitMined(context.Background(), backend, tx, WaitConfig{PollInterval: testPollInterval})
+	assert.True(t, errors.Is(err, ErrTxDropped))
+}
+
+func TestWaitMinedReplacedTx(t *testing.T) {
+	privateKey := testutil.NewKey(t)
+	backend := testutil.NewSimulatedBackend(privateKey)
+	tx := sendTransfer(t, backend, privateKey, 0, 1)
+	backend.Rollback()
+	// Mine another tx with the same nonce
+	sendTransfer(t, backend, privateKey, 0, 2)
+	backend.Commit()
+	_, err := WaitMined(context.Background(), backend, tx, WaitConfig{PollInterval: testPollInterval})
+	assert.True(t, errors.Is(err, ErrTxReplaced))
+}
+
+func TestWaitMinedReorgedTx(t *testing.T) {
+	privateKey := testutil.NewKey(t)
+	backend := testutil.NewSimulatedBackend(privateKey)
+	ctx := context.Background()
+	genesis, err := backend.HeaderByNumber(ctx, big.NewInt(0))
+	require.NoError(t, err)
+	tx := sendTransfer(t, backend, privateKey, 0, 1)
+	backend.Commit()
+	_, err = WaitMined(ctx, backend, tx, WaitConfig{
+		Confirmations: 2,
+		PollInterval:  testPollInterval,
+		OnPending: func(receipt *types.Receipt, confirmations, required uint64) {
+			// Replace the block of the tx by a longer chain without it
+			require.NoError(t, backend.Fork(ctx, genesis.Hash()))
+			backend.Commit()
+			backend.Commit()
+		},
+	})
+	assert.True(t, errors.Is(err, ErrTxReorged))
+}
+
+func TestWaitMinedReorgedTxBackToMempool(t *testing.T) {
+	privateKey := testutil.NewKey(t)
+	backend := testutil.NewSimulatedBackend(privateKey)
+	ctx := context.Background()
+	genesis, err := backend.HeaderByNumber(ctx, big.NewInt(0))
+	require.NoError(t, err)
+	tx := sendTransfer(t, backend, privateKey, 0, 1)
+	backend.Commit()
+	checks := 0
+	receipt, err := WaitMined(ctx, backend, tx, WaitConfig{
+		Confirmations: 2,
+		PollInterval:  testPollInterval,
+		OnPending: func(receipt *types.Receipt, confirmations, required uint64) {
+			checks++
+			if checks == 1 {
+				// Replace the block of the tx by a longer chain without it, the tx goes back to the mempool
+				require.NoError(t, backend.Fork(ctx, genesis.Hash()))
+				backend.Commit()
+				backend.Commit()
+				require.NoError(t, backend.SendTransaction(ctx, tx))
+				return
+			}
+			backend.Commit()
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	// Mined again on top of the new chain
+	assert.Equal(t, uint64(3), receipt.BlockNumber.Uint64())
+}
+
+func TestWaitMinedDeadline(t *testing.T) {
+	privateKey := testutil.NewKey(t)
+	backend := testutil.NewSimulatedBackend(privateKey)
+	tx := sendTransfer(t, backend, privateKey, 0, 1)
+	ctx, cancel := context.WithTimeout(context.Background(), testPollInterval*5)
+	defer cancel()
+	_, err := WaitMined(ctx, backend, tx, WaitConfig{PollInterval: testPollInterval})
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/arnaubennassar/zkOnacci/config"
+	"github.com/arnaubennassar/zkOnacci/game"
+	"github.com/arnaubennassar/zkOnacci/verify"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+func main() {
+	configFlags := config.RegisterFlags(flag.CommandLine)
+	jsonOutput := flag.Bool("json", false, "print the report as JSON")
+	flag.Parse()
+	conf, err := configFlags.Load()
+	if err != nil {
+		panic(err)
+	}
+	if conf.Manifest == nil {
+		panic("Must provide the deployment manifest (manifest of the profile, env var MANIFEST or -manifest flag)")
+	}
+	if conf.Web3URL == "" {
+		panic("Must provide the web3 URL (web3URL of the profile, env var WEB3_URL or -web3-url flag)")
+	}
+	client, err := ethclient.Dial(conf.Web3URL)
+	if err != nil {
+		panic(err)
+	}
+	chainID, err := client.ChainID(context.Background())
+	if err != nil {
+		panic(err)
+	}
+	if chainID.Uint64() != conf.Manifest.ChainID {
+		panic(fmt.Sprintf("The manifest belongs to the chain %d but the node is on the chain %d", conf.Manifest.ChainID, chainID))
+	}
+	def, err := game.Load(conf.GamePath, conf.NLevels)
+	if err != nil {
+		panic(err)
+	}
+	report, err := verify.Deployment(context.Background(), client, conf.Manifest, def)
+	if err != nil {
+		panic(err)
+	}
+	if *jsonOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			panic(err)
+		}
+	} else {
+		report.Print()
+	}
+	if !report.OK() {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,255 @@
+package verify
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/arnaubennassar/zkOnacci/contracts"
+	"github.com/arnaubennassar/zkOnacci/game"
+	"github.com/arnaubennassar/zkOnacci/manifest"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm/runtime"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Check is the result of a single verification
+type Check struct {
+	Name   string `json:"name"`
+	OK     bool   `json:"ok"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// Report holds the results of the verification of a deployment
+type Report struct {
+	Checks []Check `json:"checks"`
+}
+
+// OK returns true if all the checks passed
+func (r *Report) OK() bool {
+	for _, c := range r.Checks {
+		if !c.OK {
+			return false
+		}
+	}
+	return true
+}
+
+// Print writes a line per check to stdout
+func (r *Report) Print() {
+	for _, c := range r.Checks {
+		result := "OK  "
+		if !c.OK {
+			result = "FAIL"
+		}
+		if c.Detail != "" {
+			fmt.Printf("%s %s: %s\n", result, c.Name, c.Detail)
+		} else {
+			fmt.Printf("%s %s\n", result, c.Name)
+		}
+	}
+}
+
+func (r *Report) add(name string, ok bool, detail string) {
+	r.Checks = append(r.Checks, Check{Name: name, OK: ok, Detail: detail})
+}
+
+// Deployment checks that the code of the contracts of m matches the compiled artifacts and that the state of
+// zkOnacci on its deployment block is the initial state of def. Reading the state of old blocks needs an archive node
+func Deployment(ctx context.Context, backend bind.ContractCaller, m *manifest.Manifest, def *game.Definition) (*Report, error) {
+	r := &Report{}
+	chainID := new(big.Int).SetUint64(m.ChainID)
+	// Code
+	if err := r.checkCode(ctx, backend, "verifier code", m.Verifier.Address, chainID, contracts.VerifierBin, nil); err != nil {
+		return nil, err
+	}
+	constructorArgs, err := ZKOnacciArgs(m.Verifier.Address, m.Deployer, def)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.checkCode(ctx, backend, "zkOnacci code", m.ZKOnacci.Address, chainID, contracts.ZKOnacciBin, constructorArgs); err != nil {
+		return nil, err
+	}
+	// Initial state
+	zkOnacci, err := contracts.NewZKOnacciCaller(m.ZKOnacci.Address, backend)
+	if err != nil {
+		return nil, err
+	}
+	opts := &bind.CallOpts{Context: ctx, BlockNumber: new(big.Int).SetUint64(m.ZKOnacci.BlockNumber)}
+	nPuzzles, err := zkOnacci.NPuzzles(opts)
+	if err != nil {
+		return nil, fmt.Errorf("error reading the state of the deployment block %d: %w", m.ZKOnacci.BlockNumber, err)
+	}
+	r.add("nPuzzles", nPuzzles.Cmp(big.NewInt(1)) == 0, fmt.Sprintf("got %s, expected 1", nPuzzles))
+	// The constructor sets up the puzzle 0
+	puzzle := big.NewInt(0)
+	verifierAddr, err := zkOnacci.Verifier(opts, puzzle)
+	if err != nil {
+		return nil, err
+	}
+	r.add("verifier", verifierAddr == m.Verifier.Address,
+		fmt.Sprintf("got %s, expected %s", verifierAddr.Hex(), m.Verifier.Address.Hex()))
+	root, err := zkOnacci.Root(opts, puzzle)
+	if err != nil {
+		return nil, err
+	}
+	r.add("root", root.Cmp(def.GenesisRoot) == 0, fmt.Sprintf("got %s, expected %s", root, def.GenesisRoot))
+	tokenCounter, err := zkOnacci.TokenCounter(opts, puzzle)
+	if err != nil {
+		return nil, err
+	}
+	r.add("tokenCounter", tokenCounter.Sign() == 0, fmt.Sprintf("got %s, expected 0", tokenCounter))
+	owner, err := zkOnacci.Owner(opts)
+	if err != nil {
+		return nil, err
+	}
+	r.add("owner", owner == m.Deployer, fmt.Sprintf("got %s, expected %s", owner.Hex(), m.Deployer.Hex()))
+	baseURI, err := zkOnacci.BaseURI(opts, puzzle)
+	if err != nil {
+		return nil, err
+	}
+	r.add("baseURI", baseURI == def.BaseURI, fmt.Sprintf("got %s, expected %s", baseURI, def.BaseURI))
+	nTiers, err := zkOnacci.NTiers(opts, puzzle)
+	if err != nil {
+		return nil, err
+	}
+	r.add("nTiers", int(nTiers) == len(def.Tiers), fmt.Sprintf("got %d, expected %d", nTiers, len(def.Tiers)))
+	for i, tier := range def.Tiers {
+		if i >= int(nTiers) {
+			break
+		}
+		lastTokenID, err := zkOnacci.TokenTiers(opts, puzzle, big.NewInt(int64(i)))
+		if err != nil {
+			return nil, err
+		}
+		r.add(fmt.Sprintf("tokenTiers[%d]", i), lastTokenID == tier.LastTokenID,
+			fmt.Sprintf("got %d, expected %d", lastTokenID, tier.LastTokenID))
+		uri, err := zkOnacci.TokenURIs(opts, puzzle, big.NewInt(int64(i)))
+		if err != nil {
+			return nil, err
+		}
+		r.add(fmt.Sprintf("tokenURIs[%d]", i), uri == tier.URI, fmt.Sprintf("got %s, expected %s", uri, tier.URI))
+	}
+	// Older manifests don't have the hints contract
+	if m.Hints.Address != (common.Address{}) {
+		if err := r.checkHints(ctx, backend, m, chainID); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// checkHints checks the code of the hints contract of the manifest and that it was owned by the deployer
+// on its deployment block
+func (r *Report) checkHints(ctx context.Context, backend bind.ContractCaller, m *manifest.Manifest, chainID *big.Int) error {
+	args, err := HintsArgs(m.ZKOnacci.Address, m.Deployer)
+	if err != nil {
+		return err
+	}
+	if err := r.checkCode(ctx, backend, "hints code", m.Hints.Address, chainID, contracts.ZKOnacciHintsBin, args); err != nil {
+		return err
+	}
+	hints, err := contracts.NewZKOnacciHintsCaller(m.Hints.Address, backend)
+	if err != nil {
+		return err
+	}
+	owner, err := hints.Owner(&bind.CallOpts{Context: ctx, BlockNumber: new(big.Int).SetUint64(m.Hints.BlockNumber)})
+	if err != nil {
+		return fmt.Errorf("error reading the state of the deployment block %d: %w", m.Hints.BlockNumber, err)
+	}
+	r.add("hints owner", owner == m.Deployer, fmt.Sprintf("got %s, expected %s", owner.Hex(), m.Deployer.Hex()))
+	return nil
+}
+
+// checkCode compares the code at addr with the runtime code produced by the constructor of bin (run with args)
+func (r *Report) checkCode(
+	ctx context.Context,
+	backend bind.ContractCaller,
+	name string,
+	addr common.Address,
+	chainID *big.Int,
+	bin string,
+	args []byte,
+) error {
+	code, err := backend.CodeAt(ctx, addr, nil)
+	if err != nil {
+		return err
+	}
+	matches, err := CodeMatches(code, bin, args, chainID)
+	if err != nil {
+		return err
+	}
+	switch {
+	case len(code) == 0:
+		r.add(name, false, fmt.Sprintf("no code at %s", addr.Hex()))
+	case !matches:
+		r.add(name, false, fmt.Sprintf("the code at %s doesn't match the compiled artifacts", addr.Hex()))
+	default:
+		r.add(name, true, addr.Hex())
+	}
+	return nil
+}
+
+// ZKOnacciArgs returns the ABI encoded constructor args of zkOnacci for the game of def, owned by owner
+func ZKOnacciArgs(verifierAddr, owner common.Address, def *game.Definition) ([]byte, error) {
+	zkOnacciABI, err := abi.JSON(strings.NewReader(contracts.ZKOnacciABI))
+	if err != nil {
+		return nil, err
+	}
+	return zkOnacciABI.Pack("", verifierAddr, def.GenesisRoot, def.BaseURI, def.TokenTiers(), def.TokenURIs(), owner)
+}
+
+// HintsArgs returns the ABI encoded constructor args of the hints contract of zkOnacci
+func HintsArgs(zkOnacciAddr, owner common.Address) ([]byte, error) {
+	hintsABI, err := abi.JSON(strings.NewReader(contracts.ZKOnacciHintsABI))
+	if err != nil {
+		return nil, err
+	}
+	return hintsABI.Pack("", zkOnacciAddr, owner)
+}
+
+// CodeMatches returns true if code is the runtime code produced by the constructor of bin (run with args),
+// ignoring the metadata
+func CodeMatches(code []byte, bin string, args []byte, chainID *big.Int) (bool, error) {
+	expected, err := RuntimeCode(bin, args, chainID)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(StripMetadata(code), StripMetadata(expected)), nil
+}
+
+// RuntimeCode runs the constructor of bin with the ABI encoded args on an empty chain with the given chain ID and
+// returns the code it deploys. Immutables that depend on the args or the chain ID get the same values as on chain
+func RuntimeCode(bin string, args []byte, chainID *big.Int) ([]byte, error) {
+	creationCode, err := hex.DecodeString(strings.TrimPrefix(bin, "0x"))
+	if err != nil {
+		return nil, err
+	}
+	chainConfig := *params.AllEthashProtocolChanges
+	chainConfig.ChainID = chainID
+	code, _, _, err := runtime.Create(append(creationCode, args...), &runtime.Config{ChainConfig: &chainConfig})
+	return code, err
+}
+
+// StripMetadata removes the CBOR encoded metadata that solc appends to the runtime code, which changes with
+// the source paths and comments even if the code doesn't
+func StripMetadata(code []byte) []byte {
+	if len(code) < 2 {
+		return code
+	}
+	metadataLen := int(code[len(code)-2])<<8 | int(code[len(code)-1])
+	if metadataLen+2 > len(code) {
+		return code
+	}
+	// The metadata is a CBOR map
+	start := len(code) - 2 - metadataLen
+	if code[start]&0xe0 != 0xa0 {
+		return code
+	}
+	return code[:start]
+}
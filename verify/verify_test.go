@@ -0,0 +1,121 @@
+package verify
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/arnaubennassar/zkOnacci/contracts"
+	"github.com/arnaubennassar/zkOnacci/game"
+	"github.com/arnaubennassar/zkOnacci/manifest"
+	"github.com/arnaubennassar/zkOnacci/testutil"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func failedChecks(r *Report) []string {
+	failed := []string{}
+	for _, c := range r.Checks {
+		if !c.OK {
+			failed = append(failed, c.Name)
+		}
+	}
+	return failed
+}
+
+func TestRuntimeCode(t *testing.T) {
+	ctx := context.Background()
+	privateKey := testutil.NewKey(t)
+	backend := testutil.NewSimulatedBackend(privateKey)
+	verifierAddr, _, _ := testutil.Deploy(t, backend, testutil.NewTransactor(t, privateKey))
+	code, err := backend.CodeAt(ctx, verifierAddr, nil)
+	require.NoError(t, err)
+	expected, err := RuntimeCode(contracts.VerifierBin, nil, big.NewInt(testutil.ChainID))
+	require.NoError(t, err)
+	assert.Equal(t, code, expected)
+	// The metadata is ignored, but not the code
+	stripped := StripMetadata(code)
+	require.Less(t, len(stripped), len(code))
+	otherMetadata := append([]byte{}, code...)
+	otherMetadata[len(otherMetadata)-3] ^= 0xff
+	assert.Equal(t, stripped, StripMetadata(otherMetadata))
+	otherCode := append([]byte{}, code...)
+	otherCode[0] ^= 0xff
+	assert.NotEqual(t, stripped, StripMetadata(otherCode))
+}
+
+func TestDeployment(t *testing.T) {
+	ctx := context.Background()
+	privateKey := testutil.NewKey(t)
+	backend := testutil.NewSimulatedBackend(privateKey)
+	auth := testutil.NewTransactor(t, privateKey)
+	verifierAddr, scAddr, _ := testutil.Deploy(t, backend, auth)
+	m := &manifest.Manifest{
+		ChainID:  testutil.ChainID,
+		Deployer: auth.From,
+		Verifier: manifest.Deployment{Address: verifierAddr, BlockNumber: 1},
+		ZKOnacci: manifest.Deployment{Address: scAddr, BlockNumber: 1},
+	}
+	def := testutil.LoadGame(t)
+	// Matching deployment
+	r, err := Deployment(ctx, backend, m, def)
+	require.NoError(t, err)
+	assert.True(t, r.OK(), failedChecks(r))
+	assert.Len(t, r.Checks, 17)
+	// Another contract at the address of the verifier (the verifier address is stored in the storage of zkOnacci,
+	// so its code doesn't change)
+	tampered := *m
+	tampered.Verifier.Address = scAddr
+	r, err = Deployment(ctx, backend, &tampered, def)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"verifier code", "verifier"}, failedChecks(r))
+	// No code
+	tampered = *m
+	tampered.Verifier.Address[0] ^= 0xff
+	r, err = Deployment(ctx, backend, &tampered, def)
+	require.NoError(t, err)
+	assert.Contains(t, failedChecks(r), "verifier code")
+	// Other game
+	otherGame := *def
+	otherGame.GenesisRoot = big.NewInt(1)
+	otherGame.BaseURI = "ipfs://"
+	r, err = Deployment(ctx, backend, m, &otherGame)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"root", "baseURI"}, failedChecks(r))
+	otherGame = *def
+	otherGame.Tiers = append([]game.Tier{}, def.Tiers...)
+	otherGame.Tiers[1].LastTokenID = 5
+	otherGame.Tiers[2].URI = game.RawCID([]byte("other metadata"))
+	r, err = Deployment(ctx, backend, m, &otherGame)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"tokenTiers[1]", "tokenURIs[2]"}, failedChecks(r))
+	otherGame = *def
+	otherGame.Tiers = def.Tiers[:3]
+	r, err = Deployment(ctx, backend, m, &otherGame)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"nTiers"}, failedChecks(r))
+
+	// Hints
+	hintsAddr, _ := testutil.DeployHints(t, backend, auth, scAddr)
+	withHints := *m
+	withHints.Hints = manifest.Deployment{Address: hintsAddr, BlockNumber: 2}
+	// The simulated backend only has the state of the latest block
+	withHints.ZKOnacci.BlockNumber = 2
+	r, err = Deployment(ctx, backend, &withHints, def)
+	require.NoError(t, err)
+	assert.True(t, r.OK(), failedChecks(r))
+	assert.Len(t, r.Checks, 19)
+	// Owned by another account
+	tampered = withHints
+	tampered.Deployer = common.Address{1}
+	r, err = Deployment(ctx, backend, &tampered, def)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"owner", "hints owner"}, failedChecks(r))
+	// Another contract at the address of the hints
+	tampered = withHints
+	tampered.Hints.Address = scAddr
+	r, err = Deployment(ctx, backend, &tampered, def)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"hints code"}, failedChecks(r))
+}